@@ -21,12 +21,13 @@ export class CartUseCases {
         return this.cartRepository.clearCart(userId);
     }
 
-    async checkout(userId: string, shippingAddress: any) {
+    // The order is placed on the user's behalf with their access token
+    async checkout(userId: string, shippingAddress: any, accessToken: string) {
         const cart = await this.cartRepository.getCart(userId);
         if (cart.items.length === 0) {
             throw new Error("Cart is empty");
         }
-        const orderResponse = await this.orderClient.createOrder(cart, shippingAddress);
+        const orderResponse = await this.orderClient.createOrder(cart, shippingAddress, accessToken);
         await this.clearCart(userId);
         return orderResponse;
     }
@@ -2,5 +2,6 @@ import { Cart } from "../entities/Cart";
 
 
 export interface IOrderClient {
-    createOrder(cart: Cart, shippingAddress: any): Promise<any>;
+    // accessToken is the user's bearer token; the order service requires it
+    createOrder(cart: Cart, shippingAddress: any, accessToken: string): Promise<any>;
 }
\ No newline at end of file
@@ -26,7 +26,7 @@ export class OrderClient implements IOrderClient {
         );
     }
 
-    async createOrder(cart: Cart, shippingAddress: any): Promise<any> {
+    async createOrder(cart: Cart, shippingAddress: any, accessToken: string): Promise<any> {
         const request = {
             user_id: cart.user_id,
             items: cart.items.map(item => ({
@@ -40,8 +40,12 @@ export class OrderClient implements IOrderClient {
             total_amount: cart.total_amount
         };
 
+        // The order service places orders only for the user the token is for
+        const metadata = new grpc.Metadata();
+        metadata.set('authorization', `Bearer ${accessToken}`);
+
         return new Promise((resolve, reject) => {
-            this.client.createOrder(request, (err: any, response: any) => {
+            this.client.createOrder(request, metadata, (err: any, response: any) => {
                 if (err) {
                     return reject(err);
                 }
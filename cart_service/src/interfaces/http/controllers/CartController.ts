@@ -69,7 +69,7 @@ export class CartController {
             const userId = req.user!.id;
             const { shippingAddress } = req.body;
 
-            const orderResponse = await this.cartUseCases.checkout(userId, shippingAddress);
+            const orderResponse = await this.cartUseCases.checkout(userId, shippingAddress, req.token!);
 
             res.status(201).json(orderResponse);
         } catch (error) {
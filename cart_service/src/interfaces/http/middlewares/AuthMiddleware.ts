@@ -6,6 +6,8 @@ export interface AuthRequest extends Request {
         id: string;
         roles: string[];
     };
+    // The verified bearer token, forwarded to the order service
+    token?: string;
 }
 
 export const authMiddleware = (req: AuthRequest, res: Response, next: NextFunction) => {
@@ -25,6 +27,7 @@ export const authMiddleware = (req: AuthRequest, res: Response, next: NextFuncti
             id: decoded.sub,
             roles: decoded.roles || [decoded.role] || []
         };
+        req.token = token;
 
         next();
     } catch (error) {
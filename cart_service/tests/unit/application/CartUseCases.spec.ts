@@ -76,10 +76,10 @@ describe('CartUseCases', () => {
             mockOrderClient.createOrder.mockResolvedValue(orderResponse);
             mockCartRepository.clearCart.mockResolvedValue(undefined);
 
-            const result = await cartUseCases.checkout(userId, shippingAddress);
+            const result = await cartUseCases.checkout(userId, shippingAddress, 'token-1');
 
             expect(result).toEqual(orderResponse);
-            expect(mockOrderClient.createOrder).toHaveBeenCalledWith(cart, shippingAddress);
+            expect(mockOrderClient.createOrder).toHaveBeenCalledWith(cart, shippingAddress, 'token-1');
             expect(mockCartRepository.clearCart).toHaveBeenCalledWith(userId);
         });
 
@@ -87,7 +87,7 @@ describe('CartUseCases', () => {
             const userId = 'user-123';
             mockCartRepository.getCart.mockResolvedValue({ items: [] } as any);
 
-            await expect(cartUseCases.checkout(userId, {})).rejects.toThrow('Cart is empty');
+            await expect(cartUseCases.checkout(userId, {}, 'token-1')).rejects.toThrow('Cart is empty');
         });
     });
 });
@@ -0,0 +1,63 @@
+import * as grpc from '@grpc/grpc-js';
+import * as protoLoader from '@grpc/proto-loader';
+import path from 'path';
+import { OrderClient } from '../../../src/infrastructure/grpc/OrderClient';
+
+// Serves CreateOrder from the proto the client is built on and records what
+// the client sent, as the order service would receive it.
+describe('OrderClient', () => {
+    let server: grpc.Server;
+    let received: { request: any; authorization: grpc.MetadataValue[] }[];
+
+    beforeAll(async () => {
+        const packageDefinition = protoLoader.loadSync(path.resolve(__dirname, '../../../proto/order.proto'), {
+            keepCase: true,
+            longs: String,
+            enums: String,
+            defaults: true,
+            oneofs: true,
+        });
+        const orderProto: any = (grpc.loadPackageDefinition(packageDefinition) as any).ecommerce.orders;
+
+        server = new grpc.Server();
+        server.addService(orderProto.OrderService.service, {
+            createOrder: (call: any, callback: any) => {
+                received.push({ request: call.request, authorization: call.metadata.get('authorization') });
+                callback(null, { order_id: 'ord-1', status: 'CREATED', created_at: '2026-05-01T09:00:00Z' });
+            },
+        });
+        const port = await new Promise<number>((resolve, reject) => {
+            server.bindAsync('127.0.0.1:0', grpc.ServerCredentials.createInsecure(), (err, port) => (err ? reject(err) : resolve(port)));
+        });
+        process.env.ORDER_SERVICE_URL = `127.0.0.1:${port}`;
+    });
+
+    afterAll(() => {
+        server.forceShutdown();
+    });
+
+    beforeEach(() => {
+        received = [];
+    });
+
+    it('places the order with the user\'s token as bearer metadata', async () => {
+        const client = new OrderClient();
+        const cart = {
+            user_id: 'user-1',
+            items: [{ productId: 'prod-1', productName: 'Lamp', unitPrice: 40, quantity: 2 }],
+            total_amount: 80,
+        };
+        const shippingAddress = { full_name: 'Abebe Kebede', phone: '+251911234567', city: 'Addis Ababa', street: 'Bole Road' };
+
+        const response = await client.createOrder(cart, shippingAddress, 'token-1');
+
+        expect(response.order_id).toBe('ord-1');
+        expect(received).toHaveLength(1);
+        expect(received[0].authorization).toEqual(['Bearer token-1']);
+        expect(received[0].request.user_id).toBe('user-1');
+        expect(received[0].request.items).toEqual([
+            expect.objectContaining({ product_id: 'prod-1', product_name: 'Lamp', unit_price: 40, quantity: 2 }),
+        ]);
+        expect(received[0].request.shipping_address).toEqual(shippingAddress);
+    });
+});
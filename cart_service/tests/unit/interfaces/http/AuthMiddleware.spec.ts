@@ -29,6 +29,7 @@ describe('AuthMiddleware', () => {
         expect(nextFunction).toHaveBeenCalled();
         expect(mockRequest.user).toBeDefined();
         expect(mockRequest.user?.id).toBe('user-1');
+        expect(mockRequest.token).toBe(token);
     });
 
     it('should return 401 if authorization header is missing', () => {
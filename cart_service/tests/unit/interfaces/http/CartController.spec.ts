@@ -25,6 +25,7 @@ describe('CartController', () => {
 
         mockRequest = {
             user: { id: 'user-1', roles: [] },
+            token: 'token-1',
             body: {},
             params: {},
             headers: {}
@@ -97,7 +98,7 @@ describe('CartController', () => {
             mockRequest.body = { shippingAddress: { city: 'Test' } };
             mockCartUseCases.checkout.mockResolvedValue({ orderId: 'ord-1' } as any);
             await controller.checkout(mockRequest as AuthRequest, mockResponse as Response);
-            expect(mockCartUseCases.checkout).toHaveBeenCalledWith('user-1', { city: 'Test' });
+            expect(mockCartUseCases.checkout).toHaveBeenCalledWith('user-1', { city: 'Test' }, 'token-1');
             expect(mockResponse.status).toHaveBeenCalledWith(201);
             expect(mockResponse.json).toHaveBeenCalledWith({ orderId: 'ord-1' });
         });
@@ -1,5 +1,21 @@
 module github.com/Asfm445/Distributed_EcommerceProject/delivery_service
 
-go 1.24
+go 1.24.0
 
-require github.com/rabbitmq/amqp091-go v1.10.0
+require (
+	github.com/Asfm445/Distributed_EcommerceProject/shared v0.0.0-00010101000000-000000000000
+	github.com/rabbitmq/amqp091-go v1.10.0
+	google.golang.org/grpc v1.78.0
+	google.golang.org/protobuf v1.36.11
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251029180050-ab9386a59fda // indirect
+)
+
+replace github.com/Asfm445/Distributed_EcommerceProject/shared => ../shared
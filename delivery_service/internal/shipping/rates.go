@@ -0,0 +1,196 @@
+// Package shipping prices shipments from a table of rates by destination
+// zone, item count and weight.
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rate prices a shipment: the base fee, plus a fee per item and per started
+// kilogram. Shipments of goods worth at least FreeOver ship free; 0 never
+// does.
+type Rate struct {
+	BaseFee  float64 `yaml:"base_fee"`
+	PerItem  float64 `yaml:"per_item"`
+	PerKg    float64 `yaml:"per_kg"`
+	FreeOver float64 `yaml:"free_over"`
+}
+
+// Zone is a set of destinations sharing a rate. A zone listing cities covers
+// those cities, within its countries if it lists any; a zone listing only
+// countries covers all of them.
+type Zone struct {
+	Name      string   `yaml:"name"`
+	Countries []string `yaml:"countries"`
+	Cities    []string `yaml:"cities"`
+	Rate      `yaml:",inline"`
+}
+
+// Table is the content of a rates file:
+//
+//	currency: ETB
+//	default_item_weight_kg: 0.5
+//	default: {base_fee: 250, per_kg: 20}
+//	zones:
+//	  - name: addis-ababa
+//	    countries: [ET]
+//	    cities: [Addis Ababa]
+//	    base_fee: 60
+//	    per_item: 5
+//	    free_over: 2000
+//	  - name: ethiopia
+//	    countries: [ET]
+//	    base_fee: 150
+//	    per_kg: 10
+//
+// Destinations outside every zone get the default rate. Countries and cities
+// match case-insensitively.
+type Table struct {
+	Currency            string  `yaml:"currency"`
+	DefaultItemWeightKg float64 `yaml:"default_item_weight_kg"`
+	Default             Rate    `yaml:"default"`
+	Zones               []Zone  `yaml:"zones"`
+}
+
+// DefaultTable ships everything for a flat fee until a rates file is set.
+func DefaultTable() Table {
+	return Table{
+		Currency:            "ETB",
+		DefaultItemWeightKg: 0.5,
+		Default:             Rate{BaseFee: 100},
+	}
+}
+
+// Load reads a rates file.
+func Load(path string) (*Table, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("shipping: %w", err)
+	}
+	var table Table
+	if err := yaml.Unmarshal(b, &table); err != nil {
+		return nil, fmt.Errorf("shipping: %s: %w", path, err)
+	}
+	if err := table.Validate(); err != nil {
+		return nil, fmt.Errorf("shipping: %s: %w", path, err)
+	}
+	return &table, nil
+}
+
+// Validate checks zones are named and fees and weights are not negative.
+func (t *Table) Validate() error {
+	var errs []error
+	check := func(where string, r Rate) {
+		if r.BaseFee < 0 || r.PerItem < 0 || r.PerKg < 0 || r.FreeOver < 0 {
+			errs = append(errs, fmt.Errorf("%s: fees and thresholds must not be negative", where))
+		}
+	}
+	if t.Currency == "" {
+		errs = append(errs, errors.New("currency is required"))
+	}
+	if t.DefaultItemWeightKg < 0 {
+		errs = append(errs, errors.New("default_item_weight_kg must not be negative"))
+	}
+	check("default", t.Default)
+	for i, z := range t.Zones {
+		if z.Name == "" {
+			errs = append(errs, fmt.Errorf("zone %d: name is required", i))
+		}
+		if len(z.Countries) == 0 && len(z.Cities) == 0 {
+			errs = append(errs, fmt.Errorf("zone %s: countries or cities are required", z.Name))
+		}
+		check("zone "+z.Name, z.Rate)
+	}
+	return errors.Join(errs...)
+}
+
+// Destination is where a shipment goes.
+type Destination struct {
+	Country string
+	Region  string
+	City    string
+}
+
+// Parcel is a quantity of one product. A zero weight is the table's default
+// item weight.
+type Parcel struct {
+	Quantity int
+	WeightKg float64
+}
+
+// Quote is the price of a shipment.
+type Quote struct {
+	Amount       float64
+	Currency     string
+	Zone         string
+	FreeShipping bool
+	ItemCount    int
+	WeightKg     float64
+}
+
+// DefaultZone names the zone of destinations outside every zone.
+const DefaultZone = "default"
+
+// Quote prices the shipment of parcels worth orderValue to a destination.
+func (t *Table) Quote(dest Destination, parcels []Parcel, orderValue float64) Quote {
+	zone, rate := t.zoneOf(dest)
+	q := Quote{Currency: t.Currency, Zone: zone}
+	for _, p := range parcels {
+		if p.Quantity <= 0 {
+			continue
+		}
+		weight := p.WeightKg
+		if weight <= 0 {
+			weight = t.DefaultItemWeightKg
+		}
+		q.ItemCount += p.Quantity
+		q.WeightKg += weight * float64(p.Quantity)
+	}
+	q.WeightKg = math.Ceil(q.WeightKg)
+	if rate.FreeOver > 0 && orderValue >= rate.FreeOver {
+		q.FreeShipping = true
+		return q
+	}
+	fee := rate.BaseFee + rate.PerItem*float64(q.ItemCount) + rate.PerKg*q.WeightKg
+	q.Amount = math.Round(fee*100) / 100
+	return q
+}
+
+// zoneOf picks the zone of a destination: a zone listing its city first,
+// then one listing its country.
+func (t *Table) zoneOf(dest Destination) (string, Rate) {
+	var byCountry *Zone
+	for i := range t.Zones {
+		z := &t.Zones[i]
+		inCountry := contains(z.Countries, dest.Country)
+		if len(z.Cities) > 0 {
+			if contains(z.Cities, dest.City) && (len(z.Countries) == 0 || inCountry) {
+				return z.Name, z.Rate
+			}
+			continue
+		}
+		if inCountry && byCountry == nil {
+			byCountry = z
+		}
+	}
+	if byCountry != nil {
+		return byCountry.Name, byCountry.Rate
+	}
+	return DefaultZone, t.Default
+}
+
+func contains(list []string, s string) bool {
+	s = strings.TrimSpace(s)
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
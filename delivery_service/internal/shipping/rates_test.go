@@ -0,0 +1,114 @@
+package shipping
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/delivery_service/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ratesFile = `
+currency: ETB
+default_item_weight_kg: 0.5
+default: {base_fee: 250, per_kg: 20}
+zones:
+  - name: addis-ababa
+    countries: [ET]
+    cities: [Addis Ababa]
+    base_fee: 60
+    per_item: 5
+    free_over: 2000
+  - name: ethiopia
+    countries: [et]
+    base_fee: 150
+    per_kg: 10
+`
+
+func loadTable(t *testing.T) *Table {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rates.yaml")
+	if err := os.WriteFile(path, []byte(ratesFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	table, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestTable_Quote(t *testing.T) {
+	table := loadTable(t)
+	parcels := []Parcel{{Quantity: 2, WeightKg: 1.2}, {Quantity: 3}}
+
+	tests := []struct {
+		name       string
+		dest       Destination
+		orderValue float64
+		want       Quote
+	}{
+		{
+			name: "city zone",
+			dest: Destination{Country: "ET", City: " addis ababa"},
+			want: Quote{Amount: 85, Currency: "ETB", Zone: "addis-ababa", ItemCount: 5, WeightKg: 4},
+		},
+		{
+			name:       "free over the threshold",
+			dest:       Destination{Country: "ET", City: "Addis Ababa"},
+			orderValue: 2000,
+			want:       Quote{Currency: "ETB", Zone: "addis-ababa", FreeShipping: true, ItemCount: 5, WeightKg: 4},
+		},
+		{
+			name: "country zone",
+			dest: Destination{Country: "ET", City: "Hawassa"},
+			want: Quote{Amount: 190, Currency: "ETB", Zone: "ethiopia", ItemCount: 5, WeightKg: 4},
+		},
+		{
+			name: "city in another country",
+			dest: Destination{Country: "KE", City: "Addis Ababa"},
+			want: Quote{Amount: 330, Currency: "ETB", Zone: DefaultZone, ItemCount: 5, WeightKg: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Quote(tt.dest, parcels, tt.orderValue); got != tt.want {
+				t.Errorf("Quote() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_RejectsInvalidTables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.yaml")
+	if err := os.WriteFile(path, []byte("zones:\n  - name: x\n    base_fee: -1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() accepted a table without currency, destinations and with a negative fee")
+	}
+}
+
+func TestServer_QuoteShipping(t *testing.T) {
+	s := NewServer(DefaultTable())
+
+	q, err := s.QuoteShipping(context.Background(), &pb.QuoteShippingRequest{
+		Destination: &pb.Destination{Country: "ET", City: "Adama"},
+		Items:       []*pb.Parcel{{Quantity: 2}},
+		Currency:    "ETB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Amount != 100 || q.Zone != DefaultZone || q.ItemCount != 2 || q.WeightKg != 1 {
+		t.Errorf("QuoteShipping() = %v", q)
+	}
+
+	_, err = s.QuoteShipping(context.Background(), &pb.QuoteShippingRequest{Destination: &pb.Destination{}, Currency: "USD"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("QuoteShipping() in another currency = %v, want InvalidArgument", err)
+	}
+}
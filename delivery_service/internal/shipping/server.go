@@ -0,0 +1,49 @@
+package shipping
+
+import (
+	"context"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/delivery_service/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server serves QuoteShipping from a rate table.
+type Server struct {
+	pb.UnimplementedDeliveryServiceServer
+	table Table
+}
+
+func NewServer(table Table) *Server {
+	return &Server{table: table}
+}
+
+func (s *Server) QuoteShipping(_ context.Context, req *pb.QuoteShippingRequest) (*pb.ShippingQuote, error) {
+	if req.Destination == nil {
+		return nil, status.Error(codes.InvalidArgument, "destination is required")
+	}
+	if req.Currency != "" && req.Currency != s.table.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "rates are in %s, not %s", s.table.Currency, req.Currency)
+	}
+	var parcels []Parcel
+	for _, item := range req.Items {
+		if item.Quantity < 0 || item.WeightKg < 0 {
+			return nil, status.Error(codes.InvalidArgument, "quantities and weights must not be negative")
+		}
+		parcels = append(parcels, Parcel{Quantity: int(item.Quantity), WeightKg: item.WeightKg})
+	}
+
+	q := s.table.Quote(Destination{
+		Country: req.Destination.Country,
+		Region:  req.Destination.Region,
+		City:    req.Destination.City,
+	}, parcels, req.OrderValue)
+	return &pb.ShippingQuote{
+		Amount:       q.Amount,
+		Currency:     q.Currency,
+		Zone:         q.Zone,
+		FreeShipping: q.FreeShipping,
+		ItemCount:    int32(q.ItemCount),
+		WeightKg:     q.WeightKg,
+	}, nil
+}
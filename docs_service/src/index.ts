@@ -2,6 +2,7 @@ import express, { Request, Response } from "express";
 import swaggerUi from "swagger-ui-express";
 import axios from "axios";
 import dotenv from "dotenv";
+import { isSwagger2, toOpenApi3 } from "./swagger2";
 
 dotenv.config();
 
@@ -11,13 +12,16 @@ const PORT = 8081;
 const USER_SERVICE_URL = process.env.USER_SERVICE_URL || "http://user-service:3000";
 const PRODUCT_SERVICE_URL = process.env.PRODUCT_SERVICE_URL || "http://product-management:8000";
 const CART_SERVICE_URL = process.env.CART_SERVICE_URL || "http://cart-service:8001";
+const ORDER_SERVICE_URL = process.env.ORDER_SERVICE_URL || "http://order-service:8080";
 
 app.get("/swagger-json", async (_req: Request, res: Response) => {
     try {
-        const [userSpec, productSpec, cartSpec] = await Promise.all([
+        const [userSpec, productSpec, cartSpec, orderSpec] = await Promise.all([
             axios.get(`${USER_SERVICE_URL}/swagger.json`).then(r => r.data).catch(() => null),
             axios.get(`${PRODUCT_SERVICE_URL}/swagger.json`).then(r => r.data).catch(() => null),
-            axios.get(`${CART_SERVICE_URL}/swagger.json`).then(r => r.data).catch(() => null)
+            axios.get(`${CART_SERVICE_URL}/swagger.json`).then(r => r.data).catch(() => null),
+            // Generated from the gRPC definitions as Swagger 2.0
+            axios.get(`${ORDER_SERVICE_URL}/swagger.json`).then(r => r.data).catch(() => null)
         ]);
 
         const mergedSpec: any = {
@@ -32,7 +36,7 @@ app.get("/swagger-json", async (_req: Request, res: Response) => {
             components: { schemas: {}, securitySchemes: {} }
         };
 
-        const specs = [userSpec, productSpec, cartSpec];
+        const specs = [userSpec, productSpec, cartSpec, orderSpec].map(spec => (isSwagger2(spec) ? toOpenApi3(spec) : spec));
         specs.forEach(spec => {
             if (spec) {
                 Object.assign(mergedSpec.paths, spec.paths);
@@ -0,0 +1,70 @@
+// Converts the Swagger 2.0 documents produced by protoc-gen-openapiv2 (the
+// Go services' REST gateways) into OpenAPI 3 so they can be merged with the
+// Node services' specs. Only the constructs emitted by the generator are
+// handled: definitions, body/path/query parameters and JSON responses.
+
+const SCHEMA_KEYS = ["type", "format", "items", "enum", "default", "collectionFormat"];
+
+const rewriteRefs = (value: any): any =>
+    JSON.parse(JSON.stringify(value).replace(/"#\/definitions\//g, '"#/components/schemas/'));
+
+const convertParameter = (param: any) => {
+    const schema: any = {};
+    const out: any = {};
+    Object.entries(param).forEach(([key, value]) => {
+        if (key === "collectionFormat") {
+            if (value === "multi") out.explode = true;
+        } else if (SCHEMA_KEYS.includes(key)) {
+            schema[key] = value;
+        } else {
+            out[key] = value;
+        }
+    });
+    out.schema = schema;
+    return out;
+};
+
+const convertOperation = (operation: any) => {
+    const { parameters = [], responses = {}, consumes, produces, ...rest } = operation;
+    const out: any = { ...rest, parameters: [], responses: {} };
+
+    parameters.forEach((param: any) => {
+        if (param.in === "body") {
+            out.requestBody = {
+                required: param.required ?? false,
+                description: param.description,
+                content: { "application/json": { schema: param.schema } }
+            };
+        } else {
+            out.parameters.push(convertParameter(param));
+        }
+    });
+
+    Object.entries(responses).forEach(([code, response]: [string, any]) => {
+        const { schema, ...fields } = response;
+        out.responses[code] = schema
+            ? { ...fields, content: { "application/json": { schema } } }
+            : fields;
+    });
+    return out;
+};
+
+export const isSwagger2 = (spec: any): boolean => typeof spec?.swagger === "string" && spec.swagger.startsWith("2.");
+
+export const toOpenApi3 = (spec: any): any => {
+    const paths: any = {};
+    Object.entries(spec.paths ?? {}).forEach(([path, item]: [string, any]) => {
+        paths[path] = {};
+        Object.entries(item).forEach(([method, operation]) => {
+            paths[path][method] = convertOperation(operation);
+        });
+    });
+
+    return rewriteRefs({
+        openapi: "3.0.0",
+        info: spec.info,
+        tags: spec.tags,
+        paths,
+        components: { schemas: spec.definitions ?? {} }
+    });
+};
@@ -1,3 +1,4 @@
 CREATE DATABASE order_db;
+CREATE DATABASE payment_db;
 CREATE DATABASE product_db;
 CREATE DATABASE ecommerce_db;
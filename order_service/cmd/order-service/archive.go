@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/config"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/archive"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/persistence"
+	"gorm.io/gorm"
+)
+
+const archiveUsage = `usage: order-service [flags] archive
+
+Archives the orders due and exports them as configured, then exits.`
+
+// newArchiveUseCase archives the orders of db as configured.
+func newArchiveUseCase(cfg *config.Config, db *gorm.DB) (*usecases.ArchiveOrdersUseCase, error) {
+	// Returns of delivered orders are requested from the live tables.
+	if cfg.Archive.After < cfg.Returns.Window {
+		return nil, fmt.Errorf("archive.after (%s) must be at least returns.window (%s)", cfg.Archive.After, cfg.Returns.Window)
+	}
+	var store domain.ArchiveStore
+	if cfg.Archive.ExportURL != "" {
+		var err error
+		if store, err = archive.Open(cfg.Archive.ExportURL); err != nil {
+			return nil, err
+		}
+	}
+	return usecases.NewArchiveOrdersUseCase(persistence.NewPostgresArchiveRepository(db), store, cfg.Archive.After, cfg.Archive.BatchSize), nil
+}
+
+// runArchive runs the archive subcommand.
+func runArchive(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return errors.New(archiveUsage)
+	}
+	db, err := openDatabase(cfg.Database.URL, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("connecting to the database: %w", err)
+	}
+	uc, err := newArchiveUseCase(cfg, db)
+	if err != nil {
+		return err
+	}
+	result, err := uc.Run(context.Background())
+	log.Printf("Archived %d orders, exported %d", result.Archived, result.Exported)
+	return err
+}
+
+// scheduleArchive archives every interval, logging failures, until ctx is
+// done.
+func scheduleArchive(ctx context.Context, uc *usecases.ArchiveOrdersUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		result, err := uc.Run(ctx)
+		if result.Archived > 0 || result.Exported > 0 {
+			log.Printf("Archived %d orders, exported %d", result.Archived, result.Exported)
+		}
+		if err != nil {
+			log.Printf("Archiving orders: %v", err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/config"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/schema"
+)
+
+const migrateUsage = `usage: order-service [flags] migrate <command>
+
+commands:
+  up          apply all pending migrations
+  down [N]    revert the last N migrations, 1 by default
+  goto V      migrate up or down to version V
+  version     print the applied version
+  force V     mark version V as applied and clean without running it,
+              after repairing a failed migration by hand`
+
+// runMigrate runs the migrate subcommand.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	command, args := args[0], args[1:]
+	arity := map[string][2]int{"up": {0, 0}, "down": {0, 1}, "goto": {1, 1}, "version": {0, 0}, "force": {1, 1}}
+	n, ok := arity[command]
+	if !ok || len(args) < n[0] || len(args) > n[1] {
+		return errors.New(migrateUsage)
+	}
+	var number int
+	if len(args) == 1 {
+		var err error
+		if number, err = strconv.Atoi(args[0]); err != nil || number < 0 {
+			return fmt.Errorf("%s: %q is not a migration number\n\n%s", command, args[0], migrateUsage)
+		}
+	}
+
+	m, err := schema.Open(cfg.Database.URL, cfg.Migrations.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch command {
+	case "up":
+		err = m.Up()
+	case "down":
+		err = m.Down(max(number, 1))
+	case "goto":
+		err = m.Goto(uint(number))
+	case "force":
+		err = m.Force(number)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		fmt.Printf("%d (dirty)\n", version)
+	} else {
+		fmt.Println(version)
+	}
+	if command != "version" {
+		log.Printf("Database at version %d; the latest migration is %d", version, m.Latest())
+	}
+	return nil
+}
+
+// prepareSchema handles the schema at boot as configured.
+func prepareSchema(cfg *config.Config) error {
+	if cfg.Migrations.OnStart == config.MigrateSkip {
+		log.Println("Skipping schema migration and verification")
+		return nil
+	}
+	m, err := schema.Open(cfg.Database.URL, cfg.Migrations.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if cfg.Migrations.OnStart == config.MigrateUp {
+		if err := m.Up(); err != nil {
+			return fmt.Errorf("running migrations: %w", err)
+		}
+		log.Println("Migrations completed successfully")
+		return nil
+	}
+	if err := m.Verify(); err != nil {
+		return fmt.Errorf("%w; run `order-service migrate up`", err)
+	}
+	log.Println("Database schema is up to date")
+	return nil
+}
@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
@@ -18,11 +20,17 @@ func main() {
 	defer conn.Close()
 	c := pb.NewOrderServiceClient(conn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	// The order RPCs act on the user of the access token.
+	token := os.Getenv("ACCESS_TOKEN")
+	if token == "" {
+		log.Fatal("ACCESS_TOKEN must hold an access token issued by the user service")
+	}
+	authCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+
+	ctx, cancel := context.WithTimeout(authCtx, time.Second*5)
 	defer cancel()
 
 	r, err := c.CreateOrder(ctx, &pb.CreateOrderRequest{
-		UserId: "550e8400-e29b-41d4-a716-446655440000",
 		Items: []*pb.OrderItem{
 			{
 				ProductId:   "550e8400-e29b-41d4-a716-446655440001",
@@ -44,4 +52,23 @@ func main() {
 		log.Fatalf("could not create order: %v", err)
 	}
 	log.Printf("Order created: %s", r.GetOrderId())
+
+	// Follow the order until it is delivered instead of polling GetOrderStatus
+	watchCtx, cancelWatch := context.WithTimeout(authCtx, time.Minute)
+	defer cancelWatch()
+
+	stream, err := c.WatchOrder(watchCtx, &pb.WatchOrderRequest{OrderId: r.GetOrderId()})
+	if err != nil {
+		log.Fatalf("could not watch order: %v", err)
+	}
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("watch ended: %v", err)
+		}
+		log.Printf("Order %s is %s (sequence %d)", ev.GetOrderId(), ev.GetStatus(), ev.GetSequence())
+		if ev.GetStatus() == "DELIVERED" {
+			return
+		}
+	}
 }
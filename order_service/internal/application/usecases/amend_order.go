@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAmendment is returned, wrapped with the reason, for an amendment
+// of unknown lines, with a negative quantity, that removes every line or that
+// changes nothing.
+var ErrInvalidAmendment = domain.NewError(domain.ErrInvalidArgument, "INVALID_AMENDMENT", "invalid amendment")
+
+// AmendOrderInput describes changes to an unpaid order. Items sets the
+// quantity of existing lines, zero removing the line; lines cannot be added.
+// The addresses, when set, replace the current ones. AmendedBy identifies who
+// makes the change and defaults to the user.
+type AmendOrderInput struct {
+	OrderID         uuid.UUID
+	UserID          uuid.UUID
+	AmendedBy       string
+	Items           []ItemChangeInput
+	ShippingAddress *AddressInput
+	BillingAddress  *AddressInput
+}
+
+type ItemChangeInput struct {
+	ItemID   uuid.UUID
+	Quantity int
+}
+
+// AmendOrderUseCase changes the lines and addresses of an order until it is
+// paid. The order is priced again like a new one, and the payment service is
+// told the new amount through order.amended.
+type AmendOrderUseCase struct {
+	repo           domain.OrderRepository
+	promotions     domain.PromotionRepository
+	taxes          domain.TaxCalculator
+	shipping       domain.ShippingQuoter
+	defaultCountry string
+	eventProducer  domain.OrderEventProducer
+	emitTimeout    time.Duration
+	now            func() time.Time
+}
+
+func NewAmendOrderUseCase(repo domain.OrderRepository, promotions domain.PromotionRepository, taxes domain.TaxCalculator, shipping domain.ShippingQuoter, defaultCountry string, eventProducer domain.OrderEventProducer, emitTimeout time.Duration) *AmendOrderUseCase {
+	return &AmendOrderUseCase{
+		repo:           repo,
+		promotions:     promotions,
+		taxes:          taxes,
+		shipping:       shipping,
+		defaultCountry: defaultCountry,
+		eventProducer:  eventProducer,
+		emitTimeout:    emitTimeout,
+		now:            time.Now,
+	}
+}
+
+// Execute applies the changes to the user's order, prices it again and
+// records the amendment. The order is returned in its new state. When
+// another update lands first, the changes are applied again to the reloaded
+// order.
+func (uc *AmendOrderUseCase) Execute(ctx context.Context, input AmendOrderInput) (*domain.Order, error) {
+	for attempt := 0; ; attempt++ {
+		order, amendment, err := uc.amend(ctx, input)
+		if errors.Is(err, domain.ErrVersionConflict) && attempt < maxConflictRetries {
+			log.Printf("OrderID %s changed while amending it; retrying", input.OrderID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		go func() {
+			asyncCtx, cancel := context.WithTimeout(context.Background(), uc.emitTimeout)
+			defer cancel()
+			if err := uc.eventProducer.EmitOrderAmended(asyncCtx, order, amendment); err != nil {
+				log.Printf("Failed to emit order.amended for OrderID %s: %v", order.ID, err)
+			}
+		}()
+		return order, nil
+	}
+}
+
+// amend applies the changes to the order as currently stored.
+func (uc *AmendOrderUseCase) amend(ctx context.Context, input AmendOrderInput) (*domain.Order, *domain.OrderAmendment, error) {
+	order, err := uc.repo.GetOrderByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if order.UserID != input.UserID {
+		return nil, nil, domain.ErrOrderNotFound
+	}
+	if !order.Amendable() {
+		return nil, nil, domain.ErrOrderNotAmendable
+	}
+
+	now := uc.now()
+	amendment := &domain.OrderAmendment{
+		ID:            uuid.New(),
+		OrderID:       order.ID,
+		AmendedBy:     input.AmendedBy,
+		PreviousTotal: order.TotalAmount,
+		AmendedAt:     now,
+	}
+	if amendment.AmendedBy == "" {
+		amendment.AmendedBy = input.UserID.String()
+	}
+
+	quantities := map[uuid.UUID]int{}
+	for _, change := range input.Items {
+		_, seen := quantities[change.ItemID]
+		switch {
+		case findItem(order.Items, change.ItemID) == nil:
+			return nil, nil, fmt.Errorf("%w: item %s is not in the order", ErrInvalidAmendment, change.ItemID)
+		case seen:
+			return nil, nil, fmt.Errorf("%w: item %s is listed twice", ErrInvalidAmendment, change.ItemID)
+		case change.Quantity < 0:
+			return nil, nil, fmt.Errorf("%w: quantity of item %s must not be negative", ErrInvalidAmendment, change.ItemID)
+		}
+		quantities[change.ItemID] = change.Quantity
+	}
+
+	var items []domain.OrderItem
+	var removed []uuid.UUID
+	for _, item := range order.Items {
+		quantity, ok := quantities[item.ID]
+		if !ok || quantity == item.Quantity {
+			items = append(items, item)
+			continue
+		}
+		itemID := item.ID
+		amendment.Changes = append(amendment.Changes, domain.AmendmentChange{
+			Field:  domain.AmendQuantity,
+			ItemID: &itemID,
+			From:   strconv.Itoa(item.Quantity),
+			To:     strconv.Itoa(quantity),
+		})
+		if quantity == 0 {
+			removed = append(removed, item.ID)
+			continue
+		}
+		item.Quantity = quantity
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("%w: an order must keep at least one item; cancel it instead", ErrInvalidAmendment)
+	}
+
+	if err := uc.replaceAddress(order, amendment, domain.AddressShipping, domain.AmendShippingAddress, input.ShippingAddress); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.replaceAddress(order, amendment, domain.AddressBilling, domain.AmendBillingAddress, input.BillingAddress); err != nil {
+		return nil, nil, err
+	}
+	if len(amendment.Changes) == 0 {
+		return nil, nil, fmt.Errorf("%w: nothing to change", ErrInvalidAmendment)
+	}
+	shippingAddress := order.Address(domain.AddressShipping)
+	if shippingAddress == nil {
+		return nil, nil, fmt.Errorf("%w: the order has no shipping address", domain.ErrOrderNotAmendable)
+	}
+
+	discounts, err := uc.discounts(ctx, order, items)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := priceOrder(ctx, uc.taxes, uc.shipping, order, items, discounts, *shippingAddress); err != nil {
+		return nil, nil, err
+	}
+	order.Items = items
+	order.UpdatedAt = now
+	amendment.NewTotal = order.TotalAmount
+
+	if err := uc.repo.AmendOrder(ctx, order, removed, amendment); err != nil {
+		return nil, nil, err
+	}
+	return order, amendment, nil
+}
+
+// ListAmendments returns the amendments of an order, oldest first.
+func (uc *AmendOrderUseCase) ListAmendments(ctx context.Context, orderID uuid.UUID) ([]domain.OrderAmendment, error) {
+	if _, err := uc.repo.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListAmendments(ctx, orderID)
+}
+
+// replaceAddress replaces the order's address of the given kind, keeping its
+// ID, and records the change when the address differs.
+func (uc *AmendOrderUseCase) replaceAddress(order *domain.Order, amendment *domain.OrderAmendment, kind domain.AddressKind, field domain.AmendmentField, in *AddressInput) error {
+	if in == nil {
+		return nil
+	}
+	next, err := newAddress(order.ID, kind, *in, uc.defaultCountry)
+	if err != nil {
+		return err
+	}
+	current := order.Address(kind)
+	if current == nil {
+		amendment.Changes = append(amendment.Changes, domain.AmendmentChange{Field: field, To: next.OneLine()})
+		order.Addresses = append(order.Addresses, *next)
+		return nil
+	}
+	next.ID = current.ID
+	if next.OneLine() == current.OneLine() && sameCoordinates(next, current) {
+		return nil
+	}
+	amendment.Changes = append(amendment.Changes, domain.AmendmentChange{Field: field, From: current.OneLine(), To: next.OneLine()})
+	*current = *next
+	return nil
+}
+
+// discounts applies the promotions the order was placed with to its amended
+// lines. They were redeemed when the order was placed, so validity and usage
+// limits are not checked again. A promotion the amended order no longer
+// qualifies for is dropped; its redemption stands, as for a canceled order.
+func (uc *AmendOrderUseCase) discounts(ctx context.Context, order *domain.Order, items []domain.OrderItem) ([]domain.OrderDiscount, error) {
+	var promotions []domain.Promotion
+	for _, d := range order.Discounts {
+		p, err := uc.promotions.GetPromotion(ctx, d.PromotionID)
+		if errors.Is(err, domain.ErrPromotionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, *p)
+	}
+	discounts := domain.ApplyPromotions(items, promotions)
+	for i := range discounts {
+		discounts[i].ID = uuid.New()
+		discounts[i].OrderID = order.ID
+	}
+	return discounts, nil
+}
+
+func sameCoordinates(a, b *domain.OrderAddress) bool {
+	same := func(x, y *float64) bool {
+		return x == nil && y == nil || x != nil && y != nil && *x == *y
+	}
+	return same(a.Latitude, b.Latitude) && same(a.Longitude, b.Longitude)
+}
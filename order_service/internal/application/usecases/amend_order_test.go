@@ -0,0 +1,251 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type amendFixture struct {
+	orders     *MockOrderRepository
+	promotions *MockPromotionRepository
+	events     *MockEventProducer
+	uc         *AmendOrderUseCase
+	order      *domain.Order
+	promotion  domain.Promotion
+}
+
+// newAmendFixture returns an order of three lamps and a cable with 10% off,
+// taxed at 15% and shipped for 20.
+func newAmendFixture(t *testing.T, status domain.OrderStatus) *amendFixture {
+	f := &amendFixture{
+		orders:     new(MockOrderRepository),
+		promotions: new(MockPromotionRepository),
+		events:     new(MockEventProducer),
+		promotion:  domain.Promotion{ID: uuid.New(), Kind: domain.PromotionPercentage, Value: 10, Stackable: true, Active: true},
+	}
+	orderID := uuid.New()
+	shipping, err := newAddress(orderID, domain.AddressShipping, shippingTo, "ET")
+	require.NoError(t, err)
+	billing, err := newAddress(orderID, domain.AddressBilling, shippingTo, "ET")
+	require.NoError(t, err)
+	f.order = &domain.Order{
+		ID: orderID, UserID: uuid.New(), Status: status, Currency: "ETB", TotalAmount: 134.2,
+		Items: []domain.OrderItem{
+			{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 3},
+			{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), ProductName: "Cable", UnitPrice: 10, Quantity: 1},
+		},
+		Discounts: []domain.OrderDiscount{{ID: uuid.New(), OrderID: orderID, PromotionID: f.promotion.ID, Amount: 13}},
+		Addresses: []domain.OrderAddress{*shipping, *billing},
+	}
+	f.uc = NewAmendOrderUseCase(f.orders, f.promotions, flatTax(0.15), flatShipping(20), "ET", f.events, 5*time.Second)
+	f.uc.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC) }
+	return f
+}
+
+func TestAmendOrderUseCase_Execute(t *testing.T) {
+	f := newAmendFixture(t, domain.StatusPending)
+	ctx := context.Background()
+	lamp, cable := f.order.Items[0], f.order.Items[1]
+	oldShipping := f.order.Address(domain.AddressShipping)
+	shippingID, oldLine := oldShipping.ID, oldShipping.OneLine()
+	moved := shippingTo
+	moved.Street = "Churchill Avenue"
+
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.promotions.On("GetPromotion", ctx, f.promotion.ID).Return(&f.promotion, nil)
+	var amendment *domain.OrderAmendment
+	f.orders.On("AmendOrder", ctx, mock.Anything, []uuid.UUID{cable.ID}, mock.Anything).
+		Run(func(args mock.Arguments) { amendment = args.Get(3).(*domain.OrderAmendment) }).
+		Return(nil)
+	emitted := make(chan struct{})
+	f.events.On("EmitOrderAmended", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(mock.Arguments) { close(emitted) })
+
+	order, err := f.uc.Execute(ctx, AmendOrderInput{
+		OrderID:         f.order.ID,
+		UserID:          f.order.UserID,
+		Items:           []ItemChangeInput{{ItemID: lamp.ID, Quantity: 1}, {ItemID: cable.ID, Quantity: 0}},
+		ShippingAddress: &moved,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, 1, order.Items[0].Quantity)
+	assert.Equal(t, 40.0, order.Subtotal)
+	assert.Equal(t, 4.0, order.DiscountAmount, "the order's promotion applies to the new lines")
+	assert.Equal(t, 5.4, order.TaxAmount)
+	assert.Equal(t, 61.4, order.TotalAmount)
+	assert.Equal(t, "Churchill Avenue", order.Address(domain.AddressShipping).Street)
+	assert.Equal(t, shippingID, order.Address(domain.AddressShipping).ID, "the address keeps its ID")
+
+	require.NotNil(t, amendment)
+	assert.Equal(t, f.order.UserID.String(), amendment.AmendedBy)
+	assert.Equal(t, 134.2, amendment.PreviousTotal)
+	assert.Equal(t, 61.4, amendment.NewTotal)
+	assert.Equal(t, []domain.AmendmentChange{
+		{Field: domain.AmendQuantity, ItemID: &lamp.ID, From: "3", To: "1"},
+		{Field: domain.AmendQuantity, ItemID: &cable.ID, From: "1", To: "0"},
+		{Field: domain.AmendShippingAddress, From: oldLine, To: order.Address(domain.AddressShipping).OneLine()},
+	}, amendment.Changes)
+
+	select {
+	case <-emitted:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for EmitOrderAmended")
+	}
+	f.orders.AssertExpectations(t)
+}
+
+func TestAmendOrderUseCase_Execute_DropsPromotionNoLongerMet(t *testing.T) {
+	f := newAmendFixture(t, domain.StatusCreated)
+	ctx := context.Background()
+	f.promotion.MinSubtotal = 100
+
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.promotions.On("GetPromotion", ctx, f.promotion.ID).Return(&f.promotion, nil)
+	f.orders.On("AmendOrder", ctx, mock.Anything, []uuid.UUID(nil), mock.Anything).Return(nil)
+	f.events.On("EmitOrderAmended", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	order, err := f.uc.Execute(ctx, AmendOrderInput{
+		OrderID:   f.order.ID,
+		UserID:    f.order.UserID,
+		AmendedBy: "support-agent",
+		Items:     []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, order.Discounts)
+	assert.Equal(t, 90.0, order.Subtotal)
+	assert.Equal(t, 123.5, order.TotalAmount)
+}
+
+func TestAmendOrderUseCase_Execute_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.OrderStatus
+		input  func(f *amendFixture) AmendOrderInput
+		want   error
+	}{
+		{
+			name:   "paid order",
+			status: domain.StatusPaid,
+			input: func(f *amendFixture) AmendOrderInput {
+				return AmendOrderInput{Items: []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: 1}}}
+			},
+			want: domain.ErrOrderNotAmendable,
+		},
+		{
+			name: "another user's order",
+			input: func(f *amendFixture) AmendOrderInput {
+				return AmendOrderInput{UserID: uuid.New(), Items: []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: 1}}}
+			},
+			want: domain.ErrOrderNotFound,
+		},
+		{
+			name: "unknown item",
+			input: func(f *amendFixture) AmendOrderInput {
+				return AmendOrderInput{Items: []ItemChangeInput{{ItemID: uuid.New(), Quantity: 1}}}
+			},
+			want: ErrInvalidAmendment,
+		},
+		{
+			name: "negative quantity",
+			input: func(f *amendFixture) AmendOrderInput {
+				return AmendOrderInput{Items: []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: -1}}}
+			},
+			want: ErrInvalidAmendment,
+		},
+		{
+			name: "every item removed",
+			input: func(f *amendFixture) AmendOrderInput {
+				return AmendOrderInput{Items: []ItemChangeInput{{ItemID: f.order.Items[0].ID}, {ItemID: f.order.Items[1].ID}}}
+			},
+			want: ErrInvalidAmendment,
+		},
+		{
+			name: "nothing changed",
+			input: func(f *amendFixture) AmendOrderInput {
+				same := shippingTo
+				return AmendOrderInput{Items: []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: 3}}, ShippingAddress: &same}
+			},
+			want: ErrInvalidAmendment,
+		},
+		{
+			name: "invalid address",
+			input: func(f *amendFixture) AmendOrderInput {
+				return AmendOrderInput{BillingAddress: &AddressInput{FullName: "Abebe Kebede"}}
+			},
+			want: domain.ErrInvalidAddress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == "" {
+				status = domain.StatusCreated
+			}
+			f := newAmendFixture(t, status)
+			ctx := context.Background()
+			f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+
+			input := tt.input(f)
+			input.OrderID = f.order.ID
+			if input.UserID == uuid.Nil {
+				input.UserID = f.order.UserID
+			}
+			_, err := f.uc.Execute(ctx, input)
+			assert.ErrorIs(t, err, tt.want)
+			f.orders.AssertNotCalled(t, "AmendOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAmendOrderUseCase_Execute_PaidMeanwhile(t *testing.T) {
+	f := newAmendFixture(t, domain.StatusPending)
+	ctx := context.Background()
+
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.promotions.On("GetPromotion", ctx, f.promotion.ID).Return(&f.promotion, nil)
+	f.orders.On("AmendOrder", ctx, mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrOrderNotAmendable)
+
+	_, err := f.uc.Execute(ctx, AmendOrderInput{
+		OrderID: f.order.ID,
+		UserID:  f.order.UserID,
+		Items:   []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, domain.ErrOrderNotAmendable)
+	f.events.AssertNotCalled(t, "EmitOrderAmended", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAmendOrderUseCase_Execute_ReappliesOnConflict(t *testing.T) {
+	f := newAmendFixture(t, domain.StatusPending)
+	ctx := context.Background()
+	fresh := *f.order
+	fresh.Items = append([]domain.OrderItem(nil), f.order.Items...)
+	fresh.Version = 2
+
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil).Once()
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(&fresh, nil).Once()
+	f.promotions.On("GetPromotion", ctx, f.promotion.ID).Return(&f.promotion, nil)
+	f.orders.On("AmendOrder", ctx, f.order, mock.Anything, mock.Anything).
+		Return(&domain.VersionConflictError{OrderID: f.order.ID}).Once()
+	f.orders.On("AmendOrder", ctx, &fresh, mock.Anything, mock.Anything).Return(nil).Once()
+	f.events.On("EmitOrderAmended", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	order, err := f.uc.Execute(ctx, AmendOrderInput{
+		OrderID: f.order.ID,
+		UserID:  f.order.UserID,
+		Items:   []ItemChangeInput{{ItemID: f.order.Items[0].ID, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	assert.Same(t, &fresh, order)
+	assert.Equal(t, 2, order.Items[0].Quantity)
+	f.orders.AssertExpectations(t)
+}
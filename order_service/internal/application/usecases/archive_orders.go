@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ArchiveOrdersUseCase moves orders finished for longer than the retention
+// age out of the live tables and exports them as gzipped JSON Lines files,
+// one archived order per line, without personal data.
+type ArchiveOrdersUseCase struct {
+	repo      domain.ArchiveRepository
+	store     domain.ArchiveStore
+	after     time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+// NewArchiveOrdersUseCase archives orders last changed more than after ago,
+// batchSize per transaction and file. A nil store keeps them in the database
+// only.
+func NewArchiveOrdersUseCase(repo domain.ArchiveRepository, store domain.ArchiveStore, after time.Duration, batchSize int) *ArchiveOrdersUseCase {
+	return &ArchiveOrdersUseCase{repo: repo, store: store, after: after, batchSize: batchSize, now: time.Now}
+}
+
+// ArchiveResult counts the orders a run archived and exported.
+type ArchiveResult struct {
+	Archived int
+	Exported int
+}
+
+// Run archives every order that is due, then exports the archived orders not
+// exported yet, including those earlier runs failed to export.
+func (uc *ArchiveOrdersUseCase) Run(ctx context.Context) (ArchiveResult, error) {
+	var result ArchiveResult
+	query := domain.ArchiveQuery{Before: uc.now().Add(-uc.after), Limit: uc.batchSize}
+	for {
+		n, err := uc.repo.ArchiveOrders(ctx, query)
+		result.Archived += n
+		if err != nil {
+			return result, fmt.Errorf("archiving orders: %w", err)
+		}
+		if n < uc.batchSize {
+			break
+		}
+	}
+	if uc.store == nil {
+		return result, nil
+	}
+
+	for {
+		orders, err := uc.repo.PendingExports(ctx, uc.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("loading archived orders: %w", err)
+		}
+		if len(orders) == 0 {
+			break
+		}
+		if err := uc.export(ctx, orders); err != nil {
+			return result, err
+		}
+		result.Exported += len(orders)
+		if len(orders) < uc.batchSize {
+			break
+		}
+	}
+	return result, nil
+}
+
+// export writes the orders to one file, named after the first of them so
+// that exporting the same batch again replaces the file. Erasing a user does
+// not reach exported files, so their addresses are erased before they are
+// written; the archive in the database keeps them until then.
+func (uc *ArchiveOrdersUseCase) export(ctx context.Context, orders []domain.ArchivedOrder) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	ids := make([]uuid.UUID, len(orders))
+	for i := range orders {
+		orders[i].Erase()
+		if err := enc.Encode(&orders[i]); err != nil {
+			return fmt.Errorf("encoding archived order %s: %w", orders[i].Order.ID, err)
+		}
+		ids[i] = orders[i].Order.ID
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	first := orders[0]
+	name := fmt.Sprintf("orders/%s/%s.jsonl.gz", first.ArchivedAt.UTC().Format("2006/01/02"), first.Order.ID)
+	location, err := uc.store.Put(ctx, name, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("exporting archived orders: %w", err)
+	}
+	if err := uc.repo.MarkExported(ctx, ids, location); err != nil {
+		return fmt.Errorf("recording the export to %s: %w", location, err)
+	}
+	return nil
+}
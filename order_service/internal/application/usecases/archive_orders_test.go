@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func archivedOrders(n int, at time.Time) []domain.ArchivedOrder {
+	orders := make([]domain.ArchivedOrder, n)
+	for i := range orders {
+		orders[i] = domain.ArchivedOrder{Order: domain.Order{ID: uuid.New(), Status: domain.StatusDelivered}, ArchivedAt: at}
+	}
+	return orders
+}
+
+// mockQuery matches archive queries of batches of limit orders.
+func mockQuery(limit int) any {
+	return mock.MatchedBy(func(q domain.ArchiveQuery) bool { return q.Limit == limit })
+}
+
+// failingArchive cannot store anything.
+type failingArchive struct{}
+
+func (failingArchive) Put(context.Context, string, []byte) (string, error) {
+	return "", errors.New("bucket unreachable")
+}
+
+func TestArchiveOrdersUseCase_Run(t *testing.T) {
+	repo := new(MockArchiveRepository)
+	store := memoryArchive{}
+	uc := NewArchiveOrdersUseCase(repo, store, 365*24*time.Hour, 2)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	query := domain.ArchiveQuery{Before: now.Add(-365 * 24 * time.Hour), Limit: 2}
+	repo.On("ArchiveOrders", ctx, query).Return(2, nil).Once()
+	repo.On("ArchiveOrders", ctx, query).Return(1, nil).Once()
+	first, second := archivedOrders(2, now), archivedOrders(1, now)
+	first[0].Order.Addresses = []domain.OrderAddress{{Kind: domain.AddressShipping, FullName: "Abebe Kebede", Phone: "+251911234567",
+		Country: "ET", City: "Addis Ababa", Street: "Bole Road"}}
+	first[0].Amendments = []domain.OrderAmendment{{Changes: []domain.AmendmentChange{
+		{Field: domain.AmendShippingAddress, From: "Abebe Kebede, +251911234567, Bole Road", To: "Abebe Kebede, +251911234567, CMC Road"},
+	}}}
+	repo.On("PendingExports", ctx, 2).Return(first, nil).Once()
+	repo.On("PendingExports", ctx, 2).Return(second, nil).Once()
+	firstName := "orders/2026/05/01/" + first[0].Order.ID.String() + ".jsonl.gz"
+	repo.On("MarkExported", ctx, []uuid.UUID{first[0].Order.ID, first[1].Order.ID}, "mem://"+firstName).Return(nil).Once()
+	repo.On("MarkExported", ctx, []uuid.UUID{second[0].Order.ID}, "mem://orders/2026/05/01/"+second[0].Order.ID.String()+".jsonl.gz").Return(nil).Once()
+
+	result, err := uc.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ArchiveResult{Archived: 3, Exported: 3}, result)
+	repo.AssertExpectations(t)
+
+	// One archived order per line, gzipped.
+	require.Contains(t, store, firstName)
+	zr, err := gzip.NewReader(bytes.NewReader(store[firstName]))
+	require.NoError(t, err)
+	var exported []domain.ArchivedOrder
+	lines := bufio.NewScanner(zr)
+	for lines.Scan() {
+		var order domain.ArchivedOrder
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &order))
+		exported = append(exported, order)
+	}
+	require.Len(t, exported, 2)
+	assert.Equal(t, first[0].Order.ID, exported[0].Order.ID)
+	assert.Equal(t, first[1].Order.ID, exported[1].Order.ID)
+
+	// Files cannot be erased later, so they hold no personal data.
+	address := exported[0].Order.Addresses[0]
+	assert.Equal(t, domain.ErasedValue, address.FullName)
+	assert.Empty(t, address.Phone)
+	assert.Empty(t, address.Street)
+	assert.Equal(t, "Addis Ababa", address.City)
+	assert.Equal(t, domain.ErasedValue, exported[0].Amendments[0].Changes[0].To)
+}
+
+func TestArchiveOrdersUseCase_Run_WithoutExport(t *testing.T) {
+	repo := new(MockArchiveRepository)
+	uc := NewArchiveOrdersUseCase(repo, nil, time.Hour, 10)
+	ctx := context.Background()
+
+	repo.On("ArchiveOrders", ctx, mockQuery(10)).Return(4, nil).Once()
+
+	result, err := uc.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ArchiveResult{Archived: 4}, result)
+	repo.AssertNotCalled(t, "PendingExports")
+}
+
+func TestArchiveOrdersUseCase_Run_ExportFailure(t *testing.T) {
+	repo := new(MockArchiveRepository)
+	uc := NewArchiveOrdersUseCase(repo, failingArchive{}, time.Hour, 10)
+	ctx := context.Background()
+
+	repo.On("ArchiveOrders", ctx, mockQuery(10)).Return(1, nil).Once()
+	repo.On("PendingExports", ctx, 10).Return(archivedOrders(1, time.Now()), nil).Once()
+
+	// The orders stay pending, for the next run to export.
+	result, err := uc.Run(ctx)
+	assert.ErrorContains(t, err, "bucket unreachable")
+	assert.Equal(t, ArchiveResult{Archived: 1}, result)
+	repo.AssertNotCalled(t, "MarkExported")
+}
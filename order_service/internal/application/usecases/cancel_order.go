@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrOrderNotCancelable is returned when canceling an order that was
+	// already paid, shipped, delivered or canceled.
+	ErrOrderNotCancelable = domain.NewError(domain.ErrPreconditionFailed, "ORDER_NOT_CANCELABLE", "order can no longer be canceled")
+	// ErrInvalidCancellation is returned, wrapped with the reason, for a
+	// cancellation with an overlong reason.
+	ErrInvalidCancellation = domain.NewError(domain.ErrInvalidArgument, "INVALID_CANCELLATION", "invalid cancellation")
+)
+
+// maxCancelReason bounds the length of a cancellation reason.
+const maxCancelReason = 500
+
+// CancelOrderInput is a cancellation of an order by Actor, with an optional
+// reason recorded in the order's audit trail.
+type CancelOrderInput struct {
+	OrderID uuid.UUID
+	Actor   domain.Actor
+	Reason  string
+}
+
+type CancelOrderUseCase struct {
+	repo         domain.OrderRepository
+	updateStatus *UpdateOrderStatusUseCase
+}
+
+func NewCancelOrderUseCase(repo domain.OrderRepository, updateStatus *UpdateOrderStatusUseCase) *CancelOrderUseCase {
+	return &CancelOrderUseCase{repo: repo, updateStatus: updateStatus}
+}
+
+// Execute cancels an unpaid order and returns it in its new state. The
+// transition is recorded and published like any other status update; an
+// order paid meanwhile is not canceled.
+func (uc *CancelOrderUseCase) Execute(ctx context.Context, input CancelOrderInput) (*domain.Order, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if len(reason) > maxCancelReason {
+		return nil, fmt.Errorf("%w: the reason must be at most %d characters", ErrInvalidCancellation, maxCancelReason)
+	}
+	err := uc.updateStatus.Execute(ctx, StatusUpdateInput{
+		OrderID: input.OrderID,
+		Status:  domain.StatusCanceled,
+		Actor:   input.Actor,
+		Reason:  reason,
+		Check: func(order *domain.Order) error {
+			if !order.Cancelable() {
+				return ErrOrderNotCancelable
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetOrderByID(ctx, input.OrderID)
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelOrderUseCase_Execute_CancelsUnpaidOrder(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewCancelOrderUseCase(mockRepo, NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer))
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	created := &domain.Order{ID: orderID, Status: domain.StatusCreated}
+	canceled := &domain.Order{ID: orderID, Status: domain.StatusCanceled}
+
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(created, nil).Once()
+	actor := domain.Actor{Type: domain.ActorUser, ID: "user-1"}
+	mockRepo.On("ChangeStatus", ctx, mock.MatchedBy(func(e *domain.OrderStatusHistory) bool {
+		return e.OrderID == orderID && e.Status == domain.StatusCanceled && e.Actor == actor && e.Reason == "ordered twice"
+	}), mock.Anything).Return(nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(canceled, nil)
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+
+	order, err := uc.Execute(ctx, CancelOrderInput{OrderID: orderID, Actor: actor, Reason: "  ordered twice "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusCanceled, order.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCancelOrderUseCase_Execute_RejectsPaidOrder(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewCancelOrderUseCase(mockRepo, NewUpdateOrderStatusUseCase(mockRepo, new(MockEventProducer)))
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusPaid}, nil)
+
+	_, err := uc.Execute(ctx, CancelOrderInput{OrderID: orderID})
+
+	assert.ErrorIs(t, err, ErrOrderNotCancelable)
+	mockRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelOrderUseCase_Execute_PaidMeanwhile(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewCancelOrderUseCase(mockRepo, NewUpdateOrderStatusUseCase(mockRepo, new(MockEventProducer)))
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusPending, Version: 1}, nil).Once()
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, int64(1)).Return(&domain.VersionConflictError{OrderID: orderID, Version: 1})
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusPaid, Version: 2}, nil).Once()
+
+	_, err := uc.Execute(ctx, CancelOrderInput{OrderID: orderID})
+
+	assert.ErrorIs(t, err, ErrOrderNotCancelable)
+	mockRepo.AssertNumberOfCalls(t, "ChangeStatus", 1)
+}
+
+func TestCancelOrderUseCase_Execute_RecordsHistory(t *testing.T) {
+	store := memory.NewStore()
+	mockEventProducer := new(MockEventProducer)
+	uc := NewCancelOrderUseCase(store, NewUpdateOrderStatusUseCase(store, mockEventProducer))
+
+	ctx := context.Background()
+	order := &domain.Order{ID: uuid.New(), UserID: uuid.New(), Status: domain.StatusPending, Currency: "ETB"}
+	require.NoError(t, store.CreateOrder(ctx, order, nil, nil))
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+	actor := domain.Actor{Type: domain.ActorUser, ID: order.UserID.String()}
+
+	canceled, err := uc.Execute(ctx, CancelOrderInput{OrderID: order.ID, Actor: actor, Reason: "ordered twice"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCanceled, canceled.Status)
+	assert.Equal(t, int64(2), canceled.Version)
+
+	history, err := store.ListStatusHistory(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.StatusPending, history[0].FromStatus)
+	assert.Equal(t, actor, history[0].Actor)
+	assert.Equal(t, "ordered twice", history[0].Reason)
+
+	_, err = uc.Execute(ctx, CancelOrderInput{OrderID: order.ID, Actor: actor})
+	assert.ErrorIs(t, err, ErrOrderNotCancelable)
+}
@@ -2,17 +2,36 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
 	"github.com/google/uuid"
 )
 
+// ErrInvalidCoupon is returned, wrapped with the reason, when a coupon code
+// cannot be applied to the order.
+var ErrInvalidCoupon = domain.NewError(domain.ErrInvalidArgument, "INVALID_COUPON", "invalid coupon")
+
+// ErrInvalidItems is returned, wrapped with the reason, when an order has no
+// items or an item with a quantity below one or a negative price.
+var ErrInvalidItems = domain.NewError(domain.ErrInvalidArgument, "INVALID_ITEMS", "invalid items")
+
+// maxCouponCodes bounds the codes entered on one order.
+const maxCouponCodes = 5
+
+// CreateOrderInput describes a new order. Its total is computed from the
+// items and the promotions it qualifies for: automatic ones and those whose
+// code is in CouponCodes. Tax follows the shipping address and the category
+// of each item, and the shipping fee is quoted by the delivery service.
+// Without a BillingAddress the order is billed to the shipping address.
 type CreateOrderInput struct {
 	UserID          uuid.UUID
 	Items           []OrderItemInput
 	ShippingAddress AddressInput
-	TotalAmount     float64
+	BillingAddress  *AddressInput
+	CouponCodes     []string
 }
 
 type OrderItemInput struct {
@@ -21,35 +40,66 @@ type OrderItemInput struct {
 	ProductName string
 	UnitPrice   float64
 	Quantity    int
+	Category    string
+	WeightKg    float64
 }
 
 type AddressInput struct {
-	FullName string
-	Phone    string
-	City     string
-	Street   string
+	FullName     string
+	Phone        string
+	Country      string
+	Region       string
+	City         string
+	Street       string
+	AddressLine2 string
+	PostalCode   string
+	Latitude     *float64
+	Longitude    *float64
 }
 
 type CreateOrderUseCase struct {
-	repo          domain.OrderRepository
-	eventProducer domain.OrderEventProducer
+	repo       domain.OrderRepository
+	promotions domain.PromotionRepository
+	taxes      domain.TaxCalculator
+	shipping   domain.ShippingQuoter
+	// defaultCountry is the country of addresses entered without one.
+	defaultCountry string
+	eventProducer  domain.OrderEventProducer
+	emitTimeout    time.Duration
 }
 
-func NewCreateOrderUseCase(repo domain.OrderRepository, eventProducer domain.OrderEventProducer) *CreateOrderUseCase {
-	return &CreateOrderUseCase{repo: repo, eventProducer: eventProducer}
+func NewCreateOrderUseCase(repo domain.OrderRepository, promotions domain.PromotionRepository, taxes domain.TaxCalculator, shipping domain.ShippingQuoter, defaultCountry string, eventProducer domain.OrderEventProducer, emitTimeout time.Duration) *CreateOrderUseCase {
+	return &CreateOrderUseCase{repo: repo, promotions: promotions, taxes: taxes, shipping: shipping, defaultCountry: defaultCountry, eventProducer: eventProducer, emitTimeout: emitTimeout}
 }
 
 func (uc *CreateOrderUseCase) Execute(ctx context.Context, input CreateOrderInput) (*domain.Order, error) {
+	if err := validateItems(input.Items); err != nil {
+		return nil, err
+	}
 	orderID := uuid.New()
+	now := time.Now()
 	order := &domain.Order{
-		ID:          orderID,
-		UserID:      input.UserID,
-		Status:      domain.StatusCreated,
-		TotalAmount: input.TotalAmount,
-		Currency:    "ETB",
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:        orderID,
+		UserID:    input.UserID,
+		Status:    domain.StatusCreated,
+		Currency:  "ETB",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	shippingAddress, err := newAddress(orderID, domain.AddressShipping, input.ShippingAddress, uc.defaultCountry)
+	if err != nil {
+		return nil, err
 	}
+	billingInput := input.ShippingAddress
+	if input.BillingAddress != nil {
+		billingInput = *input.BillingAddress
+	}
+	billingAddress, err := newAddress(orderID, domain.AddressBilling, billingInput, uc.defaultCountry)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []domain.OrderAddress{*shippingAddress, *billingAddress}
 
 	var items []domain.OrderItem
 	for _, itemInput := range input.Items {
@@ -61,30 +111,116 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, input CreateOrderInpu
 			ProductName: itemInput.ProductName,
 			UnitPrice:   itemInput.UnitPrice,
 			Quantity:    itemInput.Quantity,
+			Category:    itemInput.Category,
+			WeightKg:    itemInput.WeightKg,
 		})
 	}
 
-	address := &domain.OrderAddress{
-		ID:       uuid.New(),
-		OrderID:  orderID,
-		FullName: input.ShippingAddress.FullName,
-		Phone:    input.ShippingAddress.Phone,
-		City:     input.ShippingAddress.City,
-		Street:   input.ShippingAddress.Street,
+	discounts, err := uc.discounts(ctx, input.UserID, items, input.CouponCodes, now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range discounts {
+		discounts[i].ID = uuid.New()
+		discounts[i].OrderID = orderID
+	}
+	if err := priceOrder(ctx, uc.taxes, uc.shipping, order, items, discounts, *shippingAddress); err != nil {
+		return nil, err
 	}
 
-	err := uc.repo.CreateOrder(ctx, order, items, address)
-	if err != nil {
+	if err := uc.repo.CreateOrder(ctx, order, items, addresses); err != nil {
 		return nil, err
 	}
+	// Attached after persisting so the repository does not insert the items
+	// twice through the association; the event carries them.
+	order.Items = items
+	order.Addresses = addresses
 
 	// Asynchronous event emission using Goroutine
 	go func() {
 		// Use a background context for the async task to avoid cancellation if the request context expires
-		asyncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		asyncCtx, cancel := context.WithTimeout(context.Background(), uc.emitTimeout)
 		defer cancel()
-		_ = uc.eventProducer.EmitOrderCreated(asyncCtx, order)
+		if err := uc.eventProducer.EmitOrderCreated(asyncCtx, order); err != nil {
+			log.Printf("Failed to emit order.created for OrderID %s: %v", order.ID, err)
+		}
 	}()
 
 	return order, nil
 }
+
+// discounts picks the discounts of an order among the automatic promotions
+// and those of the entered codes. A code that is unknown, expired, used up or
+// not met by the order is rejected; automatic promotions the user has used
+// up are skipped.
+func (uc *CreateOrderUseCase) discounts(ctx context.Context, userID uuid.UUID, items []domain.OrderItem, codes []string, at time.Time) ([]domain.OrderDiscount, error) {
+	var requested []string
+	seen := map[string]bool{}
+	for _, code := range codes {
+		code = domain.NormalizeCouponCode(code)
+		if code != "" && !seen[code] {
+			seen[code] = true
+			requested = append(requested, code)
+		}
+	}
+	if len(requested) > maxCouponCodes {
+		return nil, fmt.Errorf("%w: at most %d codes per order", ErrInvalidCoupon, maxCouponCodes)
+	}
+
+	candidates, err := uc.promotions.ApplicablePromotions(ctx, requested, at)
+	if err != nil {
+		return nil, err
+	}
+	var limited []uuid.UUID
+	for _, p := range candidates {
+		if p.MaxUsesPerUser > 0 {
+			limited = append(limited, p.ID)
+		}
+	}
+	used := map[uuid.UUID]int{}
+	if len(limited) > 0 {
+		if used, err = uc.promotions.CountRedemptions(ctx, userID, limited); err != nil {
+			return nil, err
+		}
+	}
+
+	found := map[string]bool{}
+	var usable []domain.Promotion
+	for _, p := range candidates {
+		exhausted := p.Exhausted() || (p.MaxUsesPerUser > 0 && used[p.ID] >= p.MaxUsesPerUser)
+		if p.Code != "" {
+			found[p.Code] = true
+			if exhausted {
+				return nil, fmt.Errorf("%w: %s has reached its usage limit", ErrInvalidCoupon, p.Code)
+			}
+			if p.Discount(items) == 0 {
+				return nil, fmt.Errorf("%w: the order does not qualify for %s", ErrInvalidCoupon, p.Code)
+			}
+		}
+		if !exhausted {
+			usable = append(usable, p)
+		}
+	}
+	for _, code := range requested {
+		if !found[code] {
+			return nil, fmt.Errorf("%w: %s is unknown or expired", ErrInvalidCoupon, code)
+		}
+	}
+	return domain.ApplyPromotions(items, usable), nil
+}
+
+// validateItems checks the items before anything is priced or quoted.
+func validateItems(items []OrderItemInput) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: an order needs at least one item", ErrInvalidItems)
+	}
+	for i, item := range items {
+		if item.Quantity < 1 {
+			return fmt.Errorf("%w: item %d: quantity must be at least 1", ErrInvalidItems, i+1)
+		}
+		if item.UnitPrice < 0 {
+			return fmt.Errorf("%w: item %d: unit price must not be negative", ErrInvalidItems, i+1)
+		}
+	}
+	return nil
+}
@@ -12,10 +12,14 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// shippingTo is a complete address in Addis Ababa.
+var shippingTo = AddressInput{FullName: "Abebe Kebede", Phone: "0911234567", City: "Addis Ababa", Street: "Bole Road"}
+
 func TestCreateOrderUseCase_Execute_Success(t *testing.T) {
 	mockRepo := new(MockOrderRepository)
+	mockPromotions := new(MockPromotionRepository)
 	mockEventProducer := new(MockEventProducer)
-	uc := NewCreateOrderUseCase(mockRepo, mockEventProducer)
+	uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", mockEventProducer, 5*time.Second)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -39,10 +43,10 @@ func TestCreateOrderUseCase_Execute_Success(t *testing.T) {
 			City:     "New York",
 			Street:   "Fifth Avenue",
 		},
-		TotalAmount: 100.0,
 	}
 
 	// Mock expectations
+	mockPromotions.On("ApplicablePromotions", ctx, []string(nil), mock.Anything).Return([]domain.Promotion{}, nil)
 	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// Since event emission is async, use a channel to wait for it
@@ -59,6 +63,8 @@ func TestCreateOrderUseCase_Execute_Success(t *testing.T) {
 	assert.NotNil(t, order)
 	assert.Equal(t, userID, order.UserID)
 	assert.Equal(t, domain.StatusCreated, order.Status)
+	assert.Equal(t, 100.0, order.TotalAmount, "the total is computed from the items")
+	assert.Len(t, order.Items, 1, "items are attached for the order.created event")
 
 	// Wait for async event
 	select {
@@ -74,17 +80,18 @@ func TestCreateOrderUseCase_Execute_Success(t *testing.T) {
 
 func TestCreateOrderUseCase_Execute_RepoError(t *testing.T) {
 	mockRepo := new(MockOrderRepository)
+	mockPromotions := new(MockPromotionRepository)
 	mockEventProducer := new(MockEventProducer)
-	uc := NewCreateOrderUseCase(mockRepo, mockEventProducer)
+	uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", mockEventProducer, 5*time.Second)
 
 	ctx := context.Background()
 	input := CreateOrderInput{
 		UserID:          uuid.New(),
-		Items:           []OrderItemInput{},
-		ShippingAddress: AddressInput{},
-		TotalAmount:     0,
+		Items:           []OrderItemInput{{ProductID: uuid.New(), UnitPrice: 10, Quantity: 1}},
+		ShippingAddress: shippingTo,
 	}
 
+	mockPromotions.On("ApplicablePromotions", ctx, []string(nil), mock.Anything).Return([]domain.Promotion{}, nil)
 	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
 
 	order, err := uc.Execute(ctx, input)
@@ -97,3 +104,219 @@ func TestCreateOrderUseCase_Execute_RepoError(t *testing.T) {
 	// Event producer should not be called
 	mockEventProducer.AssertNotCalled(t, "EmitOrderCreated")
 }
+
+func TestCreateOrderUseCase_Execute_AppliesPromotions(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockPromotions := new(MockPromotionRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", mockEventProducer, 5*time.Second)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	automatic := domain.Promotion{ID: uuid.New(), Kind: domain.PromotionFixed, Value: 5, Stackable: true, Active: true}
+	coupon := domain.Promotion{ID: uuid.New(), Code: "SPRING10", Kind: domain.PromotionPercentage, Value: 10, Stackable: true, Active: true, MaxUsesPerUser: 1}
+	input := CreateOrderInput{
+		UserID:          userID,
+		Items:           []OrderItemInput{{ProductID: uuid.New(), UnitPrice: 50, Quantity: 4}},
+		ShippingAddress: shippingTo,
+		CouponCodes:     []string{" spring10", "SPRING10"},
+	}
+
+	mockPromotions.On("ApplicablePromotions", ctx, []string{"SPRING10"}, mock.Anything).Return([]domain.Promotion{automatic, coupon}, nil)
+	mockPromotions.On("CountRedemptions", ctx, userID, []uuid.UUID{coupon.ID}).Return(map[uuid.UUID]int{}, nil)
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	emitted := make(chan *domain.Order, 1)
+	mockEventProducer.On("EmitOrderCreated", mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) { emitted <- args.Get(1).(*domain.Order) })
+
+	order, err := uc.Execute(ctx, input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200.0, order.Subtotal)
+	assert.Equal(t, 25.0, order.DiscountAmount)
+	assert.Equal(t, 175.0, order.TotalAmount)
+	if assert.Len(t, order.Discounts, 2) {
+		assert.Equal(t, order.ID, order.Discounts[1].OrderID)
+		assert.Equal(t, "SPRING10", order.Discounts[1].Code)
+	}
+	select {
+	case e := <-emitted:
+		assert.Equal(t, 175.0, e.TotalAmount, "payment charges the discounted total")
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for EmitOrderCreated")
+	}
+}
+
+func TestCreateOrderUseCase_Execute_AddsTaxAndShipping(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockPromotions := new(MockPromotionRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0.15), flatShipping(30), "ET", mockEventProducer, 5*time.Second)
+
+	ctx := context.Background()
+	automatic := domain.Promotion{ID: uuid.New(), Kind: domain.PromotionFixed, Value: 20, Active: true}
+	input := CreateOrderInput{
+		UserID: uuid.New(),
+		Items: []OrderItemInput{
+			{ProductID: uuid.New(), UnitPrice: 100, Quantity: 1, Category: "books"},
+			{ProductID: uuid.New(), UnitPrice: 100, Quantity: 1},
+		},
+		ShippingAddress: AddressInput{FullName: "Abebe Kebede", Phone: "0911234567", Country: "ET", Region: "Addis Ababa", City: "Addis Ababa", Street: "Bole Road"},
+	}
+	mockPromotions.On("ApplicablePromotions", ctx, []string(nil), mock.Anything).Return([]domain.Promotion{automatic}, nil)
+	var items []domain.OrderItem
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) { items = args.Get(2).([]domain.OrderItem) })
+	mockEventProducer.On("EmitOrderCreated", mock.Anything, mock.Anything).Return(nil)
+
+	order, err := uc.Execute(ctx, input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 27.0, order.TaxAmount, "tax is due on the discounted lines")
+	assert.Equal(t, 30.0, order.ShippingAmount, "shipping is not taxed")
+	assert.Equal(t, 237.0, order.TotalAmount)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, "books", items[0].Category)
+		assert.Equal(t, 90.0, items[0].TaxableAmount)
+		assert.Equal(t, 13.5, items[0].TaxAmount)
+		assert.Equal(t, 0.15, items[1].TaxRate)
+	}
+}
+
+func TestCreateOrderUseCase_Execute_Addresses(t *testing.T) {
+	ctx := context.Background()
+	items := []OrderItemInput{{ProductID: uuid.New(), UnitPrice: 50, Quantity: 1}}
+
+	t.Run("billing defaults to shipping", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		mockPromotions := new(MockPromotionRepository)
+		mockEventProducer := new(MockEventProducer)
+		uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", mockEventProducer, 5*time.Second)
+		mockPromotions.On("ApplicablePromotions", ctx, []string(nil), mock.Anything).Return([]domain.Promotion{}, nil)
+		mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockEventProducer.On("EmitOrderCreated", mock.Anything, mock.Anything).Return(nil)
+
+		order, err := uc.Execute(ctx, CreateOrderInput{UserID: uuid.New(), Items: items, ShippingAddress: shippingTo})
+
+		assert.NoError(t, err)
+		shipping, billing := order.Address(domain.AddressShipping), order.Address(domain.AddressBilling)
+		if assert.NotNil(t, shipping) && assert.NotNil(t, billing) {
+			assert.Equal(t, "+251911234567", shipping.Phone)
+			assert.Equal(t, "ET", shipping.Country)
+			assert.NotEqual(t, shipping.ID, billing.ID)
+			assert.Equal(t, shipping.Street, billing.Street)
+		}
+	})
+
+	t.Run("separate billing address", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		mockPromotions := new(MockPromotionRepository)
+		mockEventProducer := new(MockEventProducer)
+		uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", mockEventProducer, 5*time.Second)
+		mockPromotions.On("ApplicablePromotions", ctx, []string(nil), mock.Anything).Return([]domain.Promotion{}, nil)
+		var stored []domain.OrderAddress
+		mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) { stored = args.Get(3).([]domain.OrderAddress) })
+		mockEventProducer.On("EmitOrderCreated", mock.Anything, mock.Anything).Return(nil)
+		billTo := AddressInput{FullName: "Acme Ltd", Country: "GB", City: "London", Street: "1 High St", PostalCode: "sw1a 1aa"}
+
+		_, err := uc.Execute(ctx, CreateOrderInput{UserID: uuid.New(), Items: items, ShippingAddress: shippingTo, BillingAddress: &billTo})
+
+		assert.NoError(t, err)
+		if assert.Len(t, stored, 2) {
+			assert.Equal(t, domain.AddressBilling, stored[1].Kind)
+			assert.Equal(t, "SW1A 1AA", stored[1].PostalCode)
+		}
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		uc := NewCreateOrderUseCase(mockRepo, new(MockPromotionRepository), flatTax(0), flatShipping(0), "ET", new(MockEventProducer), 5*time.Second)
+		billTo := AddressInput{FullName: "Jane Roe", Country: "US", City: "NYC", Street: "5th Ave"}
+
+		_, err := uc.Execute(ctx, CreateOrderInput{UserID: uuid.New(), Items: items, ShippingAddress: shippingTo, BillingAddress: &billTo})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAddress)
+		assert.ErrorContains(t, err, "billing address")
+		mockRepo.AssertNotCalled(t, "CreateOrder")
+	})
+}
+
+func TestCreateOrderUseCase_Execute_InvalidCoupon(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	items := []OrderItemInput{{ProductID: uuid.New(), UnitPrice: 50, Quantity: 1}}
+	usedUp := domain.Promotion{ID: uuid.New(), Code: "ONCE", Kind: domain.PromotionFixed, Value: 5, Active: true, MaxUsesPerUser: 1}
+	bigSpender := domain.Promotion{ID: uuid.New(), Code: "BIG", Kind: domain.PromotionFixed, Value: 5, Active: true, MinSubtotal: 500}
+
+	tests := []struct {
+		name       string
+		codes      []string
+		promotions []domain.Promotion
+	}{
+		{"unknown code", []string{"NOPE"}, nil},
+		{"used up by the user", []string{"ONCE"}, []domain.Promotion{usedUp}},
+		{"order does not qualify", []string{"BIG"}, []domain.Promotion{bigSpender}},
+		{"too many codes", []string{"A", "B", "C", "D", "E", "F"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrderRepository)
+			mockPromotions := new(MockPromotionRepository)
+			uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", new(MockEventProducer), 5*time.Second)
+			mockPromotions.On("ApplicablePromotions", ctx, mock.Anything, mock.Anything).Return(tt.promotions, nil)
+			mockPromotions.On("CountRedemptions", ctx, userID, mock.Anything).Return(map[uuid.UUID]int{usedUp.ID: 1}, nil)
+
+			_, err := uc.Execute(ctx, CreateOrderInput{UserID: userID, Items: items, ShippingAddress: shippingTo, CouponCodes: tt.codes})
+
+			assert.ErrorIs(t, err, ErrInvalidCoupon)
+			mockRepo.AssertNotCalled(t, "CreateOrder")
+		})
+	}
+}
+
+func TestCreateOrderUseCase_Execute_InvalidItems(t *testing.T) {
+	ctx := context.Background()
+	valid := OrderItemInput{ProductID: uuid.New(), UnitPrice: 50, Quantity: 1}
+
+	tests := []struct {
+		name  string
+		items []OrderItemInput
+	}{
+		{"no items", nil},
+		{"zero quantity", []OrderItemInput{valid, {ProductID: uuid.New(), UnitPrice: 50, Quantity: 0}}},
+		{"negative quantity", []OrderItemInput{{ProductID: uuid.New(), UnitPrice: 50, Quantity: -2}}},
+		{"negative price", []OrderItemInput{{ProductID: uuid.New(), UnitPrice: -0.01, Quantity: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockOrderRepository)
+			mockPromotions := new(MockPromotionRepository)
+			uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", new(MockEventProducer), 5*time.Second)
+
+			_, err := uc.Execute(ctx, CreateOrderInput{UserID: uuid.New(), Items: tt.items, ShippingAddress: shippingTo})
+
+			assert.ErrorIs(t, err, ErrInvalidItems)
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+			mockPromotions.AssertNotCalled(t, "ApplicablePromotions")
+			mockRepo.AssertNotCalled(t, "CreateOrder")
+		})
+	}
+
+	t.Run("free item", func(t *testing.T) {
+		mockRepo := new(MockOrderRepository)
+		mockPromotions := new(MockPromotionRepository)
+		mockEvents := new(MockEventProducer)
+		uc := NewCreateOrderUseCase(mockRepo, mockPromotions, flatTax(0), flatShipping(0), "ET", mockEvents, 5*time.Second)
+		mockPromotions.On("ApplicablePromotions", ctx, []string(nil), mock.Anything).Return([]domain.Promotion{}, nil)
+		mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockEvents.On("EmitOrderCreated", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+		_, err := uc.Execute(ctx, CreateOrderInput{UserID: uuid.New(), Items: []OrderItemInput{{ProductID: uuid.New(), Quantity: 1}}, ShippingAddress: shippingTo})
+
+		assert.NoError(t, err)
+	})
+}
@@ -18,3 +18,11 @@ func NewGetOrderUseCase(repo domain.OrderRepository) *GetOrderUseCase {
 func (uc *GetOrderUseCase) Execute(ctx context.Context, orderID uuid.UUID) (*domain.Order, error) {
 	return uc.repo.GetOrderByID(ctx, orderID)
 }
+
+// History returns the audit trail of an order, oldest first.
+func (uc *GetOrderUseCase) History(ctx context.Context, orderID uuid.UUID) ([]domain.OrderStatusHistory, error) {
+	if _, err := uc.repo.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListStatusHistory(ctx, orderID)
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ErrInvalidPageToken is returned for a page token that was not issued by a
+// previous listing.
+var ErrInvalidPageToken = domain.NewError(domain.ErrInvalidArgument, "INVALID_PAGE_TOKEN", "invalid page token")
+
+type ListOrdersInput struct {
+	UserID    uuid.UUID
+	PageSize  int
+	PageToken string
+}
+
+type ListOrdersOutput struct {
+	Orders        []domain.Order
+	NextPageToken string
+}
+
+type ListOrdersUseCase struct {
+	repo domain.OrderRepository
+}
+
+func NewListOrdersUseCase(repo domain.OrderRepository) *ListOrdersUseCase {
+	return &ListOrdersUseCase{repo: repo}
+}
+
+// Execute returns one page of the user's orders, newest first. The next page
+// token is empty on the last page.
+func (uc *ListOrdersUseCase) Execute(ctx context.Context, input ListOrdersInput) (*ListOrdersOutput, error) {
+	size := input.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	size = min(size, maxPageSize)
+
+	query := domain.OrderListQuery{UserID: input.UserID, Limit: size + 1}
+	if input.PageToken != "" {
+		cursor, err := decodePageToken(input.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		query.After = cursor
+	}
+
+	orders, err := uc.repo.ListOrders(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	out := &ListOrdersOutput{Orders: orders}
+	if len(orders) > size {
+		out.Orders = orders[:size]
+		last := out.Orders[size-1]
+		out.NextPageToken = encodePageToken(domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return out, nil
+}
+
+func encodePageToken(c domain.OrderCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*domain.OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &domain.OrderCursor{CreatedAt: createdAt, ID: orderID}, nil
+}
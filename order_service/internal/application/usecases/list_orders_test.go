@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOrdersUseCase_Execute_Paginates(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewListOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	orders := []domain.Order{
+		{ID: uuid.New(), UserID: userID, CreatedAt: now},
+		{ID: uuid.New(), UserID: userID, CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), UserID: userID, CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	mockRepo.On("ListOrders", ctx, domain.OrderListQuery{UserID: userID, Limit: 3}).Return(orders, nil)
+	first, err := uc.Execute(ctx, ListOrdersInput{UserID: userID, PageSize: 2})
+	require.NoError(t, err)
+	assert.Len(t, first.Orders, 2)
+	require.NotEmpty(t, first.NextPageToken)
+
+	after := &domain.OrderCursor{CreatedAt: orders[1].CreatedAt, ID: orders[1].ID}
+	mockRepo.On("ListOrders", ctx, domain.OrderListQuery{UserID: userID, Limit: 3, After: after}).Return(orders[2:], nil)
+	second, err := uc.Execute(ctx, ListOrdersInput{UserID: userID, PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, orders[2:], second.Orders)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestListOrdersUseCase_Execute_PageSizeBounds(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewListOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	mockRepo.On("ListOrders", ctx, domain.OrderListQuery{UserID: userID, Limit: defaultPageSize + 1}).Return([]domain.Order{}, nil)
+	mockRepo.On("ListOrders", ctx, domain.OrderListQuery{UserID: userID, Limit: maxPageSize + 1}).Return([]domain.Order{}, nil)
+
+	_, err := uc.Execute(ctx, ListOrdersInput{UserID: userID})
+	assert.NoError(t, err)
+	_, err = uc.Execute(ctx, ListOrdersInput{UserID: userID, PageSize: 1000})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListOrdersUseCase_Execute_InvalidPageToken(t *testing.T) {
+	uc := NewListOrdersUseCase(new(MockOrderRepository))
+
+	_, err := uc.Execute(context.Background(), ListOrdersInput{UserID: uuid.New(), PageToken: "not-a-token"})
+
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
 	"github.com/google/uuid"
@@ -12,8 +13,8 @@ type MockOrderRepository struct {
 	mock.Mock
 }
 
-func (m *MockOrderRepository) CreateOrder(ctx context.Context, order *domain.Order, items []domain.OrderItem, address *domain.OrderAddress) error {
-	args := m.Called(ctx, order, items, address)
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, order *domain.Order, items []domain.OrderItem, addresses []domain.OrderAddress) error {
+	args := m.Called(ctx, order, items, addresses)
 	return args.Error(0)
 }
 
@@ -25,16 +26,77 @@ func (m *MockOrderRepository) GetOrderByID(ctx context.Context, id uuid.UUID) (*
 	return args.Get(0).(*domain.Order), args.Error(1)
 }
 
-func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status domain.OrderStatus) error {
-	args := m.Called(ctx, orderID, status)
+func (m *MockOrderRepository) ListOrders(ctx context.Context, query domain.OrderListQuery) ([]domain.Order, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) ChangeStatus(ctx context.Context, entry *domain.OrderStatusHistory, version int64) error {
+	args := m.Called(ctx, entry, version)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]domain.OrderStatusHistory, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrderStatusHistory), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListStatusChanges(ctx context.Context, query domain.StatusChangeQuery) ([]domain.OrderStatusChange, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrderStatusChange), args.Error(1)
+}
+
+func (m *MockOrderRepository) SearchOrders(ctx context.Context, query domain.OrderSearchQuery) ([]domain.Order, int64, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domain.Order), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrderRepository) ListSellerLines(ctx context.Context, query domain.SellerLineQuery) ([]domain.SellerOrderLine, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SellerOrderLine), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateLineFulfillment(ctx context.Context, orderID uuid.UUID, itemIDs []uuid.UUID, status domain.FulfillmentStatus, at time.Time) error {
+	args := m.Called(ctx, orderID, itemIDs, status, at)
 	return args.Error(0)
 }
 
-func (m *MockOrderRepository) AddStatusHistory(ctx context.Context, history *domain.OrderStatusHistory) error {
-	args := m.Called(ctx, history)
+func (m *MockOrderRepository) SellerDailySales(ctx context.Context, query domain.SellerSalesQuery) ([]domain.SellerDailySales, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SellerDailySales), args.Error(1)
+}
+
+func (m *MockOrderRepository) AmendOrder(ctx context.Context, order *domain.Order, removed []uuid.UUID, amendment *domain.OrderAmendment) error {
+	args := m.Called(ctx, order, removed, amendment)
 	return args.Error(0)
 }
 
+func (m *MockOrderRepository) ListAmendments(ctx context.Context, orderID uuid.UUID) ([]domain.OrderAmendment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrderAmendment), args.Error(1)
+}
+
 type MockEventProducer struct {
 	mock.Mock
 }
@@ -48,3 +110,181 @@ func (m *MockEventProducer) EmitOrderPaid(ctx context.Context, order *domain.Ord
 	args := m.Called(ctx, order)
 	return args.Error(0)
 }
+
+func (m *MockEventProducer) EmitOrderStatusChanged(ctx context.Context, change *domain.OrderStatusChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockEventProducer) EmitOrderAmended(ctx context.Context, order *domain.Order, amendment *domain.OrderAmendment) error {
+	args := m.Called(ctx, order, amendment)
+	return args.Error(0)
+}
+
+func (m *MockEventProducer) EmitPaymentRefundRequested(ctx context.Context, order *domain.Order, paymentID string, amount float64) error {
+	args := m.Called(ctx, order, paymentID, amount)
+	return args.Error(0)
+}
+
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) CreatePromotion(ctx context.Context, promotion *domain.Promotion) error {
+	args := m.Called(ctx, promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) ListPromotions(ctx context.Context, includeInactive bool) ([]domain.Promotion, error) {
+	args := m.Called(ctx, includeInactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) SetPromotionActive(ctx context.Context, id uuid.UUID, active bool) error {
+	args := m.Called(ctx, id, active)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) ApplicablePromotions(ctx context.Context, codes []string, at time.Time) ([]domain.Promotion, error) {
+	args := m.Called(ctx, codes, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) CountRedemptions(ctx context.Context, userID uuid.UUID, promotionIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, userID, promotionIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+// flatTax taxes every line at one rate, on top of the prices.
+type flatTax float64
+
+func (r flatTax) CalculateTax(_ context.Context, _ domain.OrderAddress, lines []domain.TaxableLine) (*domain.TaxQuote, error) {
+	quote := &domain.TaxQuote{}
+	for _, line := range lines {
+		quote.Lines = append(quote.Lines, domain.LineTax{Rate: float64(r), Amount: domain.TaxAt(line.Amount, float64(r), false)})
+	}
+	return quote, nil
+}
+
+// flatShipping charges one fee for every shipment.
+type flatShipping float64
+
+func (f flatShipping) QuoteShipping(context.Context, domain.OrderAddress, []domain.OrderItem, float64, string) (*domain.ShippingQuote, error) {
+	return &domain.ShippingQuote{Amount: float64(f), Zone: "flat"}, nil
+}
+
+type MockReturnRepository struct {
+	mock.Mock
+}
+
+func (m *MockReturnRepository) CreateReturn(ctx context.Context, ret *domain.OrderReturn, entry *domain.OrderStatusHistory) error {
+	args := m.Called(ctx, ret, entry)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) GetReturn(ctx context.Context, id uuid.UUID) (*domain.OrderReturn, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrderReturn), args.Error(1)
+}
+
+func (m *MockReturnRepository) ListReturns(ctx context.Context, query domain.ReturnListQuery) ([]domain.OrderReturn, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrderReturn), args.Error(1)
+}
+
+func (m *MockReturnRepository) UpdateReturn(ctx context.Context, ret *domain.OrderReturn, from domain.ReturnStatus) error {
+	args := m.Called(ctx, ret, from)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) DeliveredAt(ctx context.Context, orderID uuid.UUID) (*time.Time, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+type MockReturnEventProducer struct {
+	mock.Mock
+}
+
+func (m *MockReturnEventProducer) EmitReturnApproved(ctx context.Context, order *domain.Order, ret *domain.OrderReturn) error {
+	args := m.Called(ctx, order, ret)
+	return args.Error(0)
+}
+
+func (m *MockReturnEventProducer) EmitRefundRequested(ctx context.Context, order *domain.Order, ret *domain.OrderReturn) error {
+	args := m.Called(ctx, order, ret)
+	return args.Error(0)
+}
+
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+func (m *MockArchiveRepository) ArchiveOrders(ctx context.Context, query domain.ArchiveQuery) (int, error) {
+	args := m.Called(ctx, query)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockArchiveRepository) PendingExports(ctx context.Context, limit int) ([]domain.ArchivedOrder, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ArchivedOrder), args.Error(1)
+}
+
+func (m *MockArchiveRepository) MarkExported(ctx context.Context, ids []uuid.UUID, location string) error {
+	args := m.Called(ctx, ids, location)
+	return args.Error(0)
+}
+
+// memoryArchive keeps exported files by name.
+type memoryArchive map[string][]byte
+
+func (a memoryArchive) Put(_ context.Context, name string, data []byte) (string, error) {
+	a[name] = data
+	return "mem://" + name, nil
+}
+
+type MockUserDataRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserDataRepository) UserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserData), args.Error(1)
+}
+
+func (m *MockUserDataRepository) EraseUser(ctx context.Context, erasure *domain.UserErasure) error {
+	args := m.Called(ctx, erasure)
+	return args.Error(0)
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// priceOrder sets the amounts of an order from its items and discounts. The
+// discounts lower the taxable amounts, so tax comes after them, and shipping
+// is quoted last on the value of the goods after discounts.
+func priceOrder(ctx context.Context, taxes domain.TaxCalculator, shipping domain.ShippingQuoter, order *domain.Order, items []domain.OrderItem, discounts []domain.OrderDiscount, shippingAddress domain.OrderAddress) error {
+	order.Price(items, discounts)
+
+	lines := domain.TaxableLines(items, order.DiscountAmount)
+	quote, err := taxes.CalculateTax(ctx, shippingAddress, lines)
+	if err != nil {
+		return fmt.Errorf("failed to calculate tax: %w", err)
+	}
+	if err := order.ApplyTax(items, lines, quote); err != nil {
+		return err
+	}
+
+	shippingQuote, err := shipping.QuoteShipping(ctx, shippingAddress, items, order.Subtotal-order.DiscountAmount, order.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to quote shipping: %w", err)
+	}
+	order.ApplyShipping(shippingQuote)
+	return nil
+}
+
+// newAddress builds and validates an address of the order.
+func newAddress(orderID uuid.UUID, kind domain.AddressKind, in AddressInput, defaultCountry string) (*domain.OrderAddress, error) {
+	address := &domain.OrderAddress{
+		ID:           uuid.New(),
+		OrderID:      orderID,
+		Kind:         kind,
+		FullName:     in.FullName,
+		Phone:        in.Phone,
+		Country:      in.Country,
+		Region:       in.Region,
+		City:         in.City,
+		Street:       in.Street,
+		AddressLine2: in.AddressLine2,
+		PostalCode:   in.PostalCode,
+		Latitude:     in.Latitude,
+		Longitude:    in.Longitude,
+	}
+	if err := address.Normalize(defaultCountry); err != nil {
+		return nil, fmt.Errorf("%s address: %w", strings.ToLower(string(kind)), err)
+	}
+	return address, nil
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// PromotionsUseCase manages the promotions applied when orders are created.
+type PromotionsUseCase struct {
+	repo domain.PromotionRepository
+}
+
+func NewPromotionsUseCase(repo domain.PromotionRepository) *PromotionsUseCase {
+	return &PromotionsUseCase{repo: repo}
+}
+
+// Create stores a new, active promotion. Usage counts start at zero.
+func (uc *PromotionsUseCase) Create(ctx context.Context, promotion domain.Promotion) (*domain.Promotion, error) {
+	promotion.ID = uuid.New()
+	promotion.Code = domain.NormalizeCouponCode(promotion.Code)
+	promotion.Uses = 0
+	promotion.Active = true
+	promotion.CreatedAt = time.Now()
+	if err := promotion.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.CreatePromotion(ctx, &promotion); err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// List returns the promotions, newest first, with the inactive ones when
+// asked to.
+func (uc *PromotionsUseCase) List(ctx context.Context, includeInactive bool) ([]domain.Promotion, error) {
+	return uc.repo.ListPromotions(ctx, includeInactive)
+}
+
+// SetActive switches a promotion on or off and returns it. Orders already
+// created keep their discounts.
+func (uc *PromotionsUseCase) SetActive(ctx context.Context, id uuid.UUID, active bool) (*domain.Promotion, error) {
+	if err := uc.repo.SetPromotionActive(ctx, id, active); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetPromotion(ctx, id)
+}
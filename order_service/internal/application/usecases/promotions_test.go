@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPromotionsUseCase_Create(t *testing.T) {
+	mockRepo := new(MockPromotionRepository)
+	uc := NewPromotionsUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("CreatePromotion", ctx, mock.Anything).Return(nil)
+
+	p, err := uc.Create(ctx, domain.Promotion{Code: " welcome ", Kind: domain.PromotionPercentage, Value: 10, Uses: 7})
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, p.ID)
+	assert.Equal(t, "WELCOME", p.Code)
+	assert.True(t, p.Active)
+	assert.Zero(t, p.Uses)
+
+	_, err = uc.Create(ctx, domain.Promotion{Kind: domain.PromotionPercentage, Value: 0})
+	assert.ErrorIs(t, err, domain.ErrInvalidPromotion)
+	mockRepo.AssertNumberOfCalls(t, "CreatePromotion", 1)
+}
+
+func TestPromotionsUseCase_SetActive(t *testing.T) {
+	mockRepo := new(MockPromotionRepository)
+	uc := NewPromotionsUseCase(mockRepo)
+	ctx := context.Background()
+	id := uuid.New()
+
+	mockRepo.On("SetPromotionActive", ctx, id, false).Return(nil)
+	mockRepo.On("GetPromotion", ctx, id).Return(&domain.Promotion{ID: id}, nil)
+
+	p, err := uc.SetActive(ctx, id, false)
+	assert.NoError(t, err)
+	assert.Equal(t, id, p.ID)
+
+	missing := uuid.New()
+	mockRepo.On("SetPromotionActive", ctx, missing, true).Return(domain.ErrPromotionNotFound)
+	_, err = uc.SetActive(ctx, missing, true)
+	assert.ErrorIs(t, err, domain.ErrPromotionNotFound)
+}
@@ -0,0 +1,331 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// maxReturnReason bounds the length of a return or rejection reason.
+const maxReturnReason = 500
+
+var (
+	// ErrOrderNotReturnable is returned when requesting a return of an order
+	// that was not delivered or was already returned in full.
+	ErrOrderNotReturnable = domain.NewError(domain.ErrPreconditionFailed, "ORDER_NOT_RETURNABLE", "order cannot be returned")
+	// ErrReturnWindowClosed is returned when the return window of the order
+	// has passed.
+	ErrReturnWindowClosed = domain.NewError(domain.ErrPreconditionFailed, "RETURN_WINDOW_CLOSED", "return window has closed")
+	// ErrInvalidReturn is returned, wrapped with the reason, for a return
+	// without a reason, of unknown lines or of more units than remain.
+	ErrInvalidReturn = domain.NewError(domain.ErrInvalidArgument, "INVALID_RETURN", "invalid return")
+)
+
+type RequestReturnInput struct {
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+	Reason  string
+	Items   []ReturnItemInput
+}
+
+type ReturnItemInput struct {
+	OrderItemID uuid.UUID
+	Quantity    int
+}
+
+type ListReturnsInput struct {
+	OrderID  uuid.UUID
+	UserID   uuid.UUID
+	Statuses []domain.ReturnStatus
+	PageSize int
+}
+
+// ReturnsUseCase runs returns from the customer's request to the refund.
+// Approved returns are handed to the delivery service for pickup, and picked
+// up ones to the payment service for refund; both answer with events that
+// advance the return. The order's status follows its returns.
+type ReturnsUseCase struct {
+	orders       domain.OrderRepository
+	returns      domain.ReturnRepository
+	producer     domain.ReturnEventProducer
+	updateStatus *UpdateOrderStatusUseCase
+	window       time.Duration
+	now          func() time.Time
+}
+
+func NewReturnsUseCase(orders domain.OrderRepository, returns domain.ReturnRepository, producer domain.ReturnEventProducer, updateStatus *UpdateOrderStatusUseCase, window time.Duration) *ReturnsUseCase {
+	return &ReturnsUseCase{
+		orders:       orders,
+		returns:      returns,
+		producer:     producer,
+		updateStatus: updateStatus,
+		window:       window,
+		now:          time.Now,
+	}
+}
+
+// Request records a customer's return of some units of their order's lines,
+// within the return window after delivery. Units already in a return that
+// was not rejected cannot be returned again.
+func (uc *ReturnsUseCase) Request(ctx context.Context, input RequestReturnInput) (*domain.OrderReturn, error) {
+	order, err := uc.orders.GetOrderByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != input.UserID {
+		return nil, domain.ErrOrderNotFound
+	}
+	if !order.Returnable() {
+		return nil, ErrOrderNotReturnable
+	}
+	deliveredAt, err := uc.returns.DeliveredAt(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	if deliveredAt == nil {
+		return nil, ErrOrderNotReturnable
+	}
+	if now.After(deliveredAt.Add(uc.window)) {
+		return nil, ErrReturnWindowClosed
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > maxReturnReason {
+		return nil, fmt.Errorf("%w: a reason of at most %d characters is required", ErrInvalidReturn, maxReturnReason)
+	}
+	if len(input.Items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", ErrInvalidReturn)
+	}
+
+	existing, err := uc.returns.ListReturns(ctx, domain.ReturnListQuery{OrderID: order.ID})
+	if err != nil {
+		return nil, err
+	}
+	returned := map[uuid.UUID]int{}
+	for _, r := range existing {
+		if r.Status == domain.ReturnRejected {
+			continue
+		}
+		for _, item := range r.Items {
+			returned[item.OrderItemID] += item.Quantity
+		}
+	}
+
+	ret := &domain.OrderReturn{
+		ID:          uuid.New(),
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		Status:      domain.ReturnRequested,
+		Reason:      reason,
+		RequestedAt: now,
+		UpdatedAt:   now,
+	}
+	seen := map[uuid.UUID]bool{}
+	var refund int64
+	for _, in := range input.Items {
+		item := findItem(order.Items, in.OrderItemID)
+		switch {
+		case item == nil:
+			return nil, fmt.Errorf("%w: item %s is not in the order", ErrInvalidReturn, in.OrderItemID)
+		case seen[item.ID]:
+			return nil, fmt.Errorf("%w: item %s is listed twice", ErrInvalidReturn, item.ID)
+		case in.Quantity <= 0:
+			return nil, fmt.Errorf("%w: quantity of item %s must be positive", ErrInvalidReturn, item.ID)
+		case in.Quantity > item.Quantity-returned[item.ID]:
+			return nil, fmt.Errorf("%w: only %d units of item %s can be returned", ErrInvalidReturn, item.Quantity-returned[item.ID], item.ID)
+		}
+		seen[item.ID] = true
+		line := item.ReturnItem(ret.ID, in.Quantity, returned[item.ID])
+		refund += toCents(line.RefundAmount)
+		ret.Items = append(ret.Items, line)
+	}
+	ret.RefundAmount = float64(refund) / 100
+
+	// The order moves to RETURN_REQUESTED with the return, so a return is
+	// never recorded without it.
+	var history *domain.OrderStatusHistory
+	if order.Status != domain.StatusReturnRequested {
+		history = &domain.OrderStatusHistory{
+			ID:        uuid.New(),
+			OrderID:   order.ID,
+			Status:    domain.StatusReturnRequested,
+			Actor:     domain.Actor{Type: domain.ActorUser, ID: order.UserID.String()},
+			Reason:    "return requested",
+			Metadata:  map[string]string{"return_id": ret.ID.String()},
+			ChangedAt: now,
+		}
+	}
+
+	// Another return of the order may have taken the units since they were
+	// counted; the repository checks them again.
+	if err := uc.returns.CreateReturn(ctx, ret, history); err != nil {
+		if errors.Is(err, domain.ErrReturnExceedsOrder) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidReturn, err)
+		}
+		return nil, err
+	}
+	if history != nil && history.Sequence != 0 {
+		uc.updateStatus.emitStatusChanged(ctx, order.UserID, history)
+	}
+	return ret, nil
+}
+
+// Get returns a return with its items.
+func (uc *ReturnsUseCase) Get(ctx context.Context, id uuid.UUID) (*domain.OrderReturn, error) {
+	return uc.returns.GetReturn(ctx, id)
+}
+
+// List returns the returns of an order or a user, or all of them, newest
+// first, optionally in some statuses only.
+func (uc *ReturnsUseCase) List(ctx context.Context, input ListReturnsInput) ([]domain.OrderReturn, error) {
+	size := input.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	return uc.returns.ListReturns(ctx, domain.ReturnListQuery{
+		OrderID:  input.OrderID,
+		UserID:   input.UserID,
+		Statuses: input.Statuses,
+		Limit:    min(size, maxPageSize),
+	})
+}
+
+// Approve accepts a requested return and asks the delivery service to pick
+// up its items. Approving an approved return publishes the pickup request
+// again, so an approval whose event was lost can be retried.
+func (uc *ReturnsUseCase) Approve(ctx context.Context, id uuid.UUID) (*domain.OrderReturn, error) {
+	ret, err := uc.returns.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != domain.ReturnApproved {
+		now := uc.now()
+		ret.Status, ret.DecidedAt, ret.UpdatedAt = domain.ReturnApproved, &now, now
+		if err := uc.returns.UpdateReturn(ctx, ret, domain.ReturnRequested); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := uc.orders.GetOrderByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("loading order %s: %w", ret.OrderID, err)
+	}
+	if err := uc.producer.EmitReturnApproved(ctx, order, ret); err != nil {
+		return nil, fmt.Errorf("emitting return.approved: %w", err)
+	}
+	return ret, nil
+}
+
+// Reject refuses a requested return. The order leaves RETURN_REQUESTED once
+// none of its returns is open.
+func (uc *ReturnsUseCase) Reject(ctx context.Context, id uuid.UUID, reason string) (*domain.OrderReturn, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > maxReturnReason {
+		return nil, fmt.Errorf("%w: a reason of at most %d characters is required", ErrInvalidReturn, maxReturnReason)
+	}
+	ret, err := uc.returns.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	ret.Status, ret.RejectReason, ret.DecidedAt, ret.UpdatedAt = domain.ReturnRejected, reason, &now, now
+	if err := uc.returns.UpdateReturn(ctx, ret, domain.ReturnRequested); err != nil {
+		return nil, err
+	}
+	if err := uc.settleOrder(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// MarkPickedUp records that the courier collected an approved return and
+// asks the payment service for the refund. Failures are returned so the
+// triggering message is redelivered; a redelivery for a return already
+// picked up publishes the refund request again.
+func (uc *ReturnsUseCase) MarkPickedUp(ctx context.Context, id uuid.UUID) error {
+	ret, err := uc.returns.GetReturn(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ret.Status != domain.ReturnPickedUp {
+		now := uc.now()
+		ret.Status, ret.PickedUpAt, ret.UpdatedAt = domain.ReturnPickedUp, &now, now
+		if err := uc.returns.UpdateReturn(ctx, ret, domain.ReturnApproved); err != nil {
+			return err
+		}
+	}
+
+	order, err := uc.orders.GetOrderByID(ctx, ret.OrderID)
+	if err != nil {
+		return fmt.Errorf("loading order %s: %w", ret.OrderID, err)
+	}
+	if err := uc.producer.EmitRefundRequested(ctx, order, ret); err != nil {
+		return fmt.Errorf("emitting refund.requested: %w", err)
+	}
+	return nil
+}
+
+// MarkRefunded records the refund of a picked up return and moves the order
+// to RETURNED or PARTIALLY_REFUNDED once none of its returns is open.
+func (uc *ReturnsUseCase) MarkRefunded(ctx context.Context, id uuid.UUID) error {
+	ret, err := uc.returns.GetReturn(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ret.Status != domain.ReturnRefunded {
+		now := uc.now()
+		ret.Status, ret.RefundedAt, ret.UpdatedAt = domain.ReturnRefunded, &now, now
+		if err := uc.returns.UpdateReturn(ctx, ret, domain.ReturnPickedUp); err != nil {
+			return err
+		}
+	}
+	return uc.settleOrder(ctx, ret)
+}
+
+// settleOrder moves the order to the status its returns call for, recording
+// and publishing the transition like any other. The change is the system's,
+// following the last return to close.
+func (uc *ReturnsUseCase) settleOrder(ctx context.Context, closed *domain.OrderReturn) error {
+	orderID := closed.OrderID
+	order, err := uc.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	returns, err := uc.returns.ListReturns(ctx, domain.ReturnListQuery{OrderID: orderID})
+	if err != nil {
+		return err
+	}
+	status := domain.StatusAfterReturns(order.Items, returns)
+	if status == order.Status {
+		return nil
+	}
+	log.Printf("Returns of OrderID %s settle it as %s", orderID, status)
+	return uc.updateStatus.Execute(ctx, StatusUpdateInput{
+		OrderID:  orderID,
+		Status:   status,
+		Actor:    domain.Actor{Type: domain.ActorSystem},
+		Reason:   "returns settled",
+		Metadata: map[string]string{"return_id": closed.ID.String(), "return_status": string(closed.Status)},
+	})
+}
+
+func findItem(items []domain.OrderItem, id uuid.UUID) *domain.OrderItem {
+	for i := range items {
+		if items[i].ID == id {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type returnsFixture struct {
+	orders   *MockOrderRepository
+	returns  *MockReturnRepository
+	producer *MockReturnEventProducer
+	events   *MockEventProducer
+	uc       *ReturnsUseCase
+	order    *domain.Order
+	now      time.Time
+}
+
+func newReturnsFixture(status domain.OrderStatus) *returnsFixture {
+	f := &returnsFixture{
+		orders:   new(MockOrderRepository),
+		returns:  new(MockReturnRepository),
+		producer: new(MockReturnEventProducer),
+		events:   new(MockEventProducer),
+		now:      time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+	}
+	orderID := uuid.New()
+	f.order = &domain.Order{
+		ID: orderID, UserID: uuid.New(), Status: status, Currency: "ETB",
+		Items: []domain.OrderItem{
+			{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), SellerID: uuid.New(), UnitPrice: 40, Quantity: 3, TaxableAmount: 120, TaxAmount: 18},
+			{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), SellerID: uuid.New(), UnitPrice: 10, Quantity: 1, TaxableAmount: 10},
+		},
+	}
+	f.uc = NewReturnsUseCase(f.orders, f.returns, f.producer, NewUpdateOrderStatusUseCase(f.orders, f.events), 14*24*time.Hour)
+	f.uc.now = func() time.Time { return f.now }
+	return f
+}
+
+// expectStatus expects the order to move to status, caused by actor.
+func (f *returnsFixture) expectStatus(status domain.OrderStatus, actor domain.ActorType) {
+	f.orders.On("ChangeStatus", mock.Anything, mock.MatchedBy(func(e *domain.OrderStatusHistory) bool {
+		return e.OrderID == f.order.ID && e.Status == status && e.Actor.Type == actor && e.Metadata["return_id"] != ""
+	}), mock.Anything).Return(nil).Once()
+	f.events.On("EmitOrderStatusChanged", mock.Anything, mock.Anything).Return(nil).Once()
+}
+
+func TestReturnsUseCase_Request(t *testing.T) {
+	f := newReturnsFixture(domain.StatusDelivered)
+	ctx := context.Background()
+	delivered := f.now.Add(-48 * time.Hour)
+	lamp := f.order.Items[0]
+
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.returns.On("DeliveredAt", ctx, f.order.ID).Return(&delivered, nil)
+	f.returns.On("ListReturns", ctx, domain.ReturnListQuery{OrderID: f.order.ID}).Return([]domain.OrderReturn{
+		{Status: domain.ReturnRejected, Items: []domain.OrderReturnItem{{OrderItemID: lamp.ID, Quantity: 3}}},
+		{Status: domain.ReturnRefunded, Items: []domain.OrderReturnItem{{OrderItemID: lamp.ID, Quantity: 1}}},
+	}, nil)
+	// The order moves to RETURN_REQUESTED in the return's transaction.
+	f.returns.On("CreateReturn", ctx, mock.AnythingOfType("*domain.OrderReturn"), mock.MatchedBy(func(e *domain.OrderStatusHistory) bool {
+		return e.OrderID == f.order.ID && e.Status == domain.StatusReturnRequested && e.Actor.Type == domain.ActorUser && e.Metadata["return_id"] != ""
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).(*domain.OrderStatusHistory).Sequence = 7
+	}).Return(nil).Once()
+	f.events.On("EmitOrderStatusChanged", mock.Anything, mock.MatchedBy(func(c *domain.OrderStatusChange) bool {
+		return c.Status == domain.StatusReturnRequested && c.Sequence == 7 && c.UserID == f.order.UserID
+	})).Return(nil).Once()
+
+	ret, err := f.uc.Request(ctx, RequestReturnInput{
+		OrderID: f.order.ID, UserID: f.order.UserID, Reason: " broken ",
+		Items: []ReturnItemInput{{OrderItemID: lamp.ID, Quantity: 2}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReturnRequested, ret.Status)
+	assert.Equal(t, "broken", ret.Reason)
+	require.Len(t, ret.Items, 1)
+	assert.Equal(t, 2, ret.Items[0].Quantity)
+	assert.Equal(t, 80.0, ret.Items[0].SaleAmount)
+	assert.Equal(t, 92.0, ret.RefundAmount, "two thirds of 138 paid, the last unit included")
+	f.orders.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	f.returns.AssertExpectations(t)
+	f.events.AssertExpectations(t)
+}
+
+func TestReturnsUseCase_Request_Rejects(t *testing.T) {
+	lampQuantity := func(f *returnsFixture, quantity int) []ReturnItemInput {
+		return []ReturnItemInput{{OrderItemID: f.order.Items[0].ID, Quantity: quantity}}
+	}
+	tests := []struct {
+		name      string
+		status    domain.OrderStatus
+		delivered time.Duration // before now
+		items     func(f *returnsFixture) []ReturnItemInput
+		otherUser bool
+		want      error
+	}{
+		{"not delivered", domain.StatusShipped, time.Hour, func(f *returnsFixture) []ReturnItemInput { return lampQuantity(f, 1) }, false, ErrOrderNotReturnable},
+		{"someone else's order", domain.StatusDelivered, time.Hour, func(f *returnsFixture) []ReturnItemInput { return lampQuantity(f, 1) }, true, domain.ErrOrderNotFound},
+		{"window closed", domain.StatusDelivered, 15 * 24 * time.Hour, func(f *returnsFixture) []ReturnItemInput { return lampQuantity(f, 1) }, false, ErrReturnWindowClosed},
+		{"too many units", domain.StatusDelivered, time.Hour, func(f *returnsFixture) []ReturnItemInput { return lampQuantity(f, 3) }, false, ErrInvalidReturn},
+		{"unknown item", domain.StatusDelivered, time.Hour, func(*returnsFixture) []ReturnItemInput {
+			return []ReturnItemInput{{OrderItemID: uuid.New(), Quantity: 1}}
+		}, false, ErrInvalidReturn},
+		{"no items", domain.StatusDelivered, time.Hour, func(*returnsFixture) []ReturnItemInput { return nil }, false, ErrInvalidReturn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newReturnsFixture(tt.status)
+			ctx := context.Background()
+			delivered := f.now.Add(-tt.delivered)
+			f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+			f.returns.On("DeliveredAt", ctx, f.order.ID).Return(&delivered, nil)
+			f.returns.On("ListReturns", ctx, mock.Anything).Return([]domain.OrderReturn{
+				{Status: domain.ReturnApproved, Items: []domain.OrderReturnItem{{OrderItemID: f.order.Items[0].ID, Quantity: 1}}},
+			}, nil)
+
+			userID := f.order.UserID
+			if tt.otherUser {
+				userID = uuid.New()
+			}
+			_, err := f.uc.Request(ctx, RequestReturnInput{OrderID: f.order.ID, UserID: userID, Reason: "broken", Items: tt.items(f)})
+
+			assert.ErrorIs(t, err, tt.want)
+			f.returns.AssertNotCalled(t, "CreateReturn", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReturnsUseCase_Approve(t *testing.T) {
+	f := newReturnsFixture(domain.StatusReturnRequested)
+	ctx := context.Background()
+	ret := &domain.OrderReturn{ID: uuid.New(), OrderID: f.order.ID, Status: domain.ReturnRequested}
+
+	f.returns.On("GetReturn", ctx, ret.ID).Return(ret, nil)
+	f.returns.On("UpdateReturn", ctx, ret, domain.ReturnRequested).Return(nil).Once()
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.producer.On("EmitReturnApproved", ctx, f.order, ret).Return(errors.New("broker down")).Once()
+
+	_, err := f.uc.Approve(ctx, ret.ID)
+	assert.Error(t, err)
+	assert.Equal(t, domain.ReturnApproved, ret.Status, "the approval is kept")
+
+	// Retrying publishes the pickup request without deciding again.
+	f.producer.On("EmitReturnApproved", ctx, f.order, ret).Return(nil).Once()
+	_, err = f.uc.Approve(ctx, ret.ID)
+	assert.NoError(t, err)
+	f.returns.AssertNumberOfCalls(t, "UpdateReturn", 1)
+	f.producer.AssertExpectations(t)
+}
+
+func TestReturnsUseCase_PickupAndRefund(t *testing.T) {
+	f := newReturnsFixture(domain.StatusReturnRequested)
+	ctx := context.Background()
+	ret := &domain.OrderReturn{
+		ID: uuid.New(), OrderID: f.order.ID, Status: domain.ReturnApproved,
+		Items: []domain.OrderReturnItem{{OrderItemID: f.order.Items[0].ID, Quantity: 1}},
+	}
+
+	f.returns.On("GetReturn", ctx, ret.ID).Return(ret, nil)
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.returns.On("UpdateReturn", ctx, ret, domain.ReturnApproved).Return(nil).Once()
+	f.producer.On("EmitRefundRequested", ctx, f.order, ret).Return(nil).Once()
+
+	require.NoError(t, f.uc.MarkPickedUp(ctx, ret.ID))
+	assert.Equal(t, domain.ReturnPickedUp, ret.Status)
+	assert.Equal(t, f.now, *ret.PickedUpAt)
+
+	f.returns.On("UpdateReturn", ctx, ret, domain.ReturnPickedUp).Return(nil).Once()
+	f.returns.On("ListReturns", ctx, domain.ReturnListQuery{OrderID: f.order.ID}).
+		Return([]domain.OrderReturn{{Status: domain.ReturnRefunded, Items: ret.Items}}, nil)
+	f.expectStatus(domain.StatusPartiallyRefunded, domain.ActorSystem)
+
+	require.NoError(t, f.uc.MarkRefunded(ctx, ret.ID))
+	assert.Equal(t, domain.ReturnRefunded, ret.Status)
+	f.orders.AssertExpectations(t)
+	f.producer.AssertExpectations(t)
+}
+
+func TestReturnsUseCase_Reject_SettlesOrder(t *testing.T) {
+	f := newReturnsFixture(domain.StatusReturnRequested)
+	ctx := context.Background()
+	ret := &domain.OrderReturn{ID: uuid.New(), OrderID: f.order.ID, Status: domain.ReturnRequested}
+
+	f.returns.On("GetReturn", ctx, ret.ID).Return(ret, nil)
+	f.returns.On("UpdateReturn", ctx, ret, domain.ReturnRequested).Return(nil)
+	f.orders.On("GetOrderByID", ctx, f.order.ID).Return(f.order, nil)
+	f.returns.On("ListReturns", ctx, mock.Anything).Return([]domain.OrderReturn{{Status: domain.ReturnRejected}}, nil)
+	f.expectStatus(domain.StatusDelivered, domain.ActorSystem)
+
+	got, err := f.uc.Reject(ctx, ret.ID, "used")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReturnRejected, got.Status)
+	assert.Equal(t, "used", got.RejectReason)
+	f.orders.AssertExpectations(t)
+
+	_, err = f.uc.Reject(ctx, ret.ID, " ")
+	assert.ErrorIs(t, err, ErrInvalidReturn)
+}
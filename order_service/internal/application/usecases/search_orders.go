@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// minPhoneFragment is the shortest phone fragment searched for; shorter ones
+// match most orders and cannot use the trigram index.
+const minPhoneFragment = 3
+
+// ErrInvalidSearch is returned, wrapped with the reason, for contradictory or
+// unsupported search criteria.
+var ErrInvalidSearch = domain.NewError(domain.ErrInvalidArgument, "INVALID_SEARCH", "invalid search")
+
+type SearchOrdersInput struct {
+	UserID        uuid.UUID
+	SellerID      uuid.UUID
+	ProductID     uuid.UUID
+	Statuses      []domain.OrderStatus
+	MinTotal      *float64
+	MaxTotal      *float64
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+	City          string
+	PhoneFragment string
+	SortBy        domain.OrderSortField
+	Ascending     bool
+	Page          int
+	PageSize      int
+}
+
+type SearchOrdersOutput struct {
+	Orders     []domain.Order
+	TotalCount int64
+	Page       int
+	PageSize   int
+}
+
+type SearchOrdersUseCase struct {
+	repo domain.OrderRepository
+}
+
+func NewSearchOrdersUseCase(repo domain.OrderRepository) *SearchOrdersUseCase {
+	return &SearchOrdersUseCase{repo: repo}
+}
+
+// Execute returns one page of the orders matching the input, newest first
+// unless another sort is requested, and the number of matches on all pages.
+// Pages are numbered from 1.
+func (uc *SearchOrdersUseCase) Execute(ctx context.Context, input SearchOrdersInput) (*SearchOrdersOutput, error) {
+	if err := validateSearch(input); err != nil {
+		return nil, err
+	}
+
+	size := input.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	size = min(size, maxPageSize)
+	page := max(input.Page, 1)
+
+	sortBy := input.SortBy
+	if sortBy == "" {
+		sortBy = domain.SortByCreatedAt
+	}
+
+	orders, total, err := uc.repo.SearchOrders(ctx, domain.OrderSearchQuery{
+		UserID:        input.UserID,
+		SellerID:      input.SellerID,
+		ProductID:     input.ProductID,
+		Statuses:      input.Statuses,
+		MinTotal:      input.MinTotal,
+		MaxTotal:      input.MaxTotal,
+		CreatedFrom:   input.CreatedFrom,
+		CreatedTo:     input.CreatedTo,
+		City:          input.City,
+		PhoneFragment: input.PhoneFragment,
+		SortBy:        sortBy,
+		Descending:    !input.Ascending,
+		Offset:        (page - 1) * size,
+		Limit:         size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SearchOrdersOutput{Orders: orders, TotalCount: total, Page: page, PageSize: size}, nil
+}
+
+func validateSearch(input SearchOrdersInput) error {
+	switch input.SortBy {
+	case "", domain.SortByCreatedAt, domain.SortByUpdatedAt, domain.SortByTotalAmount:
+	default:
+		return fmt.Errorf("%w: cannot sort by %q", ErrInvalidSearch, input.SortBy)
+	}
+	if input.MinTotal != nil && input.MaxTotal != nil && *input.MinTotal > *input.MaxTotal {
+		return fmt.Errorf("%w: min_total exceeds max_total", ErrInvalidSearch)
+	}
+	if !input.CreatedFrom.IsZero() && !input.CreatedTo.IsZero() && !input.CreatedFrom.Before(input.CreatedTo) {
+		return fmt.Errorf("%w: created_from must be before created_to", ErrInvalidSearch)
+	}
+	if n := len([]rune(input.PhoneFragment)); n > 0 && n < minPhoneFragment {
+		return fmt.Errorf("%w: phone must have at least %d characters", ErrInvalidSearch, minPhoneFragment)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchOrdersUseCase_Execute_PagesNewestFirst(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewSearchOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	sellerID := uuid.New()
+	orders := []domain.Order{{ID: uuid.New()}}
+	mockRepo.On("SearchOrders", ctx, domain.OrderSearchQuery{
+		SellerID:   sellerID,
+		City:       "Addis Ababa",
+		SortBy:     domain.SortByCreatedAt,
+		Descending: true,
+		Offset:     20,
+		Limit:      10,
+	}).Return(orders, int64(21), nil)
+
+	out, err := uc.Execute(ctx, SearchOrdersInput{SellerID: sellerID, City: "Addis Ababa", Page: 3, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, orders, out.Orders)
+	assert.Equal(t, int64(21), out.TotalCount)
+	assert.Equal(t, 3, out.Page)
+	assert.Equal(t, 10, out.PageSize)
+}
+
+func TestSearchOrdersUseCase_Execute_Defaults(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewSearchOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("SearchOrders", ctx, domain.OrderSearchQuery{
+		SortBy: domain.SortByTotalAmount,
+		Limit:  maxPageSize,
+	}).Return([]domain.Order{}, int64(0), nil)
+
+	out, err := uc.Execute(ctx, SearchOrdersInput{SortBy: domain.SortByTotalAmount, Ascending: true, PageSize: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.Page)
+	assert.Equal(t, maxPageSize, out.PageSize)
+}
+
+func TestSearchOrdersUseCase_Execute_RejectsInvalidCriteria(t *testing.T) {
+	low, high := 10.0, 5.0
+	now := time.Now()
+	cases := map[string]SearchOrdersInput{
+		"sort":         {SortBy: "status"},
+		"amount range": {MinTotal: &low, MaxTotal: &high},
+		"date range":   {CreatedFrom: now, CreatedTo: now.Add(-time.Hour)},
+		"short phone":  {PhoneFragment: "12"},
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(MockOrderRepository)
+			uc := NewSearchOrdersUseCase(mockRepo)
+
+			_, err := uc.Execute(context.Background(), input)
+			assert.ErrorIs(t, err, ErrInvalidSearch)
+			mockRepo.AssertNotCalled(t, "SearchOrders", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// maxSalesDays bounds the range of a daily sales report.
+const maxSalesDays = 92
+
+var (
+	// ErrOrderNotFulfillable is returned when marking lines of an order that
+	// is not paid, or already shipped or canceled.
+	ErrOrderNotFulfillable = domain.NewError(domain.ErrPreconditionFailed, "ORDER_NOT_FULFILLABLE", "order is not awaiting fulfillment")
+	// ErrOrderLineNotFound is returned for an item that is not one of the
+	// seller's lines in the order.
+	ErrOrderLineNotFound = domain.NewError(domain.ErrNotFound, "ORDER_LINE_NOT_FOUND", "order line not found")
+	// ErrInvalidFulfillment is returned, wrapped with the reason, when a line
+	// would move back or to an unknown status.
+	ErrInvalidFulfillment = domain.NewError(domain.ErrPreconditionFailed, "INVALID_FULFILLMENT", "invalid fulfillment update")
+	// ErrInvalidSalesRange is returned for an empty or too long sales range.
+	ErrInvalidSalesRange = domain.NewError(domain.ErrInvalidArgument, "INVALID_SALES_RANGE", "invalid sales range")
+)
+
+type ListSellerLinesInput struct {
+	SellerID            uuid.UUID
+	OrderStatuses       []domain.OrderStatus
+	FulfillmentStatuses []domain.FulfillmentStatus
+	PageSize            int
+	PageToken           string
+}
+
+type ListSellerLinesOutput struct {
+	Lines         []domain.SellerOrderLine
+	NextPageToken string
+}
+
+type UpdateFulfillmentInput struct {
+	SellerID uuid.UUID
+	OrderID  uuid.UUID
+	// ItemIDs selects the lines to update; empty means all of the seller's
+	// lines in the order.
+	ItemIDs []uuid.UUID
+	Status  domain.FulfillmentStatus
+}
+
+// SellerOrdersUseCase serves the seller's view of the orders: the lines they
+// sold, their fulfillment and their sales.
+type SellerOrdersUseCase struct {
+	repo domain.OrderRepository
+}
+
+func NewSellerOrdersUseCase(repo domain.OrderRepository) *SellerOrdersUseCase {
+	return &SellerOrdersUseCase{repo: repo}
+}
+
+// ListLines returns one page of the seller's order lines, newest order first.
+// The next page token is empty on the last page.
+func (uc *SellerOrdersUseCase) ListLines(ctx context.Context, input ListSellerLinesInput) (*ListSellerLinesOutput, error) {
+	size := input.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	size = min(size, maxPageSize)
+
+	query := domain.SellerLineQuery{
+		SellerID:            input.SellerID,
+		OrderStatuses:       input.OrderStatuses,
+		FulfillmentStatuses: input.FulfillmentStatuses,
+		Limit:               size + 1,
+	}
+	if input.PageToken != "" {
+		cursor, err := decodePageToken(input.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		query.After = cursor
+	}
+
+	lines, err := uc.repo.ListSellerLines(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	out := &ListSellerLinesOutput{Lines: lines}
+	if len(lines) > size {
+		out.Lines = lines[:size]
+		last := out.Lines[size-1]
+		out.NextPageToken = encodePageToken(domain.OrderCursor{CreatedAt: last.OrderedAt, ID: last.ID})
+	}
+	return out, nil
+}
+
+// UpdateFulfillment moves the seller's lines in a paid order forward to
+// packed or handed over and returns all of the seller's lines in the order.
+// Lines already at the requested status are left as they are.
+func (uc *SellerOrdersUseCase) UpdateFulfillment(ctx context.Context, input UpdateFulfillmentInput) ([]domain.SellerOrderLine, error) {
+	if input.Status != domain.FulfillmentPacked && input.Status != domain.FulfillmentHandedOver {
+		return nil, fmt.Errorf("%w: cannot mark lines %s", ErrInvalidFulfillment, input.Status)
+	}
+
+	order, err := uc.repo.GetOrderByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	own := sellerLines(order, input.SellerID)
+	if len(own) == 0 {
+		// Other sellers' orders are indistinguishable from missing ones.
+		return nil, domain.ErrOrderNotFound
+	}
+	if !order.Fulfillable() {
+		return nil, ErrOrderNotFulfillable
+	}
+
+	targets := own
+	if len(input.ItemIDs) > 0 {
+		targets = nil
+		for _, id := range input.ItemIDs {
+			i := slices.IndexFunc(own, func(l domain.SellerOrderLine) bool { return l.ID == id })
+			if i < 0 {
+				return nil, fmt.Errorf("%w: %s", ErrOrderLineNotFound, id)
+			}
+			targets = append(targets, own[i])
+		}
+	}
+
+	var pending []uuid.UUID
+	for _, line := range targets {
+		switch {
+		case line.FulfillmentStatus == input.Status:
+		case line.FulfillmentStatus.CanAdvanceTo(input.Status):
+			pending = append(pending, line.ID)
+		default:
+			return nil, fmt.Errorf("%w: line %s is already %s", ErrInvalidFulfillment, line.ID, line.FulfillmentStatus)
+		}
+	}
+	if len(pending) == 0 {
+		return own, nil
+	}
+	if err := uc.repo.UpdateLineFulfillment(ctx, order.ID, pending, input.Status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	order, err = uc.repo.GetOrderByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	return sellerLines(order, input.SellerID), nil
+}
+
+// DailySales returns the seller's sales for each UTC day from from to to,
+// both inclusive, omitting days without sales.
+func (uc *SellerOrdersUseCase) DailySales(ctx context.Context, sellerID uuid.UUID, from, to time.Time) ([]domain.SellerDailySales, error) {
+	from = truncateDay(from)
+	end := truncateDay(to).AddDate(0, 0, 1)
+	if !from.Before(end) {
+		return nil, fmt.Errorf("%w: from is after to", ErrInvalidSalesRange)
+	}
+	if end.Sub(from) > maxSalesDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: at most %d days", ErrInvalidSalesRange, maxSalesDays)
+	}
+	return uc.repo.SellerDailySales(ctx, domain.SellerSalesQuery{SellerID: sellerID, From: from, To: end})
+}
+
+func sellerLines(order *domain.Order, sellerID uuid.UUID) []domain.SellerOrderLine {
+	var lines []domain.SellerOrderLine
+	for _, item := range order.Items {
+		if item.SellerID == sellerID {
+			lines = append(lines, domain.SellerOrderLine{OrderItem: item, OrderStatus: order.Status, OrderedAt: order.CreatedAt})
+		}
+	}
+	return lines
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
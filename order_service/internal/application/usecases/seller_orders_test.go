@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSellerOrdersUseCase_ListLines_Paginates(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewSellerOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	sellerID := uuid.New()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	lines := []domain.SellerOrderLine{
+		{OrderItem: domain.OrderItem{ID: uuid.New(), SellerID: sellerID}, OrderedAt: now},
+		{OrderItem: domain.OrderItem{ID: uuid.New(), SellerID: sellerID}, OrderedAt: now.Add(-time.Minute)},
+	}
+	statuses := []domain.FulfillmentStatus{domain.FulfillmentPending}
+
+	mockRepo.On("ListSellerLines", ctx, domain.SellerLineQuery{SellerID: sellerID, FulfillmentStatuses: statuses, Limit: 2}).Return(lines, nil)
+	first, err := uc.ListLines(ctx, ListSellerLinesInput{SellerID: sellerID, FulfillmentStatuses: statuses, PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, lines[:1], first.Lines)
+	require.NotEmpty(t, first.NextPageToken)
+
+	after := &domain.OrderCursor{CreatedAt: now, ID: lines[0].ID}
+	mockRepo.On("ListSellerLines", ctx, domain.SellerLineQuery{SellerID: sellerID, FulfillmentStatuses: statuses, Limit: 2, After: after}).Return(lines[1:], nil)
+	second, err := uc.ListLines(ctx, ListSellerLinesInput{SellerID: sellerID, FulfillmentStatuses: statuses, PageSize: 1, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, lines[1:], second.Lines)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestSellerOrdersUseCase_UpdateFulfillment(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewSellerOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	sellerID, orderID := uuid.New(), uuid.New()
+	pending := domain.OrderItem{ID: uuid.New(), OrderID: orderID, SellerID: sellerID, FulfillmentStatus: domain.FulfillmentPending}
+	packed := domain.OrderItem{ID: uuid.New(), OrderID: orderID, SellerID: sellerID, FulfillmentStatus: domain.FulfillmentPacked}
+	others := domain.OrderItem{ID: uuid.New(), OrderID: orderID, SellerID: uuid.New(), FulfillmentStatus: domain.FulfillmentPending}
+	order := &domain.Order{ID: orderID, Status: domain.StatusPaid, Items: []domain.OrderItem{pending, packed, others}}
+
+	updated := *order
+	updated.Items = []domain.OrderItem{pending, packed, others}
+	updated.Items[0].FulfillmentStatus = domain.FulfillmentPacked
+
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(order, nil).Once()
+	mockRepo.On("UpdateLineFulfillment", ctx, orderID, []uuid.UUID{pending.ID}, domain.FulfillmentPacked, mock.AnythingOfType("time.Time")).Return(nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&updated, nil).Once()
+
+	lines, err := uc.UpdateFulfillment(ctx, UpdateFulfillmentInput{SellerID: sellerID, OrderID: orderID, Status: domain.FulfillmentPacked})
+	require.NoError(t, err)
+	require.Len(t, lines, 2, "only the seller's own lines are returned")
+	assert.Equal(t, domain.FulfillmentPacked, lines[0].FulfillmentStatus)
+	assert.Equal(t, domain.StatusPaid, lines[0].OrderStatus)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSellerOrdersUseCase_UpdateFulfillment_Rejects(t *testing.T) {
+	sellerID, orderID := uuid.New(), uuid.New()
+	handedOver := domain.OrderItem{ID: uuid.New(), SellerID: sellerID, FulfillmentStatus: domain.FulfillmentHandedOver}
+
+	cases := map[string]struct {
+		order *domain.Order
+		input UpdateFulfillmentInput
+		want  error
+	}{
+		"unpaid order": {
+			order: &domain.Order{ID: orderID, Status: domain.StatusCreated, Items: []domain.OrderItem{handedOver}},
+			input: UpdateFulfillmentInput{Status: domain.FulfillmentPacked},
+			want:  ErrOrderNotFulfillable,
+		},
+		"other seller's order": {
+			order: &domain.Order{ID: orderID, Status: domain.StatusPaid, Items: []domain.OrderItem{{ID: uuid.New(), SellerID: uuid.New()}}},
+			input: UpdateFulfillmentInput{Status: domain.FulfillmentPacked},
+			want:  domain.ErrOrderNotFound,
+		},
+		"unknown line": {
+			order: &domain.Order{ID: orderID, Status: domain.StatusPaid, Items: []domain.OrderItem{handedOver}},
+			input: UpdateFulfillmentInput{ItemIDs: []uuid.UUID{uuid.New()}, Status: domain.FulfillmentPacked},
+			want:  ErrOrderLineNotFound,
+		},
+		"moving back": {
+			order: &domain.Order{ID: orderID, Status: domain.StatusPaid, Items: []domain.OrderItem{handedOver}},
+			input: UpdateFulfillmentInput{Status: domain.FulfillmentPacked},
+			want:  ErrInvalidFulfillment,
+		},
+		"pending": {
+			input: UpdateFulfillmentInput{Status: domain.FulfillmentPending},
+			want:  ErrInvalidFulfillment,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(MockOrderRepository)
+			uc := NewSellerOrdersUseCase(mockRepo)
+			ctx := context.Background()
+			if tc.order != nil {
+				mockRepo.On("GetOrderByID", ctx, orderID).Return(tc.order, nil)
+			}
+
+			tc.input.SellerID, tc.input.OrderID = sellerID, orderID
+			_, err := uc.UpdateFulfillment(ctx, tc.input)
+			assert.ErrorIs(t, err, tc.want)
+			mockRepo.AssertNotCalled(t, "UpdateLineFulfillment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSellerOrdersUseCase_DailySales(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewSellerOrdersUseCase(mockRepo)
+
+	ctx := context.Background()
+	sellerID := uuid.New()
+	from := time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 5, 3, 8, 0, 0, 0, time.UTC)
+	sales := []domain.SellerDailySales{{Day: "2026-05-02", Orders: 1, Units: 2, Revenue: 40}}
+	mockRepo.On("SellerDailySales", ctx, domain.SellerSalesQuery{
+		SellerID: sellerID,
+		From:     time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC),
+	}).Return(sales, nil)
+
+	got, err := uc.DailySales(ctx, sellerID, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, sales, got)
+
+	_, err = uc.DailySales(ctx, sellerID, to, from)
+	assert.ErrorIs(t, err, ErrInvalidSalesRange)
+	_, err = uc.DailySales(ctx, sellerID, from, from.AddDate(1, 0, 0))
+	assert.ErrorIs(t, err, ErrInvalidSalesRange)
+}
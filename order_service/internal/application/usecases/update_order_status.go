@@ -2,12 +2,48 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
 	"github.com/google/uuid"
 )
 
+// maxConflictRetries bounds how often a change is applied again to a freshly
+// loaded order after losing a race with another update.
+const maxConflictRetries = 3
+
+// StatusUpdateInput is a transition of an order to Status, recorded in its
+// audit trail with the actor that caused it. SourceEventID is the ID of the
+// triggering event, if any.
+//
+// Check, when set, vets the order before every attempt, so a precondition
+// holds for the order the change is actually applied to. Forward skips the
+// change when the order already moved past Status or ended its lifecycle,
+// for events that may arrive out of order.
+//
+// Payment is the payment behind a move to PAID. A payment for an order that
+// was canceled before it arrived is refunded in full.
+type StatusUpdateInput struct {
+	OrderID       uuid.UUID
+	Status        domain.OrderStatus
+	Actor         domain.Actor
+	SourceEventID string
+	Reason        string
+	Metadata      map[string]string
+	Check         func(*domain.Order) error
+	Forward       bool
+	Payment       *PaymentInput
+}
+
+// PaymentInput identifies a payment and its amount.
+type PaymentInput struct {
+	ID     string
+	Amount float64
+}
+
 type UpdateOrderStatusUseCase struct {
 	repo     domain.OrderRepository
 	producer domain.OrderEventProducer
@@ -20,32 +56,148 @@ func NewUpdateOrderStatusUseCase(repo domain.OrderRepository, producer domain.Or
 	}
 }
 
-func (uc *UpdateOrderStatusUseCase) Execute(ctx context.Context, orderID uuid.UUID, status domain.OrderStatus) error {
-	log.Printf("Updating OrderID %s to status %s", orderID, status)
+// Execute moves the order to the new status and records the transition in
+// its audit trail, then publishes it. When another update lands first, the
+// order is reloaded and the transition applied again. An order already in
+// the status is left as is, and an event whose transition is already in the
+// audit trail is not applied twice.
+func (uc *UpdateOrderStatusUseCase) Execute(ctx context.Context, input StatusUpdateInput) error {
+	orderID, status := input.OrderID, input.Status
+	log.Printf("Updating OrderID %s to status %s (%s %s)", orderID, status, input.Actor.Type, input.Actor.ID)
 
-	err := uc.repo.UpdateOrderStatus(ctx, orderID, status)
-	if err != nil {
-		return err
+	if input.SourceEventID != "" {
+		applied, err := uc.applied(ctx, orderID, input.SourceEventID)
+		if err != nil {
+			return err
+		}
+		if applied != nil {
+			log.Printf("OrderID %s already recorded event %s", orderID, input.SourceEventID)
+			return uc.redeliver(ctx, applied)
+		}
 	}
 
-	// Record history
-	history := &domain.OrderStatusHistory{
-		ID:      uuid.New(),
-		OrderID: orderID,
-		Status:  status,
+	var (
+		order   *domain.Order
+		history *domain.OrderStatusHistory
+	)
+	for attempt := 0; ; attempt++ {
+		var err error
+		order, err = uc.repo.GetOrderByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if input.Check != nil {
+			if err := input.Check(order); err != nil {
+				return err
+			}
+		}
+		if input.Forward && order.MovedPast(status) {
+			log.Printf("OrderID %s is already %s; not moving it back to %s", orderID, order.Status, status)
+			return uc.refundLatePayment(ctx, order, input.Payment)
+		}
+		if order.Status == status {
+			log.Printf("OrderID %s is already %s", orderID, status)
+			return nil
+		}
+
+		history = &domain.OrderStatusHistory{
+			ID:            uuid.New(),
+			OrderID:       orderID,
+			FromStatus:    order.Status,
+			Status:        status,
+			Actor:         input.Actor,
+			SourceEventID: input.SourceEventID,
+			Reason:        input.Reason,
+			Metadata:      input.Metadata,
+			ChangedAt:     time.Now(),
+		}
+		err = uc.repo.ChangeStatus(ctx, history, order.Version)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, domain.ErrVersionConflict) || attempt == maxConflictRetries {
+			return err
+		}
+		log.Printf("OrderID %s changed while updating it to %s; retrying", orderID, status)
 	}
-	_ = uc.repo.AddStatusHistory(ctx, history)
+	order.Status, order.UpdatedAt = status, history.ChangedAt
+	order.Version++
+
+	uc.emitStatusChanged(ctx, order.UserID, history)
 
-	// If status is PAID, we might need to emit an event for the delivery service
+	// If status is PAID, emit an event for the delivery service. Failures are
+	// returned so the triggering message is redelivered and the emit retried.
 	if status == domain.StatusPaid {
-		order, err := uc.repo.GetOrderByID(ctx, orderID)
-		if err == nil {
-			log.Printf("Emitting order.paid for OrderID: %s", orderID)
-			_ = uc.producer.EmitOrderPaid(ctx, order)
-		} else {
-			log.Printf("Failed to get order for event emission: %v", err)
+		log.Printf("Emitting order.paid for OrderID: %s", orderID)
+		if err := uc.producer.EmitOrderPaid(ctx, order); err != nil {
+			return fmt.Errorf("emitting order.paid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// emitStatusChanged publishes a recorded status change. Watchers catch up
+// from the history when they reconnect, so a lost event is only logged.
+func (uc *UpdateOrderStatusUseCase) emitStatusChanged(ctx context.Context, userID uuid.UUID, history *domain.OrderStatusHistory) {
+	change := &domain.OrderStatusChange{
+		OrderID:   history.OrderID,
+		UserID:    userID,
+		Status:    history.Status,
+		Sequence:  history.Sequence,
+		ChangedAt: history.ChangedAt,
+	}
+	if err := uc.producer.EmitOrderStatusChanged(ctx, change); err != nil {
+		log.Printf("Failed to emit order.status_changed for OrderID %s: %v", history.OrderID, err)
+	}
+}
+
+// refundLatePayment asks for the refund of a payment that arrived after its
+// order was canceled, since nothing will be delivered for it. Failures are
+// returned so the payment event is redelivered and the request retried.
+func (uc *UpdateOrderStatusUseCase) refundLatePayment(ctx context.Context, order *domain.Order, payment *PaymentInput) error {
+	if payment == nil || order.Status != domain.StatusCanceled {
+		return nil
+	}
+	log.Printf("Requesting refund of payment %s for canceled OrderID %s", payment.ID, order.ID)
+	if err := uc.producer.EmitPaymentRefundRequested(ctx, order, payment.ID, payment.Amount); err != nil {
+		return fmt.Errorf("requesting refund of payment %s: %w", payment.ID, err)
+	}
+	return nil
+}
+
+// applied returns the transition of the order recorded for the event, or nil
+// if the event was not applied yet.
+func (uc *UpdateOrderStatusUseCase) applied(ctx context.Context, orderID uuid.UUID, eventID string) (*domain.OrderStatusHistory, error) {
+	history, err := uc.repo.ListStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range history {
+		if history[i].SourceEventID == eventID {
+			return &history[i], nil
 		}
 	}
+	return nil, nil
+}
 
+// redeliver handles an event delivered again after its transition was
+// recorded. Only order.paid is published again, as a failure to publish it
+// is what gets the event redelivered.
+func (uc *UpdateOrderStatusUseCase) redeliver(ctx context.Context, applied *domain.OrderStatusHistory) error {
+	if applied.Status != domain.StatusPaid {
+		return nil
+	}
+	order, err := uc.repo.GetOrderByID(ctx, applied.OrderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != domain.StatusPaid {
+		return nil
+	}
+	log.Printf("Emitting order.paid again for OrderID: %s", order.ID)
+	if err := uc.producer.EmitOrderPaid(ctx, order); err != nil {
+		return fmt.Errorf("emitting order.paid: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,282 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateOrderStatusUseCase_Execute_PaidEmitsEvent(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	order := &domain.Order{ID: orderID, Status: domain.StatusPending}
+
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(order, nil)
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+	mockEventProducer.On("EmitOrderPaid", ctx, order).Return(nil)
+
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEventProducer.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_EmitFailureIsReturned(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	order := &domain.Order{ID: orderID, Status: domain.StatusPending}
+	emitErr := errors.New("message unroutable")
+
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(order, nil)
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+	mockEventProducer.On("EmitOrderPaid", ctx, order).Return(emitErr)
+
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid})
+
+	assert.ErrorIs(t, err, emitErr)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_DeliveredDoesNotEmit(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	order := &domain.Order{ID: orderID, Status: domain.StatusShipped}
+
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(order, nil)
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusDelivered})
+
+	assert.NoError(t, err)
+	mockEventProducer.AssertNotCalled(t, "EmitOrderPaid")
+}
+
+func TestUpdateOrderStatusUseCase_Execute_EmitsStatusChange(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	userID := uuid.New()
+	order := &domain.Order{ID: orderID, UserID: userID, Status: domain.StatusShipped}
+
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.OrderStatusHistory).Sequence = 7
+	}).Return(nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(order, nil)
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.MatchedBy(func(c *domain.OrderStatusChange) bool {
+		return c.OrderID == orderID && c.UserID == userID && c.Status == domain.StatusDelivered && c.Sequence == 7
+	})).Return(errors.New("broker down"))
+
+	// A lost status change does not fail the update.
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusDelivered})
+
+	assert.NoError(t, err)
+	mockEventProducer.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_ChangeFailureIsReturned(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	historyErr := errors.New("disk full")
+
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusPending}, nil)
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Return(historyErr)
+
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid})
+
+	assert.ErrorIs(t, err, historyErr)
+	mockEventProducer.AssertNotCalled(t, "EmitOrderPaid", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_RecordsAuditEntry(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	input := StatusUpdateInput{
+		OrderID:       orderID,
+		Status:        domain.StatusDelivered,
+		Actor:         domain.Actor{Type: domain.ActorService, ID: "delivery_service"},
+		SourceEventID: "evt-1",
+		Reason:        "order delivered",
+		Metadata:      map[string]string{"courier": "c-9"},
+	}
+
+	var entry *domain.OrderStatusHistory
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entry = args.Get(1).(*domain.OrderStatusHistory)
+	}).Return(nil)
+	mockRepo.On("ListStatusHistory", ctx, orderID).Return([]domain.OrderStatusHistory(nil), nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusShipped}, nil)
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+
+	assert.NoError(t, uc.Execute(ctx, input))
+
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, orderID, entry.OrderID)
+		assert.Equal(t, input.Actor, entry.Actor)
+		assert.Equal(t, "evt-1", entry.SourceEventID)
+		assert.Equal(t, "order delivered", entry.Reason)
+		assert.Equal(t, input.Metadata, entry.Metadata)
+		assert.False(t, entry.ChangedAt.IsZero())
+	}
+}
+
+func TestUpdateOrderStatusUseCase_Execute_ReappliesOnConflict(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	stale := &domain.Order{ID: orderID, Status: domain.StatusPending, Version: 1}
+	fresh := &domain.Order{ID: orderID, Status: domain.StatusShipped, Version: 2}
+
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(stale, nil).Once()
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, int64(1)).
+		Return(&domain.VersionConflictError{OrderID: orderID, Version: 1}).Once()
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(fresh, nil).Once()
+	mockRepo.On("ChangeStatus", ctx, mock.MatchedBy(func(e *domain.OrderStatusHistory) bool {
+		return e.FromStatus == domain.StatusShipped
+	}), int64(2)).Return(nil).Once()
+	mockEventProducer.On("EmitOrderStatusChanged", ctx, mock.Anything).Return(nil)
+
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusDelivered})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), fresh.Version)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_GivesUpAfterRepeatedConflicts(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, new(MockEventProducer))
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusPending}, nil)
+	mockRepo.On("ChangeStatus", ctx, mock.Anything, mock.Anything).Return(&domain.VersionConflictError{OrderID: orderID})
+
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockRepo.AssertNumberOfCalls(t, "ChangeStatus", maxConflictRetries+1)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_ForwardSkipsStaleStatus(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusDelivered}, nil)
+
+	// payment.succeeded arriving after order.delivered.
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid, Forward: true})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockEventProducer.AssertNotCalled(t, "EmitOrderPaid", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_ForwardSkipsCanceledOrder(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(&domain.Order{ID: orderID, Status: domain.StatusCanceled}, nil)
+
+	// payment.succeeded arriving after the order was canceled.
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid, Forward: true})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockEventProducer.AssertNotCalled(t, "EmitOrderPaid", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_RefundsPaymentOfCanceledOrder(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	canceled := &domain.Order{ID: orderID, Status: domain.StatusCanceled}
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(canceled, nil)
+	mockEventProducer.On("EmitPaymentRefundRequested", ctx, canceled, "pay-1", 80.0).Return(errors.New("broker unavailable")).Once()
+	mockEventProducer.On("EmitPaymentRefundRequested", ctx, canceled, "pay-1", 80.0).Return(nil).Once()
+	input := StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid, Forward: true, Payment: &PaymentInput{ID: "pay-1", Amount: 80}}
+
+	// The payment event is retried until the refund is requested.
+	err := uc.Execute(ctx, input)
+	assert.ErrorContains(t, err, "broker unavailable")
+	assert.NoError(t, uc.Execute(ctx, input))
+
+	mockRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockEventProducer.AssertNotCalled(t, "EmitOrderPaid", mock.Anything, mock.Anything)
+	mockEventProducer.AssertExpectations(t)
+
+	// A late payment of a shipped order is kept.
+	shippedID := uuid.New()
+	mockRepo.On("GetOrderByID", ctx, shippedID).Return(&domain.Order{ID: shippedID, Status: domain.StatusShipped}, nil)
+	assert.NoError(t, uc.Execute(ctx, StatusUpdateInput{OrderID: shippedID, Status: domain.StatusPaid, Forward: true, Payment: &PaymentInput{ID: "pay-2", Amount: 80}}))
+	mockEventProducer.AssertNumberOfCalls(t, "EmitPaymentRefundRequested", 2)
+}
+
+func TestUpdateOrderStatusUseCase_Execute_DuplicatePaidEvent(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	mockEventProducer := new(MockEventProducer)
+	uc := NewUpdateOrderStatusUseCase(mockRepo, mockEventProducer)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	order := &domain.Order{ID: orderID, Status: domain.StatusPaid}
+	recorded := []domain.OrderStatusHistory{{OrderID: orderID, FromStatus: domain.StatusPending, Status: domain.StatusPaid, SourceEventID: "evt-1"}}
+	mockRepo.On("ListStatusHistory", ctx, orderID).Return(recorded, nil)
+	mockRepo.On("GetOrderByID", ctx, orderID).Return(order, nil)
+	mockEventProducer.On("EmitOrderPaid", ctx, order).Return(nil).Once()
+
+	// The same event delivered again is not recorded twice, but order.paid
+	// is published again in case publishing it failed the first time.
+	err := uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid, SourceEventID: "evt-1", Forward: true})
+	assert.NoError(t, err)
+
+	// Another event paying the order again changes nothing.
+	err = uc.Execute(ctx, StatusUpdateInput{OrderID: orderID, Status: domain.StatusPaid, SourceEventID: "evt-2", Forward: true})
+	assert.NoError(t, err)
+
+	mockRepo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockEventProducer.AssertNotCalled(t, "EmitOrderStatusChanged", mock.Anything, mock.Anything)
+	mockEventProducer.AssertExpectations(t)
+}
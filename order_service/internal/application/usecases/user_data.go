@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// UserDataUseCase exports and erases the personal data of users' orders.
+type UserDataUseCase struct {
+	repo domain.UserDataRepository
+	now  func() time.Time
+}
+
+func NewUserDataUseCase(repo domain.UserDataRepository) *UserDataUseCase {
+	return &UserDataUseCase{repo: repo, now: time.Now}
+}
+
+// Export returns everything recorded about the user's orders, live and
+// archived.
+func (uc *UserDataUseCase) Export(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	data, err := uc.repo.UserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.ExportedAt = uc.now().UTC()
+	return data, nil
+}
+
+// Erase pseudonymizes the addresses of the user's orders on behalf of by.
+// Erasing a user again, e.g. when the deletion event is redelivered, erases
+// the orders placed since and records the new erasure.
+func (uc *UserDataUseCase) Erase(ctx context.Context, userID uuid.UUID, by domain.Actor) (*domain.UserErasure, error) {
+	erasure := &domain.UserErasure{UserID: userID, ErasedBy: by, ErasedAt: uc.now().UTC()}
+	if err := uc.repo.EraseUser(ctx, erasure); err != nil {
+		return nil, err
+	}
+	log.Printf("Erased the personal data of %d orders of UserID %s (%s %s)", erasure.Orders, userID, by.Type, by.ID)
+	return erasure, nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDataUseCase_Export(t *testing.T) {
+	repo := new(MockUserDataRepository)
+	uc := NewUserDataUseCase(repo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	ctx := context.Background()
+	userID := uuid.New()
+
+	orders := []domain.ArchivedOrder{{Order: domain.Order{ID: uuid.New(), UserID: userID}}}
+	repo.On("UserData", ctx, userID).Return(&domain.UserData{UserID: userID, Orders: orders}, nil)
+
+	data, err := uc.Export(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, orders, data.Orders)
+	assert.Equal(t, now, data.ExportedAt)
+}
+
+func TestUserDataUseCase_Erase(t *testing.T) {
+	repo := new(MockUserDataRepository)
+	uc := NewUserDataUseCase(repo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	ctx := context.Background()
+	userID := uuid.New()
+	by := domain.Actor{Type: domain.ActorService, ID: "user-service"}
+
+	repo.On("EraseUser", ctx, mock.MatchedBy(func(e *domain.UserErasure) bool {
+		return e.UserID == userID && e.ErasedBy == by && e.ErasedAt.Equal(now)
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.UserErasure).Orders = 3
+	}).Return(nil).Once()
+
+	erasure, err := uc.Erase(ctx, userID, by)
+	require.NoError(t, err)
+	assert.Equal(t, 3, erasure.Orders)
+
+	repo.On("EraseUser", ctx, mock.Anything).Return(errors.New("database unavailable")).Once()
+	_, err = uc.Erase(ctx, userID, by)
+	assert.Error(t, err)
+	repo.AssertExpectations(t)
+}
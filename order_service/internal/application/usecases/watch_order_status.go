@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ErrWatchLagging ends a watch whose subscriber fell behind the live feed.
+// The client should watch again from the last sequence it received.
+var ErrWatchLagging = domain.NewError(domain.ErrUnavailable, "WATCH_LAGGING", "watch fell behind the status feed")
+
+// replayPageSize bounds each history query while catching up.
+const replayPageSize = 100
+
+// resumeLookback is how many sequences at or below the one a watch resumes
+// after are replayed again. Sequences are taken before a change commits, so
+// one may become visible after a change with a greater sequence that the
+// watcher already received; the look-back outlasts the sequences taken by
+// the status transactions in flight at any time.
+const resumeLookback = 1000
+
+type WatchOrderStatusUseCase struct {
+	repo domain.OrderRepository
+	feed domain.OrderStatusFeed
+}
+
+func NewWatchOrderStatusUseCase(repo domain.OrderRepository, feed domain.OrderStatusFeed) *WatchOrderStatusUseCase {
+	return &WatchOrderStatusUseCase{repo: repo, feed: feed}
+}
+
+// WatchOrder sends the status changes of one order recorded after
+// afterSequence, then every new one, until ctx is done or send fails. When
+// resuming, the changes within resumeLookback sequences up to afterSequence
+// are sent again; watchers skip the sequences they already have.
+func (uc *WatchOrderStatusUseCase) WatchOrder(ctx context.Context, orderID uuid.UUID, afterSequence int64, send func(domain.OrderStatusChange) error) error {
+	query := domain.StatusChangeQuery{OrderID: orderID, AfterSequence: afterSequence}
+	return uc.watch(ctx, query, func(c domain.OrderStatusChange) bool { return c.OrderID == orderID }, send)
+}
+
+// WatchUserOrders is WatchOrder for all orders of a user.
+func (uc *WatchOrderStatusUseCase) WatchUserOrders(ctx context.Context, userID uuid.UUID, afterSequence int64, send func(domain.OrderStatusChange) error) error {
+	query := domain.StatusChangeQuery{UserID: userID, AfterSequence: afterSequence}
+	return uc.watch(ctx, query, func(c domain.OrderStatusChange) bool { return c.UserID == userID }, send)
+}
+
+// watch subscribes before replaying the history so that no change recorded
+// in between is missed. Each sequence is sent once per watch, whether it
+// comes from the history, the live feed or both.
+func (uc *WatchOrderStatusUseCase) watch(ctx context.Context, query domain.StatusChangeQuery, match func(domain.OrderStatusChange) bool, send func(domain.OrderStatusChange) error) error {
+	live, unsubscribe := uc.feed.Subscribe(match)
+	defer unsubscribe()
+
+	if query.AfterSequence > 0 {
+		query.AfterSequence = max(query.AfterSequence-resumeLookback, 0)
+	}
+	sent := map[int64]bool{}
+	query.Limit = replayPageSize
+	for {
+		changes, err := uc.repo.ListStatusChanges(ctx, query)
+		if err != nil {
+			return fmt.Errorf("loading status history: %w", err)
+		}
+		for _, c := range changes {
+			if err := send(c); err != nil {
+				return err
+			}
+			sent[c.Sequence] = true
+			query.AfterSequence = c.Sequence
+		}
+		if len(changes) < replayPageSize {
+			break
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c, ok := <-live:
+			if !ok {
+				return ErrWatchLagging
+			}
+			if sent[c.Sequence] {
+				continue
+			}
+			if err := send(c); err != nil {
+				return err
+			}
+			sent[c.Sequence] = true
+		}
+	}
+}
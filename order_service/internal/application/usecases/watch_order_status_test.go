@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeFeed hands the test the channel of the single subscriber.
+type fakeFeed struct {
+	ch    chan domain.OrderStatusChange
+	match func(domain.OrderStatusChange) bool
+}
+
+func (f *fakeFeed) Subscribe(match func(domain.OrderStatusChange) bool) (<-chan domain.OrderStatusChange, func()) {
+	f.match = match
+	return f.ch, func() {}
+}
+
+func TestWatchOrderStatusUseCase_WatchOrder_ReplaysThenStreams(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	feed := &fakeFeed{ch: make(chan domain.OrderStatusChange, 4)}
+	uc := NewWatchOrderStatusUseCase(mockRepo, feed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	orderID := uuid.New()
+	paid := domain.OrderStatusChange{OrderID: orderID, Status: domain.StatusPaid, Sequence: 5}
+	delivered := domain.OrderStatusChange{OrderID: orderID, Status: domain.StatusDelivered, Sequence: 9}
+
+	mockRepo.On("ListStatusChanges", ctx, domain.StatusChangeQuery{OrderID: orderID, Limit: replayPageSize}).
+		Return([]domain.OrderStatusChange{paid}, nil)
+
+	// Recorded between subscribing and replaying: delivered once only.
+	feed.ch <- paid
+	feed.ch <- delivered
+
+	var got []domain.OrderStatusChange
+	err := uc.WatchOrder(ctx, orderID, 2, func(c domain.OrderStatusChange) error {
+		got = append(got, c)
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []domain.OrderStatusChange{paid, delivered}, got)
+	assert.True(t, feed.match(paid))
+	assert.False(t, feed.match(domain.OrderStatusChange{OrderID: uuid.New()}))
+}
+
+func TestWatchOrderStatusUseCase_WatchUserOrders_Lagging(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	feed := &fakeFeed{ch: make(chan domain.OrderStatusChange)}
+	uc := NewWatchOrderStatusUseCase(mockRepo, feed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	userID := uuid.New()
+	mockRepo.On("ListStatusChanges", ctx, mock.Anything).Return([]domain.OrderStatusChange{}, nil)
+	close(feed.ch)
+
+	err := uc.WatchUserOrders(ctx, userID, 0, func(domain.OrderStatusChange) error { return nil })
+
+	assert.ErrorIs(t, err, ErrWatchLagging)
+	assert.True(t, feed.match(domain.OrderStatusChange{UserID: userID}))
+}
+
+func TestWatchOrderStatusUseCase_WatchUserOrders_ResendsLateCommits(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	feed := &fakeFeed{ch: make(chan domain.OrderStatusChange, 4)}
+	uc := NewWatchOrderStatusUseCase(mockRepo, feed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userID := uuid.New()
+	// The watcher received sequence 2009 before 2007 committed.
+	late := domain.OrderStatusChange{UserID: userID, Status: domain.StatusPaid, Sequence: 2007}
+	seen := domain.OrderStatusChange{UserID: userID, Status: domain.StatusPaid, Sequence: 2009}
+	shipped := domain.OrderStatusChange{UserID: userID, Status: domain.StatusShipped, Sequence: 2008}
+	delivered := domain.OrderStatusChange{UserID: userID, Status: domain.StatusDelivered, Sequence: 2010}
+
+	mockRepo.On("ListStatusChanges", ctx, domain.StatusChangeQuery{UserID: userID, AfterSequence: 2009 - resumeLookback, Limit: replayPageSize}).
+		Return([]domain.OrderStatusChange{late, seen}, nil)
+
+	// Live changes are sent once per watch, even when replayed or
+	// published again.
+	feed.ch <- seen
+	feed.ch <- shipped
+	feed.ch <- shipped
+	feed.ch <- delivered
+
+	var got []domain.OrderStatusChange
+	err := uc.WatchUserOrders(ctx, userID, 2009, func(c domain.OrderStatusChange) error {
+		got = append(got, c)
+		if len(got) == 4 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []domain.OrderStatusChange{late, seen, shipped, delivered}, got)
+}
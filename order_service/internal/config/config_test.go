@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, _, err := Load(nil)
+	require.NoError(t, err)
+	assert.Greater(t, cfg.Cache.Hold, cfg.Replicas.MaxLag)
+}
+
+func TestConfig_Validate_CacheHold(t *testing.T) {
+	t.Setenv("DATABASE_REPLICA_URLS", "postgres://replica/order_db")
+	t.Setenv("DATABASE_REPLICA_MAX_LAG", "10s")
+	t.Setenv("ORDER_CACHE_BACKEND", "local")
+
+	t.Setenv("ORDER_CACHE_HOLD", "10s")
+	_, _, err := Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cache.hold must be longer than replicas.max_lag")
+
+	t.Setenv("ORDER_CACHE_HOLD", "11s")
+	cfg, _, err := Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 11*time.Second, cfg.Cache.Hold)
+
+	// Without replicas every read is from the primary.
+	t.Setenv("DATABASE_REPLICA_URLS", "")
+	t.Setenv("ORDER_CACHE_HOLD", "1s")
+	_, _, err = Load(nil)
+	assert.NoError(t, err)
+}
+
+func TestConfig_Validate_ArchiveAfter(t *testing.T) {
+	t.Setenv("RETURN_WINDOW", "336h")
+
+	t.Setenv("ARCHIVE_AFTER", "335h")
+	_, _, err := Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "archive.after must be at least returns.window")
+
+	t.Setenv("ARCHIVE_AFTER", "336h")
+	_, _, err = Load(nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidAddress is returned, wrapped with the reason, when an address is
+// incomplete or malformed.
+var ErrInvalidAddress = NewError(ErrInvalidArgument, "INVALID_ADDRESS", "invalid address")
+
+// AddressKind tells what an order address is used for.
+type AddressKind string
+
+const (
+	AddressShipping AddressKind = "SHIPPING"
+	AddressBilling  AddressKind = "BILLING"
+)
+
+// Address returns the order's address of a kind, or nil when it has none.
+func (o *Order) Address(kind AddressKind) *OrderAddress {
+	for i := range o.Addresses {
+		if o.Addresses[i].Kind == kind {
+			return &o.Addresses[i]
+		}
+	}
+	return nil
+}
+
+// countryRules are the address conventions of a country: its calling code,
+// the trunk prefix dropped from national phone numbers, the format of its
+// postal codes when they are required, and whether a region is required.
+type countryRules struct {
+	callingCode    string
+	trunkPrefix    string
+	postalCode     *regexp.Regexp
+	requiresRegion bool
+}
+
+// addressRules lists the countries whose conventions are known. Addresses in
+// other countries need a phone number in international form.
+var addressRules = map[string]countryRules{
+	"ET": {callingCode: "251", trunkPrefix: "0"},
+	"KE": {callingCode: "254", trunkPrefix: "0"},
+	"AE": {callingCode: "971", trunkPrefix: "0"},
+	"US": {callingCode: "1", postalCode: regexp.MustCompile(`^\d{5}(-\d{4})?$`), requiresRegion: true},
+	"CA": {callingCode: "1", postalCode: regexp.MustCompile(`^[A-Z]\d[A-Z] \d[A-Z]\d$`), requiresRegion: true},
+	"GB": {callingCode: "44", trunkPrefix: "0", postalCode: regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? \d[A-Z]{2}$`)},
+	"DE": {callingCode: "49", trunkPrefix: "0", postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"FR": {callingCode: "33", trunkPrefix: "0", postalCode: regexp.MustCompile(`^\d{5}$`)},
+	"IN": {callingCode: "91", trunkPrefix: "0", postalCode: regexp.MustCompile(`^\d{6}$`), requiresRegion: true},
+}
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Normalize tidies an address and checks it is complete for its country,
+// which defaults to defaultCountry. The phone number is rewritten in E.164
+// form; it may be omitted on billing addresses only.
+func (a *OrderAddress) Normalize(defaultCountry string) error {
+	for _, f := range []*string{&a.FullName, &a.Phone, &a.Country, &a.Region, &a.City, &a.Street, &a.AddressLine2, &a.PostalCode} {
+		*f = strings.Join(strings.Fields(*f), " ")
+	}
+	a.Country = strings.ToUpper(a.Country)
+	if a.Country == "" {
+		a.Country = strings.ToUpper(defaultCountry)
+	}
+	a.PostalCode = strings.ToUpper(a.PostalCode)
+
+	var missing []string
+	for _, f := range []struct {
+		name, value string
+	}{{"full_name", a.FullName}, {"country", a.Country}, {"city", a.City}, {"street", a.Street}} {
+		if f.value == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	rules, known := addressRules[a.Country]
+	if rules.requiresRegion && a.Region == "" {
+		missing = append(missing, "region")
+	}
+	if rules.postalCode != nil && a.PostalCode == "" {
+		missing = append(missing, "postal_code")
+	}
+	if a.Phone == "" && a.Kind != AddressBilling {
+		missing = append(missing, "phone")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s required", ErrInvalidAddress, strings.Join(missing, ", "))
+	}
+
+	if !countryCode.MatchString(a.Country) {
+		return fmt.Errorf("%w: country must be an ISO 3166-1 alpha-2 code", ErrInvalidAddress)
+	}
+	if known && rules.postalCode != nil && !rules.postalCode.MatchString(a.PostalCode) {
+		return fmt.Errorf("%w: postal_code is not valid in %s", ErrInvalidAddress, a.Country)
+	}
+	if (a.Latitude == nil) != (a.Longitude == nil) {
+		return fmt.Errorf("%w: latitude and longitude go together", ErrInvalidAddress)
+	}
+	if a.Latitude != nil && (*a.Latitude < -90 || *a.Latitude > 90 || *a.Longitude < -180 || *a.Longitude > 180) {
+		return fmt.Errorf("%w: coordinates out of range", ErrInvalidAddress)
+	}
+	if a.Phone != "" {
+		phone, err := NormalizePhone(a.Phone, a.Country)
+		if err != nil {
+			return err
+		}
+		a.Phone = phone
+	}
+	return nil
+}
+
+// NormalizePhone rewrites a phone number in E.164 form. Numbers starting
+// with + or 00 are international; others are national numbers of the
+// country, whose trunk prefix is dropped.
+func NormalizePhone(phone, country string) (string, error) {
+	var digits strings.Builder
+	international := false
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("%w: phone contains %q", ErrInvalidAddress, r)
+		}
+	}
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		number, international = number[2:], true
+	}
+	if !international {
+		rules, ok := addressRules[strings.ToUpper(country)]
+		if !ok {
+			return "", fmt.Errorf("%w: phone must start with + and the country calling code", ErrInvalidAddress)
+		}
+		if rules.trunkPrefix != "" {
+			number = strings.TrimPrefix(number, rules.trunkPrefix)
+		}
+		number = rules.callingCode + number
+	}
+	// E.164 numbers have at most 15 digits; the shortest in use have 8.
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", fmt.Errorf("%w: phone is not a valid number", ErrInvalidAddress)
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, country, want string
+	}{
+		{"0911 23 45 67", "ET", "+251911234567"},
+		{"+251-911-234-567", "KE", "+251911234567"},
+		{"00 44 20 7946 0958", "", "+442079460958"},
+		{"(212) 555-0123", "us", "+12125550123"},
+		{"020 7946 0958", "GB", "+442079460958"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.phone, tt.country)
+		if assert.NoError(t, err, tt.phone) {
+			assert.Equal(t, tt.want, got, tt.phone)
+		}
+	}
+
+	for _, phone := range []string{"1234", "+1234567890123456", "0911-ABC", "+0911234567"} {
+		_, err := NormalizePhone(phone, "ET")
+		assert.ErrorIs(t, err, ErrInvalidAddress, phone)
+	}
+	_, err := NormalizePhone("0911234567", "BR")
+	assert.ErrorIs(t, err, ErrInvalidAddress, "national numbers need known country rules")
+}
+
+func TestOrderAddress_Normalize(t *testing.T) {
+	lat, lon, north := 9.03, 38.74, 120.0
+
+	a := OrderAddress{
+		Kind: AddressShipping, FullName: "  Abebe  Kebede ", Phone: "0911234567",
+		City: "Addis Ababa", Street: "Bole Road", Latitude: &lat, Longitude: &lon,
+	}
+	assert.NoError(t, a.Normalize("et"))
+	assert.Equal(t, "ET", a.Country, "the default country applies")
+	assert.Equal(t, "Abebe Kebede", a.FullName)
+	assert.Equal(t, "+251911234567", a.Phone)
+
+	billing := OrderAddress{Kind: AddressBilling, FullName: "Jane Roe", Country: "gb", City: "London", Street: "1 High St", PostalCode: "sw1a 1aa"}
+	assert.NoError(t, billing.Normalize("ET"), "billing addresses need no phone")
+	assert.Equal(t, "SW1A 1AA", billing.PostalCode)
+
+	tests := []struct {
+		name    string
+		address OrderAddress
+		reason  string
+	}{
+		{"missing fields", OrderAddress{Kind: AddressShipping, Country: "ET"}, "full_name, city, street, phone required"},
+		{"region and postal code per country", OrderAddress{FullName: "J", Phone: "+12125550123", Country: "US", City: "NYC", Street: "5th Ave"}, "region, postal_code required"},
+		{"bad postal code", OrderAddress{FullName: "J", Phone: "+12125550123", Country: "US", Region: "NY", City: "NYC", Street: "5th Ave", PostalCode: "1000"}, "postal_code is not valid in US"},
+		{"bad country", OrderAddress{FullName: "J", Phone: "+12125550123", Country: "USA", City: "NYC", Street: "5th Ave"}, "ISO 3166-1"},
+		{"half coordinates", OrderAddress{FullName: "J", Phone: "0911234567", City: "Adama", Street: "Main", Latitude: &lat}, "latitude and longitude"},
+		{"coordinates out of range", OrderAddress{FullName: "J", Phone: "0911234567", City: "Adama", Street: "Main", Latitude: &north, Longitude: &lon}, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.address.Normalize("ET")
+			assert.ErrorIs(t, err, ErrInvalidAddress)
+			assert.ErrorContains(t, err, tt.reason)
+		})
+	}
+}
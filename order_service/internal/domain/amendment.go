@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrOrderNotAmendable is returned when amending an order that is no longer
+// CREATED or PENDING, including by repositories when it was paid or
+// canceled while the amendment was being priced.
+var ErrOrderNotAmendable = NewError(ErrPreconditionFailed, "ORDER_NOT_AMENDABLE", "order can no longer be amended")
+
+// Amendable reports whether the order's lines and addresses may still be
+// changed, which is the case until it is paid.
+func (o *Order) Amendable() bool {
+	return o.Cancelable()
+}
+
+// AmendmentField names what an amendment change is about.
+type AmendmentField string
+
+const (
+	// AmendQuantity changes the quantity of the line ItemID; a To of "0"
+	// removes the line.
+	AmendQuantity        AmendmentField = "quantity"
+	AmendShippingAddress AmendmentField = "shipping_address"
+	AmendBillingAddress  AmendmentField = "billing_address"
+)
+
+// AmendmentChange is one value changed by an amendment, as displayed: From
+// and To are quantities or one-line addresses.
+type AmendmentChange struct {
+	Field  AmendmentField `json:"field"`
+	ItemID *uuid.UUID     `json:"item_id,omitempty"`
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+}
+
+// OrderAmendment records who changed an unpaid order, what they changed and
+// how the total moved. AmendedBy is the subject of the caller's access
+// token, or the order's user when the call carried none.
+type OrderAmendment struct {
+	ID            uuid.UUID         `json:"id"`
+	OrderID       uuid.UUID         `json:"order_id"`
+	AmendedBy     string            `json:"amended_by"`
+	Changes       []AmendmentChange `json:"changes" gorm:"serializer:json"`
+	PreviousTotal float64           `json:"previous_total"`
+	NewTotal      float64           `json:"new_total"`
+	AmendedAt     time.Time         `json:"amended_at"`
+}
+
+// OneLine formats the address on a single line, as recorded in amendments.
+func (a *OrderAddress) OneLine() string {
+	var parts []string
+	for _, s := range []string{a.FullName, a.Phone, a.Street, a.AddressLine2, a.City, a.Region, a.PostalCode, a.Country} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
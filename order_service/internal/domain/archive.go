@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivableStatuses are the statuses an order is finished in. Delivered and
+// partially refunded orders may still get returns until the return window
+// closes, so they must be archived only after it.
+var ArchivableStatuses = []OrderStatus{StatusCanceled, StatusDelivered, StatusReturned, StatusPartiallyRefunded}
+
+// ArchivedOrder is an order moved out of the live tables with everything
+// recorded about it, as kept in the archive and exported.
+type ArchivedOrder struct {
+	Order         Order                `json:"order"`
+	StatusHistory []OrderStatusHistory `json:"status_history"`
+	Amendments    []OrderAmendment     `json:"amendments,omitempty"`
+	Returns       []OrderReturn        `json:"returns,omitempty"`
+	ArchivedAt    time.Time            `json:"archived_at,omitzero"`
+}
+
+// ArchiveQuery selects up to Limit orders in one of ArchivableStatuses last
+// updated before Before, least recently updated first.
+type ArchiveQuery struct {
+	Before time.Time
+	Limit  int
+}
+
+// ArchiveRepository moves finished orders out of the live tables. Archived
+// orders are still found by OrderRepository.GetOrderByID.
+type ArchiveRepository interface {
+	// ArchiveOrders moves the selected orders into the archive in one
+	// transaction and returns how many it moved. Orders being archived by
+	// another instance are skipped.
+	ArchiveOrders(ctx context.Context, query ArchiveQuery) (int, error)
+	// PendingExports returns up to limit archived orders not exported yet,
+	// oldest archived first.
+	PendingExports(ctx context.Context, limit int) ([]ArchivedOrder, error)
+	// MarkExported records that the orders were exported to location.
+	MarkExported(ctx context.Context, ids []uuid.UUID, location string) error
+}
+
+// ArchiveStore keeps the files archived orders are exported to.
+type ArchiveStore interface {
+	// Put stores data under name, replacing any file of that name, and
+	// returns where it was stored.
+	Put(ctx context.Context, name string, data []byte) (string, error)
+}
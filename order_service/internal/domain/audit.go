@@ -0,0 +1,21 @@
+package domain
+
+// ActorType tells what kind of party changed an order.
+type ActorType string
+
+const (
+	// ActorUser is a person calling the API: a customer, seller or admin.
+	ActorUser ActorType = "USER"
+	// ActorService is another service, through an event it published.
+	ActorService ActorType = "SERVICE"
+	// ActorSystem is the order service itself, e.g. settling an order once
+	// its returns are done.
+	ActorSystem ActorType = "SYSTEM"
+)
+
+// Actor is who caused a change. ID is the subject of a user's access
+// token, the name of a service, or empty when unknown.
+type Actor struct {
+	Type ActorType `json:"type"`
+	ID   string    `json:"id,omitempty"`
+}
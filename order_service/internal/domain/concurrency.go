@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrVersionConflict matches every VersionConflictError.
+var ErrVersionConflict = NewError(ErrConflict, "VERSION_CONFLICT", "order was modified concurrently")
+
+// VersionConflictError is returned by repositories when an order is written
+// with a version that is no longer current: another update landed since it
+// was loaded. Callers reload the order and apply their change again.
+type VersionConflictError struct {
+	OrderID uuid.UUID
+	Version int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("order %s is no longer at version %d: %v", e.OrderID, e.Version, ErrVersionConflict)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// progressRank orders the statuses an order goes through once paid. Statuses
+// off that path, such as CANCELED, have no rank.
+var progressRank = map[OrderStatus]int{
+	StatusCreated:           0,
+	StatusPending:           0,
+	StatusPaid:              1,
+	StatusShipped:           2,
+	StatusDelivered:         3,
+	StatusReturnRequested:   4,
+	StatusReturned:          4,
+	StatusPartiallyRefunded: 4,
+}
+
+// terminalStatuses end the lifecycle of an order: nothing moves it on.
+var terminalStatuses = map[OrderStatus]bool{
+	StatusCanceled: true,
+	StatusReturned: true,
+}
+
+// Terminal reports whether the order ended its lifecycle, being canceled or
+// fully returned.
+func (o *Order) Terminal() bool {
+	return terminalStatuses[o.Status]
+}
+
+// MovedPast reports whether the order is further along its lifecycle than
+// status, so that moving it to status would undo progress, as when a late
+// payment.succeeded arrives for a delivered or canceled order. A terminal
+// order has moved past every other status.
+func (o *Order) MovedPast(status OrderStatus) bool {
+	if o.Terminal() {
+		return o.Status != status
+	}
+	current, ok := progressRank[o.Status]
+	next, known := progressRank[status]
+	return ok && known && current > next
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionConflictError(t *testing.T) {
+	var err error = &VersionConflictError{OrderID: uuid.New(), Version: 3}
+	wrapped := fmt.Errorf("amending: %w", err)
+
+	assert.ErrorIs(t, wrapped, ErrVersionConflict)
+	assert.ErrorIs(t, wrapped, ErrConflict)
+	assert.NotErrorIs(t, wrapped, ErrNotFound)
+	var conflict *VersionConflictError
+	if assert.True(t, errors.As(wrapped, &conflict)) {
+		assert.Equal(t, int64(3), conflict.Version)
+	}
+}
+
+func TestOrder_MovedPast(t *testing.T) {
+	tests := []struct {
+		current OrderStatus
+		next    OrderStatus
+		want    bool
+	}{
+		{StatusDelivered, StatusPaid, true},
+		{StatusShipped, StatusPaid, true},
+		{StatusPaid, StatusPaid, false},
+		{StatusPending, StatusPaid, false},
+		{StatusPaid, StatusDelivered, false},
+		{StatusReturnRequested, StatusDelivered, true},
+		{StatusCanceled, StatusPaid, true},
+		{StatusCanceled, StatusCanceled, false},
+		{StatusReturned, StatusDelivered, true},
+		{StatusPending, StatusCanceled, false},
+	}
+	for _, tt := range tests {
+		order := &Order{Status: tt.current}
+		assert.Equal(t, tt.want, order.MovedPast(tt.next), "%s -> %s", tt.current, tt.next)
+	}
+}
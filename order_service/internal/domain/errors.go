@@ -0,0 +1,53 @@
+package domain
+
+import "errors"
+
+// The kinds of domain errors. Every Error matches its kind with errors.Is,
+// so callers and the transport can handle a whole class of errors alike.
+var (
+	// ErrNotFound: the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument: the request is malformed; retrying it as is fails
+	// again.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrConflict: the request collides with existing state, such as a
+	// duplicate key or a concurrent update.
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed: the entity is not in a state that allows the
+	// request, such as canceling a shipped order.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrUnavailable: a dependency such as the database cannot be reached;
+	// the request may succeed when retried.
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// Error is a classified error. Kind is one of the kinds above and Reason a
+// stable UPPER_SNAKE_CASE identifier clients can branch on. Field names the
+// offending request field of an invalid argument, if known.
+//
+// Err is the underlying cause, such as a database error. It is kept for
+// errors.Is and logs but left out of the message, which clients see.
+type Error struct {
+	Kind    error
+	Reason  string
+	Message string
+	Field   string
+	Err     error
+}
+
+// NewError returns an error of the kind, for declaring sentinel errors.
+func NewError(kind error, reason, message string) error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -7,4 +7,25 @@ import (
 type OrderEventProducer interface {
 	EmitOrderCreated(ctx context.Context, order *Order) error
 	EmitOrderPaid(ctx context.Context, order *Order) error
+	EmitOrderStatusChanged(ctx context.Context, change *OrderStatusChange) error
+	EmitOrderAmended(ctx context.Context, order *Order, amendment *OrderAmendment) error
+	// EmitPaymentRefundRequested asks for the full refund of a payment the
+	// order can no longer take, such as one arriving after it was canceled.
+	EmitPaymentRefundRequested(ctx context.Context, order *Order, paymentID string, amount float64) error
+}
+
+// ReturnEventProducer publishes the steps of a return handled by other
+// services: the pickup of approved returns and the refund of picked up ones.
+type ReturnEventProducer interface {
+	EmitReturnApproved(ctx context.Context, order *Order, ret *OrderReturn) error
+	EmitRefundRequested(ctx context.Context, order *Order, ret *OrderReturn) error
+}
+
+// OrderStatusFeed delivers the status changes recorded by any replica.
+// Subscribe returns a channel receiving the changes accepted by match and a
+// function to unsubscribe. The channel is closed when the subscriber falls
+// behind or the feed loses changes; the subscriber should then resume from
+// the repository.
+type OrderStatusFeed interface {
+	Subscribe(match func(OrderStatusChange) bool) (<-chan OrderStatusChange, func())
 }
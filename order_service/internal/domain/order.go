@@ -25,6 +25,27 @@ type Order struct {
 	CreatedAt   time.Time   `json:"created_at"`
 	UpdatedAt   time.Time   `json:"updated_at"`
 	Items       []OrderItem `json:"items"`
+	// Subtotal is the value of the items; TotalAmount is what is charged,
+	// the subtotal less DiscountAmount, plus TaxAmount unless prices
+	// include tax, plus ShippingAmount.
+	Subtotal         float64         `json:"subtotal"`
+	DiscountAmount   float64         `json:"discount_amount"`
+	Discounts        []OrderDiscount `json:"discounts,omitempty"`
+	TaxAmount        float64         `json:"tax_amount"`
+	PricesIncludeTax bool            `json:"prices_include_tax"`
+	ShippingAmount   float64         `json:"shipping_amount"`
+	ShippingZone     string          `json:"shipping_zone,omitempty"`
+	Addresses        []OrderAddress  `json:"addresses,omitempty"`
+	// Version is incremented by every update of the order; writes name the
+	// version they were computed from and fail with a VersionConflictError
+	// when it is no longer current.
+	Version int64 `json:"version"`
+}
+
+// Cancelable reports whether the order can still be canceled, which is the
+// case until it is paid.
+func (o *Order) Cancelable() bool {
+	return o.Status == StatusCreated || o.Status == StatusPending
 }
 
 type OrderItem struct {
@@ -35,22 +56,124 @@ type OrderItem struct {
 	ProductName string    `json:"product_name"`
 	UnitPrice   float64   `json:"unit_price"`
 	Quantity    int       `json:"quantity"`
+	// Category selects the tax rate of the line. TaxableAmount is the value
+	// of the line after discounts, without tax.
+	Category      string  `json:"category,omitempty"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxRate       float64 `json:"tax_rate"`
+	TaxAmount     float64 `json:"tax_amount"`
+	// WeightKg is the weight of one unit, used to price shipping; zero
+	// leaves it to the delivery service's default.
+	WeightKg float64 `json:"weight_kg,omitempty"`
+	// Fulfillment is the seller's progress on the line.
+	FulfillmentStatus FulfillmentStatus `json:"fulfillment_status" gorm:"default:PENDING"`
+	PackedAt          *time.Time        `json:"packed_at,omitempty"`
+	HandedOverAt      *time.Time        `json:"handed_over_at,omitempty"`
 }
 
+// FulfillmentStatus tracks a seller's work on an order line: packing it and
+// handing it over to the courier.
+type FulfillmentStatus string
+
+const (
+	FulfillmentPending    FulfillmentStatus = "PENDING"
+	FulfillmentPacked     FulfillmentStatus = "PACKED"
+	FulfillmentHandedOver FulfillmentStatus = "HANDED_OVER"
+)
+
+// fulfillmentRank orders the fulfillment statuses; lines only move forward.
+var fulfillmentRank = map[FulfillmentStatus]int{
+	"":                    0,
+	FulfillmentPending:    0,
+	FulfillmentPacked:     1,
+	FulfillmentHandedOver: 2,
+}
+
+// CanAdvanceTo reports whether the line may move to the given status. Lines
+// never move back, and handing over an unpacked line packs it as well.
+func (s FulfillmentStatus) CanAdvanceTo(next FulfillmentStatus) bool {
+	to, ok := fulfillmentRank[next]
+	return ok && next != FulfillmentPending && to > fulfillmentRank[s]
+}
+
+// FulfillmentBefore returns the statuses a line may be in to move to next.
+func FulfillmentBefore(next FulfillmentStatus) []FulfillmentStatus {
+	var out []FulfillmentStatus
+	for _, s := range []FulfillmentStatus{FulfillmentPending, FulfillmentPacked, FulfillmentHandedOver} {
+		if s.CanAdvanceTo(next) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// SalesStatuses are the order statuses whose lines count as sold. Orders
+// returned in full are no longer sales.
+var SalesStatuses = []OrderStatus{StatusPaid, StatusShipped, StatusDelivered, StatusReturnRequested, StatusPartiallyRefunded}
+
+// Fulfillable reports whether sellers may pack and hand over the order's
+// lines, which is the case once it is paid and until it ships.
+func (o *Order) Fulfillable() bool {
+	return o.Status == StatusPaid
+}
+
+// SellerOrderLine is an order item as listed to its seller, with the state
+// of the order it belongs to.
+type SellerOrderLine struct {
+	OrderItem
+	OrderStatus OrderStatus `json:"order_status"`
+	OrderedAt   time.Time   `json:"ordered_at"`
+}
+
+// SellerDailySales aggregates a seller's lines in paid orders placed on one
+// UTC day.
+type SellerDailySales struct {
+	Day     string  `json:"day"` // YYYY-MM-DD
+	Orders  int64   `json:"orders"`
+	Units   int64   `json:"units"`
+	Revenue float64 `json:"revenue"`
+}
+
+// OrderAddress is where an order ships or is billed to. Street is the first
+// address line; Phone is in E.164 form.
 type OrderAddress struct {
-	ID         uuid.UUID `json:"id"`
-	OrderID    uuid.UUID `json:"order_id"`
-	FullName   string    `json:"full_name"`
-	Phone      string    `json:"phone"`
-	Country    string    `json:"country"`
-	City       string    `json:"city"`
-	Street     string    `json:"street"`
-	PostalCode string    `json:"postal_code"`
+	ID           uuid.UUID   `json:"id"`
+	OrderID      uuid.UUID   `json:"order_id"`
+	Kind         AddressKind `json:"kind"`
+	FullName     string      `json:"full_name"`
+	Phone        string      `json:"phone"`
+	Country      string      `json:"country"`
+	Region       string      `json:"region"`
+	City         string      `json:"city"`
+	Street       string      `json:"street"`
+	AddressLine2 string      `json:"address_line2,omitempty"`
+	PostalCode   string      `json:"postal_code"`
+	Latitude     *float64    `json:"latitude,omitempty"`
+	Longitude    *float64    `json:"longitude,omitempty"`
 }
 
+// OrderStatusHistory is an entry of an order's audit trail: a transition
+// from FromStatus to Status, who or what caused it and why. SourceEventID
+// is the ID of the event that triggered it, if any.
 type OrderStatusHistory struct {
-	ID        uuid.UUID   `json:"id"`
+	ID            uuid.UUID         `json:"id"`
+	OrderID       uuid.UUID         `json:"order_id"`
+	FromStatus    OrderStatus       `json:"from_status"`
+	Status        OrderStatus       `json:"status"`
+	Actor         Actor             `json:"actor" gorm:"embedded;embeddedPrefix:actor_"`
+	SourceEventID string            `json:"source_event_id,omitempty"`
+	Reason        string            `json:"reason,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty" gorm:"serializer:json"`
+	ChangedAt     time.Time         `json:"changed_at"`
+	// Sequence is assigned by the database and increases across all orders.
+	Sequence int64 `json:"sequence" gorm:"autoIncrement"`
+}
+
+// OrderStatusChange is a recorded status transition as pushed to watchers.
+type OrderStatusChange struct {
 	OrderID   uuid.UUID   `json:"order_id"`
+	UserID    uuid.UUID   `json:"user_id"`
 	Status    OrderStatus `json:"status"`
+	Sequence  int64       `json:"sequence"`
 	ChangedAt time.Time   `json:"changed_at"`
 }
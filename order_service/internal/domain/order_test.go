@@ -67,3 +67,15 @@ func TestOrderItemStruct(t *testing.T) {
 	assert.Equal(t, 50.25, item.UnitPrice)
 	assert.Equal(t, 2, item.Quantity)
 }
+
+func TestFulfillmentStatus_CanAdvanceTo(t *testing.T) {
+	assert.True(t, FulfillmentPending.CanAdvanceTo(FulfillmentPacked))
+	assert.True(t, FulfillmentPending.CanAdvanceTo(FulfillmentHandedOver))
+	assert.True(t, FulfillmentPacked.CanAdvanceTo(FulfillmentHandedOver))
+	assert.False(t, FulfillmentPacked.CanAdvanceTo(FulfillmentPacked))
+	assert.False(t, FulfillmentHandedOver.CanAdvanceTo(FulfillmentPacked))
+	assert.False(t, FulfillmentPacked.CanAdvanceTo(FulfillmentPending))
+	assert.False(t, FulfillmentPending.CanAdvanceTo("SHIPPED"))
+
+	assert.Equal(t, []FulfillmentStatus{FulfillmentPending, FulfillmentPacked}, FulfillmentBefore(FulfillmentHandedOver))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErasedValue replaces the name in erased addresses, and the addresses
+// recorded in amendments.
+const ErasedValue = "[erased]"
+
+// Erase pseudonymizes the address: the name becomes ErasedValue and the
+// phone, street, second line, postal code and coordinates are cleared. The
+// country, region and city are kept, as tax was charged according to them.
+func (a *OrderAddress) Erase() {
+	a.FullName = ErasedValue
+	a.Phone, a.Street, a.AddressLine2, a.PostalCode = "", "", "", ""
+	a.Latitude, a.Longitude = nil, nil
+}
+
+// Erase replaces the addresses the amendment records with ErasedValue and
+// reports whether it changed any.
+func (a *OrderAmendment) Erase() bool {
+	var changed bool
+	for i := range a.Changes {
+		c := &a.Changes[i]
+		if c.Field != AmendShippingAddress && c.Field != AmendBillingAddress {
+			continue
+		}
+		for _, s := range []*string{&c.From, &c.To} {
+			if *s != "" && *s != ErasedValue {
+				*s = ErasedValue
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// Erase erases the addresses of the archived order and those recorded in its
+// amendments.
+func (o *ArchivedOrder) Erase() {
+	for i := range o.Order.Addresses {
+		o.Order.Addresses[i].Erase()
+	}
+	for i := range o.Amendments {
+		o.Amendments[i].Erase()
+	}
+}
+
+// UserData is everything recorded about the orders of a user, as exported
+// to them. Orders are live or archived; archived ones have ArchivedAt set.
+type UserData struct {
+	UserID      uuid.UUID             `json:"user_id"`
+	Orders      []ArchivedOrder       `json:"orders"`
+	Redemptions []PromotionRedemption `json:"promotion_redemptions"`
+	ExportedAt  time.Time             `json:"exported_at"`
+}
+
+// UserErasure records that the personal data of a user's orders was erased,
+// by whom and how many orders it covered. OrderIDs are the orders erased.
+type UserErasure struct {
+	UserID   uuid.UUID   `json:"user_id" gorm:"primaryKey"`
+	ErasedBy Actor       `json:"erased_by" gorm:"embedded;embeddedPrefix:erased_by_"`
+	Orders   int         `json:"orders"`
+	ErasedAt time.Time   `json:"erased_at"`
+	OrderIDs []uuid.UUID `json:"-" gorm:"-"`
+}
+
+// UserDataRepository reads and erases the personal data of users' orders,
+// live and archived.
+type UserDataRepository interface {
+	// UserData returns the orders of the user, oldest first, and the
+	// promotions they redeemed.
+	UserData(ctx context.Context, userID uuid.UUID) (*UserData, error)
+	// EraseUser erases the addresses of every order of erasure.UserID in one
+	// transaction and records the erasure, replacing any earlier record.
+	// Orders and OrderIDs are set on erasure. Amounts, lines and the status
+	// history are kept for accounting.
+	EraseUser(ctx context.Context, erasure *UserErasure) error
+}
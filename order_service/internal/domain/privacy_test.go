@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchivedOrder_Erase(t *testing.T) {
+	lat, lng := 9.01, 38.76
+	order := ArchivedOrder{
+		Order: Order{Addresses: []OrderAddress{{
+			Kind: AddressShipping, FullName: "Abebe Kebede", Phone: "+251911234567", Country: "ET", Region: "Addis Ababa",
+			City: "Addis Ababa", Street: "Bole Road", AddressLine2: "Apt 4", PostalCode: "1000", Latitude: &lat, Longitude: &lng,
+		}}},
+		Amendments: []OrderAmendment{{Changes: []AmendmentChange{
+			{Field: AmendBillingAddress, From: "", To: "Abebe Kebede, Bole Road, Addis Ababa, ET"},
+			{Field: AmendQuantity, From: "1", To: "2"},
+		}}},
+	}
+	order.Erase()
+
+	assert.Equal(t, OrderAddress{Kind: AddressShipping, FullName: ErasedValue, Country: "ET", Region: "Addis Ababa", City: "Addis Ababa"},
+		order.Order.Addresses[0])
+	assert.Equal(t, []AmendmentChange{
+		{Field: AmendBillingAddress, From: "", To: ErasedValue},
+		{Field: AmendQuantity, From: "1", To: "2"},
+	}, order.Amendments[0].Changes)
+	assert.False(t, order.Amendments[0].Erase(), "erasing is idempotent")
+}
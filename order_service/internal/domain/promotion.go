@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrPromotionNotFound is returned by repositories when no promotion has
+	// the requested ID.
+	ErrPromotionNotFound = NewError(ErrNotFound, "PROMOTION_NOT_FOUND", "promotion not found")
+	// ErrPromotionCodeTaken is returned when creating a promotion with the
+	// code of another one.
+	ErrPromotionCodeTaken = NewError(ErrConflict, "PROMOTION_CODE_TAKEN", "promotion code already exists")
+	// ErrPromotionExhausted is returned by CreateOrder when a promotion of
+	// the order reached a usage limit after the order was priced.
+	ErrPromotionExhausted = NewError(ErrPreconditionFailed, "PROMOTION_EXHAUSTED", "promotion usage limit reached")
+	// ErrInvalidPromotion is returned, wrapped with the reason, for a
+	// promotion that cannot be created.
+	ErrInvalidPromotion = NewError(ErrInvalidArgument, "INVALID_PROMOTION", "invalid promotion")
+)
+
+// PromotionKind is how a promotion computes its discount.
+type PromotionKind string
+
+const (
+	// PromotionPercentage takes Value percent off the qualifying lines.
+	PromotionPercentage PromotionKind = "PERCENTAGE"
+	// PromotionFixed takes the amount Value off the qualifying lines.
+	PromotionFixed PromotionKind = "FIXED"
+	// PromotionBuyXGetY makes GetQuantity of every BuyQuantity+GetQuantity
+	// qualifying units free, the cheapest ones first.
+	PromotionBuyXGetY PromotionKind = "BUY_X_GET_Y"
+)
+
+// Promotion is a discount rule. Promotions without a code apply to every
+// qualifying order; coded ones only when the customer enters the code.
+// Usage limits of zero are unlimited, and a nil end of the validity window
+// leaves it open.
+type Promotion struct {
+	ID          uuid.UUID     `json:"id"`
+	Code        string        `json:"code,omitempty"`
+	Description string        `json:"description"`
+	Kind        PromotionKind `json:"kind"`
+	Value       float64       `json:"value"`
+	BuyQuantity int           `json:"buy_quantity,omitempty"`
+	GetQuantity int           `json:"get_quantity,omitempty"`
+	// ProductID restricts the promotion to the lines of one product.
+	ProductID   *uuid.UUID `json:"product_id,omitempty"`
+	MinSubtotal float64    `json:"min_subtotal,omitempty"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	// MaxUses limits the orders using the promotion, MaxUsesPerUser the
+	// orders of each user. Uses counts the orders so far.
+	MaxUses        int `json:"max_uses,omitempty"`
+	MaxUsesPerUser int `json:"max_uses_per_user,omitempty"`
+	Uses           int `json:"uses"`
+	// Stackable promotions combine with each other; the others apply alone.
+	Stackable bool      `json:"stackable"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NormalizeCouponCode returns the canonical form of a code as entered by a
+// customer; codes are case-insensitive.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the rule is complete and consistent.
+func (p *Promotion) Validate() error {
+	var problems []string
+	switch p.Kind {
+	case PromotionPercentage:
+		if p.Value <= 0 || p.Value > 100 {
+			problems = append(problems, "percentage must be in (0, 100]")
+		}
+	case PromotionFixed:
+		if p.Value <= 0 {
+			problems = append(problems, "fixed amount must be positive")
+		}
+	case PromotionBuyXGetY:
+		if p.BuyQuantity < 1 || p.GetQuantity < 1 {
+			problems = append(problems, "buy and get quantities must be at least 1")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown kind %q", p.Kind))
+	}
+	if p.MinSubtotal < 0 {
+		problems = append(problems, "min_subtotal must not be negative")
+	}
+	if p.MaxUses < 0 || p.MaxUsesPerUser < 0 {
+		problems = append(problems, "usage limits must not be negative")
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		problems = append(problems, "ends_at must be after starts_at")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPromotion, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ValidAt reports whether the promotion is active and within its validity
+// window at t.
+func (p *Promotion) ValidAt(t time.Time) bool {
+	return p.Active &&
+		(p.StartsAt == nil || !t.Before(*p.StartsAt)) &&
+		(p.EndsAt == nil || t.Before(*p.EndsAt))
+}
+
+// Exhausted reports whether the promotion reached its global usage limit.
+func (p *Promotion) Exhausted() bool {
+	return p.MaxUses > 0 && p.Uses >= p.MaxUses
+}
+
+// OrderDiscount is a promotion applied to an order and what it took off.
+type OrderDiscount struct {
+	ID          uuid.UUID `json:"id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	PromotionID uuid.UUID `json:"promotion_id"`
+	Code        string    `json:"code,omitempty"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+}
+
+// PromotionRedemption records that an order used a promotion; it backs the
+// per-user usage limit.
+type PromotionRedemption struct {
+	ID          uuid.UUID `json:"id"`
+	PromotionID uuid.UUID `json:"promotion_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	RedeemedAt  time.Time `json:"redeemed_at"`
+}
+
+// Subtotal is the value of the lines before discounts.
+func Subtotal(items []OrderItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.UnitPrice * float64(item.Quantity)
+	}
+	return roundMoney(total)
+}
+
+// Price sets the order's amounts from its items and discounts.
+func (o *Order) Price(items []OrderItem, discounts []OrderDiscount) {
+	o.Subtotal = Subtotal(items)
+	o.Discounts = discounts
+	var discount float64
+	for _, d := range discounts {
+		discount += d.Amount
+	}
+	o.DiscountAmount = roundMoney(discount)
+	o.TotalAmount = roundMoney(o.Subtotal - o.DiscountAmount)
+}
+
+// Discount computes what the promotion takes off the lines, or zero when
+// they do not qualify. Validity and usage limits are not checked.
+func (p *Promotion) Discount(items []OrderItem) float64 {
+	if Subtotal(items) < p.MinSubtotal {
+		return 0
+	}
+	var lines []OrderItem
+	for _, item := range items {
+		if p.ProductID == nil || item.ProductID == *p.ProductID {
+			lines = append(lines, item)
+		}
+	}
+	base := Subtotal(lines)
+	if base <= 0 {
+		return 0
+	}
+
+	switch p.Kind {
+	case PromotionPercentage:
+		return roundMoney(base * p.Value / 100)
+	case PromotionFixed:
+		return math.Min(roundMoney(p.Value), base)
+	case PromotionBuyXGetY:
+		var units int
+		for _, line := range lines {
+			units += line.Quantity
+		}
+		free := units / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].UnitPrice < lines[j].UnitPrice })
+		var discount float64
+		for _, line := range lines {
+			n := min(free, line.Quantity)
+			discount += line.UnitPrice * float64(n)
+			free -= n
+		}
+		return roundMoney(discount)
+	}
+	return 0
+}
+
+// ApplyPromotions picks the discounts of an order among candidate
+// promotions, which must be valid and within their limits. Stackable
+// promotions add up; any other promotion applies alone. The customer gets
+// whichever choice is worth more, and discounts never exceed the subtotal.
+func ApplyPromotions(items []OrderItem, candidates []Promotion) []OrderDiscount {
+	subtotal := Subtotal(items)
+	var stacked []OrderDiscount
+	var stackedTotal float64
+	var best *OrderDiscount
+	for _, p := range candidates {
+		amount := p.Discount(items)
+		if amount <= 0 {
+			continue
+		}
+		d := OrderDiscount{PromotionID: p.ID, Code: p.Code, Description: p.Description, Amount: amount}
+		if !p.Stackable {
+			if best == nil || amount > best.Amount {
+				best = &d
+			}
+			continue
+		}
+		d.Amount = math.Min(amount, roundMoney(subtotal-stackedTotal))
+		if d.Amount <= 0 {
+			continue
+		}
+		stacked = append(stacked, d)
+		stackedTotal = roundMoney(stackedTotal + d.Amount)
+	}
+	if best != nil && best.Amount > stackedTotal {
+		return []OrderDiscount{*best}
+	}
+	return stacked
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotion_Discount(t *testing.T) {
+	lamp, mug := uuid.New(), uuid.New()
+	items := []OrderItem{
+		{ProductID: lamp, UnitPrice: 40, Quantity: 2},
+		{ProductID: mug, UnitPrice: 10, Quantity: 5},
+	}
+
+	tests := []struct {
+		name      string
+		promotion Promotion
+		want      float64
+	}{
+		{"percentage", Promotion{Kind: PromotionPercentage, Value: 10}, 13},
+		{"percentage of one product", Promotion{Kind: PromotionPercentage, Value: 25, ProductID: &mug}, 12.5},
+		{"fixed", Promotion{Kind: PromotionFixed, Value: 15}, 15},
+		{"fixed capped at the lines", Promotion{Kind: PromotionFixed, Value: 100, ProductID: &mug}, 50},
+		{"below min subtotal", Promotion{Kind: PromotionFixed, Value: 15, MinSubtotal: 200}, 0},
+		{"product not ordered", Promotion{Kind: PromotionFixed, Value: 15, ProductID: new(uuid.UUID)}, 0},
+		// Seven units in groups of three: two free, the cheapest.
+		{"buy two get one", Promotion{Kind: PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, 20},
+		{"buy one get one of a product", Promotion{Kind: PromotionBuyXGetY, BuyQuantity: 1, GetQuantity: 1, ProductID: &lamp}, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.promotion.Discount(items))
+		})
+	}
+}
+
+func TestApplyPromotions_Stacking(t *testing.T) {
+	items := []OrderItem{{ProductID: uuid.New(), UnitPrice: 100, Quantity: 1}}
+	tenOff := Promotion{ID: uuid.New(), Kind: PromotionFixed, Value: 10, Stackable: true}
+	fivePercent := Promotion{ID: uuid.New(), Kind: PromotionPercentage, Value: 5, Stackable: true}
+	exclusive := func(value float64) Promotion {
+		return Promotion{ID: uuid.New(), Kind: PromotionPercentage, Value: value}
+	}
+
+	got := ApplyPromotions(items, []Promotion{tenOff, fivePercent, exclusive(12)})
+	assert.Len(t, got, 2, "stacked 15 beats an exclusive 12")
+	assert.Equal(t, 15.0, got[0].Amount+got[1].Amount)
+
+	best := exclusive(20)
+	got = ApplyPromotions(items, []Promotion{tenOff, fivePercent, exclusive(12), best})
+	assert.Equal(t, []OrderDiscount{{PromotionID: best.ID, Amount: 20}}, got)
+
+	big := Promotion{ID: uuid.New(), Kind: PromotionFixed, Value: 95, Stackable: true}
+	got = ApplyPromotions(items, []Promotion{big, tenOff})
+	assert.Equal(t, 5.0, got[1].Amount, "discounts never exceed the subtotal")
+
+	assert.Empty(t, ApplyPromotions(items, nil))
+}
+
+func TestPromotion_Validity(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	p := Promotion{Kind: PromotionFixed, Value: 5, Active: true, StartsAt: &now, EndsAt: &later}
+	assert.NoError(t, p.Validate())
+	assert.True(t, p.ValidAt(now))
+	assert.False(t, p.ValidAt(later))
+	assert.False(t, p.ValidAt(now.Add(-time.Second)))
+
+	p.MaxUses, p.Uses = 2, 2
+	assert.True(t, p.Exhausted())
+
+	invalid := []Promotion{
+		{Kind: PromotionPercentage, Value: 120},
+		{Kind: PromotionFixed},
+		{Kind: PromotionBuyXGetY, BuyQuantity: 2},
+		{Kind: "FREE"},
+		{Kind: PromotionFixed, Value: 5, StartsAt: &later, EndsAt: &now},
+	}
+	for _, p := range invalid {
+		err := p.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidPromotion), "%+v", p)
+	}
+
+	assert.Equal(t, "SPRING10", NormalizeCouponCode(" spring10 "))
+}
@@ -2,12 +2,162 @@ package domain
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// ErrOrderNotFound is returned by repositories when no order has the
+// requested ID.
+var ErrOrderNotFound = NewError(ErrNotFound, "ORDER_NOT_FOUND", "order not found")
+
+// OrderRepository stores orders. CreateOrder also records the order's
+// discounts and redeems their promotions, failing with ErrPromotionExhausted
+// when one reached a usage limit; the order starts at version 1.
+// GetOrderByID loads the order's addresses.
 type OrderRepository interface {
-	CreateOrder(ctx context.Context, order *Order, items []OrderItem, address *OrderAddress) error
+	CreateOrder(ctx context.Context, order *Order, items []OrderItem, addresses []OrderAddress) error
 	GetOrderByID(ctx context.Context, id uuid.UUID) (*Order, error)
-	UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status OrderStatus) error
-	AddStatusHistory(ctx context.Context, history *OrderStatusHistory) error
+	ListOrders(ctx context.Context, query OrderListQuery) ([]Order, error)
+	// ChangeStatus moves the order to entry.Status and records the entry in
+	// its audit trail in one transaction, provided the order is still at
+	// version; it fails with a VersionConflictError otherwise, and with
+	// ErrOrderNotFound for an unknown order. Sequence is set on the entry.
+	ChangeStatus(ctx context.Context, entry *OrderStatusHistory, version int64) error
+	// ListStatusHistory returns the audit trail of an order, oldest first.
+	ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]OrderStatusHistory, error)
+	ListStatusChanges(ctx context.Context, query StatusChangeQuery) ([]OrderStatusChange, error)
+	SearchOrders(ctx context.Context, query OrderSearchQuery) ([]Order, int64, error)
+	ListSellerLines(ctx context.Context, query SellerLineQuery) ([]SellerOrderLine, error)
+	// UpdateLineFulfillment moves the lines of the order with the given IDs
+	// to status, unless they are already at or past it.
+	UpdateLineFulfillment(ctx context.Context, orderID uuid.UUID, itemIDs []uuid.UUID, status FulfillmentStatus, at time.Time) error
+	SellerDailySales(ctx context.Context, query SellerSalesQuery) ([]SellerDailySales, error)
+	// AmendOrder saves the lines, addresses, discounts and amounts of an
+	// amended order, deletes the removed lines and records the amendment,
+	// failing with ErrOrderNotAmendable once the order is no longer CREATED
+	// or PENDING, and with a VersionConflictError when it is no longer at
+	// order.Version. The order's Version is incremented on success.
+	AmendOrder(ctx context.Context, order *Order, removed []uuid.UUID, amendment *OrderAmendment) error
+	// ListAmendments returns the amendments of an order, oldest first.
+	ListAmendments(ctx context.Context, orderID uuid.UUID) ([]OrderAmendment, error)
+}
+
+// PromotionRepository stores promotions.
+type PromotionRepository interface {
+	CreatePromotion(ctx context.Context, promotion *Promotion) error
+	GetPromotion(ctx context.Context, id uuid.UUID) (*Promotion, error)
+	ListPromotions(ctx context.Context, includeInactive bool) ([]Promotion, error)
+	SetPromotionActive(ctx context.Context, id uuid.UUID, active bool) error
+	// ApplicablePromotions returns the promotions valid at the given time
+	// that apply without a code or have one of the codes.
+	ApplicablePromotions(ctx context.Context, codes []string, at time.Time) ([]Promotion, error)
+	// CountRedemptions returns how many orders of the user used each of the
+	// promotions; promotions never used are omitted.
+	CountRedemptions(ctx context.Context, userID uuid.UUID, promotionIDs []uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+// ReturnRepository stores the returns of orders with their items.
+type ReturnRepository interface {
+	// CreateReturn records the return unless, counting the returns of the
+	// order that were not rejected, it takes back more units of a line than
+	// were ordered; it fails with ErrReturnExceedsOrder then. The check and
+	// the insert are atomic, so concurrent returns of an order cannot
+	// together exceed it. Given an entry, the order moves to its status in
+	// the same transaction and the entry, from the order's status then, is
+	// added to its history; an order already in the status is left as is
+	// and the entry's Sequence stays zero.
+	CreateReturn(ctx context.Context, ret *OrderReturn, entry *OrderStatusHistory) error
+	GetReturn(ctx context.Context, id uuid.UUID) (*OrderReturn, error)
+	ListReturns(ctx context.Context, query ReturnListQuery) ([]OrderReturn, error)
+	// UpdateReturn saves the status, decision and timestamps of a return
+	// still in status from, and fails with ErrReturnConflict otherwise.
+	UpdateReturn(ctx context.Context, ret *OrderReturn, from ReturnStatus) error
+	// DeliveredAt returns when the order was first recorded as delivered,
+	// or nil when it never was.
+	DeliveredAt(ctx context.Context, orderID uuid.UUID) (*time.Time, error)
+}
+
+// ReturnListQuery selects up to Limit returns matching every filter that is
+// set, with their items, newest first.
+type ReturnListQuery struct {
+	OrderID  uuid.UUID
+	UserID   uuid.UUID
+	Statuses []ReturnStatus
+	Limit    int
+}
+
+// StatusChangeQuery selects recorded status changes of one order, or of all
+// orders of a user, with a sequence greater than AfterSequence, in sequence
+// order.
+type StatusChangeQuery struct {
+	OrderID       uuid.UUID
+	UserID        uuid.UUID
+	AfterSequence int64
+	Limit         int
+}
+
+// OrderListQuery selects up to Limit orders of a user with their items,
+// newest first, starting after the After cursor when it is set.
+type OrderListQuery struct {
+	UserID uuid.UUID
+	Limit  int
+	After  *OrderCursor
+}
+
+// OrderCursor is the position of an order in the newest-first listing, or of
+// an order line in the seller listing, where ID is the item ID.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// OrderSortField is an order attribute search results can be sorted by.
+type OrderSortField string
+
+const (
+	SortByCreatedAt   OrderSortField = "created_at"
+	SortByUpdatedAt   OrderSortField = "updated_at"
+	SortByTotalAmount OrderSortField = "total_amount"
+)
+
+// OrderSearchQuery selects the orders matching every filter that is set,
+// with their items. Seller and product match any item of the order, city and
+// phone its address: the city exactly but case-insensitively, the phone by
+// substring. CreatedTo is exclusive. Results are sorted by SortBy, then ID,
+// and the window Offset, Limit is returned along with the total match count.
+type OrderSearchQuery struct {
+	UserID        uuid.UUID
+	SellerID      uuid.UUID
+	ProductID     uuid.UUID
+	Statuses      []OrderStatus
+	MinTotal      *float64
+	MaxTotal      *float64
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+	City          string
+	PhoneFragment string
+	SortBy        OrderSortField
+	Descending    bool
+	Offset        int
+	Limit         int
+}
+
+// SellerLineQuery selects up to Limit order lines of a seller, newest order
+// first, starting after the After cursor when it is set. The status filters
+// match any of the given statuses.
+type SellerLineQuery struct {
+	SellerID            uuid.UUID
+	OrderStatuses       []OrderStatus
+	FulfillmentStatuses []FulfillmentStatus
+	Limit               int
+	After               *OrderCursor
+}
+
+// SellerSalesQuery selects the daily sales of a seller for the UTC days in
+// [From, To).
+type SellerSalesQuery struct {
+	SellerID uuid.UUID
+	From     time.Time
+	To       time.Time
 }
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Order statuses reached through returns. An order is RETURN_REQUESTED while
+// any of its returns is open, then RETURNED once every unit was refunded, or
+// PARTIALLY_REFUNDED when only some were.
+const (
+	StatusReturnRequested   OrderStatus = "RETURN_REQUESTED"
+	StatusReturned          OrderStatus = "RETURNED"
+	StatusPartiallyRefunded OrderStatus = "PARTIALLY_REFUNDED"
+)
+
+var (
+	// ErrReturnNotFound is returned by repositories when no return has the
+	// requested ID.
+	ErrReturnNotFound = NewError(ErrNotFound, "RETURN_NOT_FOUND", "return not found")
+	// ErrReturnConflict is returned when a return is no longer in the status
+	// a transition starts from.
+	ErrReturnConflict = NewError(ErrPreconditionFailed, "RETURN_CONFLICT", "return is not in the expected status")
+	// ErrReturnExceedsOrder is returned by repositories when a return would
+	// take back more units of a line than remain outside other returns.
+	ErrReturnExceedsOrder = NewError(ErrInvalidArgument, "RETURN_EXCEEDS_ORDER", "more units returned than remain")
+)
+
+// ReturnStatus is the progress of a return: requested by the customer,
+// approved or rejected by staff, picked up by the courier and refunded.
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "REQUESTED"
+	ReturnApproved  ReturnStatus = "APPROVED"
+	ReturnRejected  ReturnStatus = "REJECTED"
+	ReturnPickedUp  ReturnStatus = "PICKED_UP"
+	ReturnRefunded  ReturnStatus = "REFUNDED"
+)
+
+// Open reports whether the return still awaits a decision, a pickup or a
+// refund.
+func (s ReturnStatus) Open() bool {
+	return s == ReturnRequested || s == ReturnApproved || s == ReturnPickedUp
+}
+
+// OrderReturn is a customer's request to send back some units of an order's
+// lines. RefundAmount is what the customer gets back: the paid price of the
+// units, tax included. Shipping is not refunded.
+type OrderReturn struct {
+	ID           uuid.UUID         `json:"id"`
+	OrderID      uuid.UUID         `json:"order_id"`
+	UserID       uuid.UUID         `json:"user_id"`
+	Status       ReturnStatus      `json:"status"`
+	Reason       string            `json:"reason"`
+	RejectReason string            `json:"reject_reason,omitempty"`
+	RefundAmount float64           `json:"refund_amount"`
+	Items        []OrderReturnItem `json:"items" gorm:"foreignKey:ReturnID"`
+	RequestedAt  time.Time         `json:"requested_at"`
+	DecidedAt    *time.Time        `json:"decided_at,omitempty"`
+	PickedUpAt   *time.Time        `json:"picked_up_at,omitempty"`
+	RefundedAt   *time.Time        `json:"refunded_at,omitempty"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// OrderReturnItem is a quantity of one order line in a return.
+type OrderReturnItem struct {
+	ID           uuid.UUID `json:"id"`
+	ReturnID     uuid.UUID `json:"return_id"`
+	OrderItemID  uuid.UUID `json:"order_item_id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	SellerID     uuid.UUID `json:"seller_id"`
+	Quantity     int       `json:"quantity"`
+	RefundAmount float64   `json:"refund_amount"`
+	// SaleAmount is the list price of the units, the part of the seller's
+	// sale reversed by the refund.
+	SaleAmount float64 `json:"sale_amount"`
+}
+
+// Returnable reports whether returns may be requested for the order, which
+// is the case once it is delivered and until every unit was returned.
+func (o *Order) Returnable() bool {
+	switch o.Status {
+	case StatusDelivered, StatusReturnRequested, StatusPartiallyRefunded:
+		return true
+	}
+	return false
+}
+
+// PaidAmount is what the customer paid for the line: its price after
+// discounts, tax included.
+func (item OrderItem) PaidAmount() float64 {
+	if item.TaxableAmount == 0 && item.TaxAmount == 0 {
+		return roundMoney(item.UnitPrice * float64(item.Quantity))
+	}
+	return roundMoney(item.TaxableAmount + item.TaxAmount)
+}
+
+// RefundFor is the share of the line's paid amount for quantity units. The
+// last units of a line get what remains, so refunding every unit returns the
+// paid amount to the cent.
+func (item OrderItem) RefundFor(quantity, alreadyReturned int) float64 {
+	if item.Quantity <= 0 {
+		return 0
+	}
+	paid := toCents(item.PaidAmount())
+	before := paid * int64(alreadyReturned) / int64(item.Quantity)
+	after := paid * int64(alreadyReturned+quantity) / int64(item.Quantity)
+	return float64(after-before) / 100
+}
+
+// ReturnItem is the return of quantity units of the line, after
+// alreadyReturned units were returned before.
+func (item OrderItem) ReturnItem(returnID uuid.UUID, quantity, alreadyReturned int) OrderReturnItem {
+	return OrderReturnItem{
+		ID:           uuid.New(),
+		ReturnID:     returnID,
+		OrderItemID:  item.ID,
+		ProductID:    item.ProductID,
+		SellerID:     item.SellerID,
+		Quantity:     quantity,
+		RefundAmount: item.RefundFor(quantity, alreadyReturned),
+		SaleAmount:   roundMoney(item.UnitPrice * float64(quantity)),
+	}
+}
+
+// StatusAfterReturns is the status of a delivered order given its returns.
+func StatusAfterReturns(items []OrderItem, returns []OrderReturn) OrderStatus {
+	ordered := 0
+	for _, item := range items {
+		ordered += item.Quantity
+	}
+	refunded := 0
+	for _, r := range returns {
+		if r.Status.Open() {
+			return StatusReturnRequested
+		}
+		if r.Status != ReturnRefunded {
+			continue
+		}
+		for _, item := range r.Items {
+			refunded += item.Quantity
+		}
+	}
+	switch {
+	case refunded == 0:
+		return StatusDelivered
+	case refunded >= ordered:
+		return StatusReturned
+	default:
+		return StatusPartiallyRefunded
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderItem_RefundFor(t *testing.T) {
+	// Three units for 100 after discounts, plus 15% tax.
+	item := OrderItem{UnitPrice: 40, Quantity: 3, TaxableAmount: 100, TaxAmount: 15}
+
+	first := item.RefundFor(1, 0)
+	second := item.RefundFor(1, 1)
+	last := item.RefundFor(1, 2)
+	assert.Equal(t, 38.33, first)
+	assert.Equal(t, 38.33, second)
+	assert.Equal(t, 38.34, last, "the last unit gets the remaining cent")
+	assert.Equal(t, item.PaidAmount(), item.RefundFor(3, 0))
+
+	untaxed := OrderItem{UnitPrice: 12.5, Quantity: 2}
+	assert.Equal(t, 12.5, untaxed.RefundFor(1, 0), "lines without tax records refund the list price")
+
+	ret := item.ReturnItem(uuid.New(), 2, 0)
+	assert.Equal(t, 76.66, ret.RefundAmount)
+	assert.Equal(t, 80.0, ret.SaleAmount)
+}
+
+func TestStatusAfterReturns(t *testing.T) {
+	items := []OrderItem{{Quantity: 2}, {Quantity: 1}}
+	returned := func(status ReturnStatus, quantity int) OrderReturn {
+		return OrderReturn{Status: status, Items: []OrderReturnItem{{Quantity: quantity}}}
+	}
+
+	tests := []struct {
+		name    string
+		returns []OrderReturn
+		want    OrderStatus
+	}{
+		{"no returns", nil, StatusDelivered},
+		{"rejected", []OrderReturn{returned(ReturnRejected, 2)}, StatusDelivered},
+		{"open", []OrderReturn{returned(ReturnRefunded, 1), returned(ReturnPickedUp, 1)}, StatusReturnRequested},
+		{"some refunded", []OrderReturn{returned(ReturnRefunded, 1), returned(ReturnRejected, 2)}, StatusPartiallyRefunded},
+		{"all refunded", []OrderReturn{returned(ReturnRefunded, 2), returned(ReturnRefunded, 1)}, StatusReturned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StatusAfterReturns(items, tt.returns))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+// ShippingQuoter prices the shipment of an order's items to an address.
+// orderValue is the value of the goods after discounts, which may reach a
+// free-shipping threshold.
+type ShippingQuoter interface {
+	QuoteShipping(ctx context.Context, address OrderAddress, items []OrderItem, orderValue float64, currency string) (*ShippingQuote, error)
+}
+
+// ShippingQuote is the shipping fee of an order and the delivery zone it was
+// priced for.
+type ShippingQuote struct {
+	Amount       float64
+	Zone         string
+	FreeShipping bool
+}
+
+// ApplyShipping records the shipping line and adds it to the total. Shipping
+// is not taxed.
+func (o *Order) ApplyShipping(quote *ShippingQuote) {
+	o.ShippingAmount = roundMoney(quote.Amount)
+	o.ShippingZone = quote.Zone
+	o.TotalAmount = roundMoney(o.TotalAmount + o.ShippingAmount)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// TaxCalculator computes the tax on the lines of an order shipped to an
+// address.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, address OrderAddress, lines []TaxableLine) (*TaxQuote, error)
+}
+
+// TaxableLine is an order line as taxed: what it is and its amount after
+// discounts.
+type TaxableLine struct {
+	ProductID uuid.UUID
+	Category  string
+	Amount    float64
+}
+
+// TaxQuote is the tax on each line, in the order of the lines. When
+// PricesIncludeTax is set the line amounts already contain the tax;
+// otherwise it is added on top.
+type TaxQuote struct {
+	PricesIncludeTax bool
+	Lines            []LineTax
+}
+
+// LineTax is the rate applied to a line and the resulting tax.
+type LineTax struct {
+	Rate   float64
+	Amount float64
+}
+
+// TaxBreakdownLine sums the lines of an order taxed at one rate.
+type TaxBreakdownLine struct {
+	Rate          float64 `json:"rate"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+// TaxAt computes the tax on amount at rate, extracting it from the amount
+// when prices include tax.
+func TaxAt(amount, rate float64, pricesIncludeTax bool) float64 {
+	if pricesIncludeTax {
+		return roundMoney(amount - amount/(1+rate))
+	}
+	return roundMoney(amount * rate)
+}
+
+// TaxableLines spreads the order discount over the items in proportion to
+// their value, to the cent, and returns what remains of each to be taxed.
+func TaxableLines(items []OrderItem, discount float64) []TaxableLine {
+	lines := make([]TaxableLine, len(items))
+	var total int64
+	amounts := make([]int64, len(items))
+	for i, item := range items {
+		amounts[i] = toCents(item.UnitPrice * float64(item.Quantity))
+		total += amounts[i]
+	}
+	remaining := toCents(discount)
+	for i, item := range items {
+		share := remaining
+		if i < len(items)-1 && total > 0 {
+			share = int64(math.Round(float64(toCents(discount)) * float64(amounts[i]) / float64(total)))
+			share = min(share, remaining)
+		}
+		share = min(share, amounts[i])
+		remaining -= share
+		lines[i] = TaxableLine{
+			ProductID: item.ProductID,
+			Category:  item.Category,
+			Amount:    float64(amounts[i]-share) / 100,
+		}
+	}
+	return lines
+}
+
+// ApplyTax records the quote for the lines on the items and the order. With
+// tax-exclusive prices the tax is added to the total.
+func (o *Order) ApplyTax(items []OrderItem, lines []TaxableLine, quote *TaxQuote) error {
+	if len(quote.Lines) != len(items) || len(lines) != len(items) {
+		return fmt.Errorf("tax quote has %d lines for %d items", len(quote.Lines), len(items))
+	}
+	var tax float64
+	for i := range items {
+		items[i].TaxRate = quote.Lines[i].Rate
+		items[i].TaxAmount = quote.Lines[i].Amount
+		items[i].TaxableAmount = lines[i].Amount
+		if quote.PricesIncludeTax {
+			items[i].TaxableAmount = roundMoney(lines[i].Amount - quote.Lines[i].Amount)
+		}
+		tax += quote.Lines[i].Amount
+	}
+	o.PricesIncludeTax = quote.PricesIncludeTax
+	o.TaxAmount = roundMoney(tax)
+	if !quote.PricesIncludeTax {
+		o.TotalAmount = roundMoney(o.TotalAmount + o.TaxAmount)
+	}
+	return nil
+}
+
+// TaxBreakdown sums the taxable amounts and tax of the items per rate, in
+// ascending rate order.
+func TaxBreakdown(items []OrderItem) []TaxBreakdownLine {
+	index := map[float64]int{}
+	var out []TaxBreakdownLine
+	for _, item := range items {
+		i, ok := index[item.TaxRate]
+		if !ok {
+			i = len(out)
+			index[item.TaxRate] = i
+			out = append(out, TaxBreakdownLine{Rate: item.TaxRate})
+		}
+		out[i].TaxableAmount = roundMoney(out[i].TaxableAmount + item.TaxableAmount)
+		out[i].TaxAmount = roundMoney(out[i].TaxAmount + item.TaxAmount)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Rate < out[j].Rate })
+	return out
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
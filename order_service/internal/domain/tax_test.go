@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxableLines_SpreadsDiscount(t *testing.T) {
+	items := []OrderItem{
+		{ProductID: uuid.New(), UnitPrice: 10, Quantity: 3},
+		{ProductID: uuid.New(), UnitPrice: 70, Quantity: 1, Category: "books"},
+	}
+
+	lines := TaxableLines(items, 10)
+
+	assert.Equal(t, 27.0, lines[0].Amount)
+	assert.Equal(t, 63.0, lines[1].Amount)
+	assert.Equal(t, "books", lines[1].Category)
+
+	// Odd cents go to the last line.
+	lines = TaxableLines([]OrderItem{{UnitPrice: 1, Quantity: 1}, {UnitPrice: 1, Quantity: 1}, {UnitPrice: 1, Quantity: 1}}, 1)
+	assert.Equal(t, []float64{0.67, 0.67, 0.66}, []float64{lines[0].Amount, lines[1].Amount, lines[2].Amount})
+}
+
+func TestOrder_ApplyTax(t *testing.T) {
+	items := []OrderItem{{UnitPrice: 100, Quantity: 1}, {UnitPrice: 50, Quantity: 1}}
+	lines := TaxableLines(items, 0)
+
+	exclusive := &Order{}
+	exclusive.Price(items, nil)
+	quote := &TaxQuote{Lines: []LineTax{{Rate: 0.15, Amount: TaxAt(100, 0.15, false)}, {Rate: 0, Amount: 0}}}
+	assert.NoError(t, exclusive.ApplyTax(items, lines, quote))
+	assert.Equal(t, 15.0, exclusive.TaxAmount)
+	assert.Equal(t, 165.0, exclusive.TotalAmount, "tax is added on top")
+	assert.Equal(t, []TaxBreakdownLine{
+		{Rate: 0, TaxableAmount: 50, TaxAmount: 0},
+		{Rate: 0.15, TaxableAmount: 100, TaxAmount: 15},
+	}, TaxBreakdown(items))
+
+	inclusive := &Order{}
+	inclusive.Price(items, nil)
+	quote = &TaxQuote{PricesIncludeTax: true, Lines: []LineTax{{Rate: 0.15, Amount: TaxAt(100, 0.15, true)}, {Rate: 0, Amount: 0}}}
+	assert.NoError(t, inclusive.ApplyTax(items, lines, quote))
+	assert.Equal(t, 13.04, inclusive.TaxAmount)
+	assert.Equal(t, 150.0, inclusive.TotalAmount, "tax is part of the prices")
+	assert.Equal(t, 86.96, items[0].TaxableAmount)
+
+	assert.Error(t, inclusive.ApplyTax(items, lines, &TaxQuote{}))
+}
@@ -0,0 +1,120 @@
+// Package archive stores the files archived orders are exported to, in a
+// local directory or an S3-compatible bucket.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Open returns the store rawURL names: a directory, as a path or file://
+// URL, or a bucket as s3://bucket/prefix. S3 query parameters set the
+// endpoint (default s3.amazonaws.com), the region and insecure=true for
+// plain HTTP. Credentials come from the AWS_ or MINIO_ environment
+// variables, or else the instance or task role.
+func Open(rawURL string) (domain.ArchiveStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("archive export URL: %w", err)
+	}
+	switch u.Scheme {
+	case "", "file":
+		return NewDirStore(u.Path), nil
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("archive export URL %q names no bucket", rawURL)
+		}
+		q := u.Query()
+		endpoint := q.Get("endpoint")
+		if endpoint == "" {
+			endpoint = "s3.amazonaws.com"
+		}
+		client, err := minio.New(endpoint, &minio.Options{
+			Creds: credentials.NewChainCredentials([]credentials.Provider{
+				&credentials.EnvAWS{},
+				&credentials.EnvMinio{},
+				&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+			}),
+			Secure: q.Get("insecure") != "true",
+			Region: q.Get("region"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("archive bucket: %w", err)
+		}
+		return NewS3Store(client, u.Host, strings.Trim(u.Path, "/")), nil
+	default:
+		return nil, fmt.Errorf("archive export URL %q: scheme must be file or s3", rawURL)
+	}
+}
+
+// DirStore keeps files under a local directory.
+type DirStore struct {
+	dir string
+}
+
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// Put writes the file next to its destination and renames it into place, so
+// that a file is either complete or absent.
+func (s *DirStore) Put(ctx context.Context, name string, data []byte) (string, error) {
+	dst := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// S3Store keeps files in a bucket of an S3-compatible object store, under a
+// key prefix.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+func NewS3Store(client *minio.Client, bucket, prefix string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (s *S3Store) Put(ctx context.Context, name string, data []byte) (string, error) {
+	key := path.Join(s.prefix, name)
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/gzip",
+	})
+	if err != nil {
+		return "", fmt.Errorf("uploading %s: %w", key, err)
+	}
+	return "s3://" + s.bucket + "/" + key, nil
+}
@@ -0,0 +1,48 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirStore_Put(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open("file://" + dir)
+	require.NoError(t, err)
+
+	location, err := store.Put(context.Background(), "orders/2025/01/02/a.jsonl.gz", []byte("first"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "orders", "2025", "01", "02", "a.jsonl.gz"), location)
+
+	// Putting it again replaces the file.
+	_, err = store.Put(context.Background(), "orders/2025/01/02/a.jsonl.gz", []byte("second"))
+	require.NoError(t, err)
+	data, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+	entries, err := os.ReadDir(filepath.Dir(location))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
+
+func TestOpen(t *testing.T) {
+	store, err := Open("/var/lib/orders")
+	require.NoError(t, err)
+	assert.IsType(t, &DirStore{}, store)
+
+	store, err = Open("s3://archive/orders?endpoint=minio:9000&insecure=true")
+	require.NoError(t, err)
+	require.IsType(t, &S3Store{}, store)
+	assert.Equal(t, "archive", store.(*S3Store).bucket)
+	assert.Equal(t, "orders", store.(*S3Store).prefix)
+
+	_, err = Open("s3:///orders")
+	assert.ErrorContains(t, err, "names no bucket")
+	_, err = Open("gs://archive")
+	assert.ErrorContains(t, err, "scheme must be file or s3")
+}
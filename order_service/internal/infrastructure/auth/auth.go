@@ -0,0 +1,156 @@
+// Package auth verifies the access tokens issued by the user service and
+// enforces per-method role requirements on the gRPC server.
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Roles as issued by the user service.
+const (
+	RoleAdmin  = "admin"
+	RoleSeller = "seller"
+	RoleBuyer  = "buyer"
+)
+
+// ErrInvalidToken is returned for a token that is malformed, expired or not
+// signed with the configured secret.
+var ErrInvalidToken = errors.New("invalid access token")
+
+// Identity is the caller described by a verified access token.
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+// HasRole reports whether the caller has the given role.
+func (id *Identity) HasRole(role string) bool {
+	return slices.Contains(id.Roles, role)
+}
+
+// claims mirrors the user service's tokens: roles holds every role, role
+// only the first one and is all older tokens carry.
+type claims struct {
+	Roles []string `json:"roles"`
+	Role  string   `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Verifier checks HS256 access tokens signed with the shared JWT secret.
+type Verifier struct {
+	secret []byte
+}
+
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{secret: []byte(secret)}
+}
+
+// Verify parses a token and returns the identity it carries.
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	if len(v.secret) == 0 {
+		return nil, ErrInvalidToken
+	}
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (any, error) {
+		return v.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || c.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	roles := c.Roles
+	if len(roles) == 0 && c.Role != "" {
+		roles = []string{c.Role}
+	}
+	return &Identity{Subject: c.Subject, Roles: roles}, nil
+}
+
+type identityKey struct{}
+
+// FromContext returns the caller's identity, if the request carried a valid
+// token.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// Policy maps full gRPC method names to the roles allowed to call them; any
+// one of them suffices. Methods not listed are open; a token sent to them is
+// still verified and its identity made available to the handler.
+type Policy map[string][]string
+
+// UnaryInterceptor authenticates unary calls according to the policy.
+func UnaryInterceptor(v *Verifier, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, v, policy[info.FullMethod])
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates streaming calls according to the policy.
+func StreamInterceptor(v *Verifier, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), v, policy[info.FullMethod])
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate verifies the bearer token in the request metadata, if any,
+// and checks it grants one of roles. Missing or invalid credentials are
+// Unauthenticated, a valid token without the roles PermissionDenied.
+func authenticate(ctx context.Context, v *Verifier, roles []string) (context.Context, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if len(roles) > 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing access token")
+		}
+		return ctx, nil
+	}
+	id, err := v.Verify(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(roles) > 0 && !slices.ContainsFunc(roles, id.HasRole) {
+		return nil, status.Errorf(codes.PermissionDenied, "requires one of the roles %s", strings.Join(roles, ", "))
+	}
+	return NewContext(ctx, id), nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "bearer") && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "test-secret"
+
+func sign(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func userClaims(roles ...string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "6f1c2a8e-3b7d-4c55-9b0e-2f4d8a1c7e90",
+		"roles": roles,
+		"role":  roles[0],
+		"exp":   time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	v := NewVerifier(testSecret)
+
+	id, err := v.Verify(sign(t, testSecret, userClaims(RoleSeller, RoleBuyer)))
+	require.NoError(t, err)
+	assert.Equal(t, "6f1c2a8e-3b7d-4c55-9b0e-2f4d8a1c7e90", id.Subject)
+	assert.True(t, id.HasRole(RoleSeller))
+	assert.False(t, id.HasRole(RoleAdmin))
+
+	legacy := userClaims(RoleAdmin)
+	delete(legacy, "roles")
+	id, err = v.Verify(sign(t, testSecret, legacy))
+	require.NoError(t, err)
+	assert.True(t, id.HasRole(RoleAdmin))
+}
+
+func TestVerifier_Verify_Rejects(t *testing.T) {
+	expired := userClaims(RoleAdmin)
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	noExpiry := userClaims(RoleAdmin)
+	delete(noExpiry, "exp")
+
+	cases := map[string]string{
+		"wrong secret": sign(t, "other-secret", userClaims(RoleAdmin)),
+		"expired":      sign(t, testSecret, expired),
+		"no expiry":    sign(t, testSecret, noExpiry),
+		"malformed":    "not-a-token",
+	}
+	v := NewVerifier(testSecret)
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := v.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+
+	_, err := NewVerifier("").Verify(sign(t, "", userClaims(RoleAdmin)))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	const method = "/test.Service/Restricted"
+	intercept := UnaryInterceptor(NewVerifier(testSecret), Policy{method: {RoleAdmin, RoleSeller}})
+
+	call := func(fullMethod, token string) (*Identity, error) {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		var seen *Identity
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, func(ctx context.Context, _ any) (any, error) {
+			seen, _ = FromContext(ctx)
+			return nil, nil
+		})
+		return seen, err
+	}
+
+	id, err := call(method, sign(t, testSecret, userClaims(RoleAdmin)))
+	require.NoError(t, err)
+	assert.True(t, id.HasRole(RoleAdmin))
+
+	id, err = call(method, sign(t, testSecret, userClaims(RoleBuyer, RoleSeller)))
+	require.NoError(t, err)
+	assert.True(t, id.HasRole(RoleSeller))
+
+	_, err = call(method, sign(t, testSecret, userClaims(RoleBuyer)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = call(method, "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(method, "garbage")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	id, err = call("/test.Service/Open", "")
+	require.NoError(t, err)
+	assert.Nil(t, id)
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+// keyPrefix namespaces the cached orders; bump its version when the JSON of
+// domain.Order changes incompatibly.
+const keyPrefix = "order_service:order:v1:"
+
+// tombstone replaces an order that changed, for the hold time.
+var tombstone = []byte("changed")
+
+// OrderRepository caches the orders of a repository looked up by ID, the
+// hot path of status polling. Other methods go straight to the repository.
+//
+// A write to an order replaces its cached copy with a tombstone for the hold
+// time, and lookups only cache an order where nothing is cached. A lookup
+// that read the order before the write, or read a replica that had not
+// caught up, thus cannot cache the old order once the write is done.
+type OrderRepository struct {
+	domain.OrderRepository
+	store Store
+	ttl   time.Duration
+	hold  time.Duration
+	group singleflight.Group
+}
+
+// NewOrderRepository caches the orders of next in store for ttl, and stops
+// caching an order for hold after it changes. hold should exceed the
+// replication lag tolerated on the replicas.
+func NewOrderRepository(next domain.OrderRepository, store Store, ttl, hold time.Duration) *OrderRepository {
+	return &OrderRepository{OrderRepository: next, store: store, ttl: ttl, hold: hold}
+}
+
+func orderKey(id uuid.UUID) string {
+	return keyPrefix + id.String()
+}
+
+// GetOrderByID serves the order from the cache, or loads it once however many
+// lookups of it miss at the same time.
+func (r *OrderRepository) GetOrderByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	key := orderKey(id)
+	data, err := r.store.Get(ctx, key)
+	switch {
+	case err == nil && !bytes.Equal(data, tombstone):
+		order, err := decode(data)
+		if err == nil {
+			return order, nil
+		}
+		log.Printf("Ignoring cached order %s: %v", id, err)
+	case err != nil && !errors.Is(err, ErrMiss):
+		log.Printf("Reading order %s from the cache: %v", id, err)
+	}
+
+	// The load is detached from ctx: callers giving up must not fail the
+	// others waiting for it.
+	loaded := r.group.DoChan(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		order, err := r.OrderRepository.GetOrderByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(order)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.store.Add(ctx, key, data, r.ttl); err != nil {
+			log.Printf("Caching order %s: %v", id, err)
+		}
+		return data, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-loaded:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		// Callers modify the order they get, so each decodes its own.
+		return decode(res.Val.([]byte))
+	}
+}
+
+func decode(data []byte) (*domain.Order, error) {
+	var order domain.Order
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ChangeStatus invalidates the order even when the change fails: a version
+// conflict means the cached order is outdated.
+func (r *OrderRepository) ChangeStatus(ctx context.Context, entry *domain.OrderStatusHistory, version int64) error {
+	defer r.invalidate(ctx, entry.OrderID)
+	return r.OrderRepository.ChangeStatus(ctx, entry, version)
+}
+
+func (r *OrderRepository) AmendOrder(ctx context.Context, order *domain.Order, removed []uuid.UUID, amendment *domain.OrderAmendment) error {
+	defer r.invalidate(ctx, order.ID)
+	return r.OrderRepository.AmendOrder(ctx, order, removed, amendment)
+}
+
+func (r *OrderRepository) UpdateLineFulfillment(ctx context.Context, orderID uuid.UUID, itemIDs []uuid.UUID, status domain.FulfillmentStatus, at time.Time) error {
+	defer r.invalidate(ctx, orderID)
+	return r.OrderRepository.UpdateLineFulfillment(ctx, orderID, itemIDs, status, at)
+}
+
+// invalidate replaces the cached order with a tombstone, even when ctx is
+// done, and makes the next lookup load it anew.
+func (r *OrderRepository) invalidate(ctx context.Context, id uuid.UUID) {
+	key := orderKey(id)
+	r.group.Forget(key)
+	if err := r.store.Set(context.WithoutCancel(ctx), key, tombstone, r.hold); err != nil {
+		log.Printf("Invalidating cached order %s: %v", id, err)
+	}
+}
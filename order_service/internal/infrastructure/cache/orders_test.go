@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/memory"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/repotest"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redisURLEnv names a Redis the conformance suite also runs against; it is
+// left with the suite's keys.
+const redisURLEnv = "ORDER_SERVICE_TEST_REDIS_URL"
+
+func TestConformance_Local(t *testing.T) {
+	repotest.TestOrderRepository(t, func(t *testing.T) repotest.Repositories {
+		store := memory.NewStore()
+		cached := NewOrderRepository(store, NewLocalStore(100, time.Minute), time.Minute, time.Second)
+		return repotest.Repositories{Orders: cached, Promotions: store}
+	})
+}
+
+func TestConformance_Redis(t *testing.T) {
+	url := os.Getenv(redisURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", redisURLEnv)
+	}
+	opts, err := redis.ParseURL(url)
+	require.NoError(t, err)
+	client := redis.NewClient(opts)
+	t.Cleanup(func() { client.Close() })
+
+	repotest.TestOrderRepository(t, func(t *testing.T) repotest.Repositories {
+		store := memory.NewStore()
+		cached := NewOrderRepository(store, NewRedisStore(client), time.Minute, time.Second)
+		return repotest.Repositories{Orders: cached, Promotions: store}
+	})
+}
+
+// countingRepository counts lookups and can hold them until released.
+type countingRepository struct {
+	domain.OrderRepository
+	lookups atomic.Int32
+	release chan struct{}
+}
+
+func (r *countingRepository) GetOrderByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	r.lookups.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	return r.OrderRepository.GetOrderByID(ctx, id)
+}
+
+func newOrder(t *testing.T, repo domain.OrderRepository) *domain.Order {
+	id := uuid.New()
+	order := &domain.Order{ID: id, UserID: uuid.New(), Status: domain.StatusPending, Currency: "ETB", Subtotal: 40, TotalAmount: 40}
+	items := []domain.OrderItem{{ID: uuid.New(), OrderID: id, ProductID: uuid.New(), SellerID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 1}}
+	require.NoError(t, repo.CreateOrder(context.Background(), order, items, nil))
+	return order
+}
+
+func TestOrderRepository_ServesCachedOrders(t *testing.T) {
+	next := &countingRepository{OrderRepository: memory.NewStore()}
+	repo := NewOrderRepository(next, NewLocalStore(100, time.Minute), time.Minute, time.Second)
+	order := newOrder(t, repo)
+	ctx := context.Background()
+
+	first, err := repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	first.Status = domain.StatusCanceled // callers get copies
+	second, err := repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, second.Status)
+	assert.Equal(t, int32(1), next.lookups.Load())
+
+	_, err = repo.GetOrderByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
+
+func TestOrderRepository_LoadsOnceForConcurrentMisses(t *testing.T) {
+	next := &countingRepository{OrderRepository: memory.NewStore(), release: make(chan struct{})}
+	repo := NewOrderRepository(next, NewLocalStore(100, time.Minute), time.Minute, time.Second)
+	order := newOrder(t, repo)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := repo.GetOrderByID(context.Background(), order.ID)
+			if assert.NoError(t, err) {
+				assert.Equal(t, order.ID, got.ID)
+			}
+		}()
+	}
+	require.Eventually(t, func() bool { return next.lookups.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond) // let the others join the lookup
+	close(next.release)
+	wg.Wait()
+	assert.Equal(t, int32(1), next.lookups.Load())
+}
+
+func TestOrderRepository_WaitersGiveUpAlone(t *testing.T) {
+	next := &countingRepository{OrderRepository: memory.NewStore(), release: make(chan struct{})}
+	repo := NewOrderRepository(next, NewLocalStore(100, time.Minute), time.Minute, time.Second)
+	order := newOrder(t, repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := repo.GetOrderByID(ctx, order.ID)
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return next.lookups.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// The lookup carries on for the others and fills the cache.
+	close(next.release)
+	got, err := repo.GetOrderByID(context.Background(), order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, got.ID)
+	assert.Equal(t, int32(1), next.lookups.Load())
+}
+
+func TestOrderRepository_InvalidatesWrites(t *testing.T) {
+	next := &countingRepository{OrderRepository: memory.NewStore()}
+	local := NewLocalStore(100, time.Minute)
+	repo := NewOrderRepository(next, local, time.Minute, time.Second)
+	order := newOrder(t, repo)
+	ctx := context.Background()
+
+	_, err := repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.ChangeStatus(ctx, &domain.OrderStatusHistory{OrderID: order.ID, FromStatus: domain.StatusPending, Status: domain.StatusPaid}, 1))
+
+	got, err := repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPaid, got.Status)
+
+	// A conflicting write invalidates too, so the caller's retry sees the
+	// current version.
+	err = repo.ChangeStatus(ctx, &domain.OrderStatusHistory{OrderID: order.ID, FromStatus: domain.StatusPaid, Status: domain.StatusShipped}, 1)
+	require.ErrorIs(t, err, domain.ErrVersionConflict)
+	_, err = local.Get(ctx, orderKey(order.ID))
+	require.NoError(t, err)
+	got, err = repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got.Version)
+	assert.Equal(t, int32(3), next.lookups.Load(), "nothing is cached while the order is held")
+
+	require.NoError(t, repo.UpdateLineFulfillment(ctx, order.ID, []uuid.UUID{got.Items[0].ID}, domain.FulfillmentPacked, time.Now()))
+	got, err = repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.FulfillmentPacked, got.Items[0].FulfillmentStatus)
+}
+
+func TestOrderRepository_HoldKeepsStaleLookupsOut(t *testing.T) {
+	store := memory.NewStore()
+	local := NewLocalStore(100, time.Minute)
+	repo := NewOrderRepository(store, local, time.Minute, time.Minute)
+	order := newOrder(t, repo)
+	ctx := context.Background()
+
+	require.NoError(t, repo.ChangeStatus(ctx, &domain.OrderStatusHistory{OrderID: order.ID, FromStatus: domain.StatusPending, Status: domain.StatusPaid}, 1))
+
+	// A lookup that read the order before the change, or from a lagging
+	// replica, finishes after it.
+	stale, err := store.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	stale.Status = domain.StatusPending
+	stale.Version = 1
+	data := mustJSON(t, stale)
+	require.NoError(t, local.Add(ctx, orderKey(order.ID), data, time.Minute))
+
+	got, err := repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPaid, got.Status)
+}
+
+// erasingRepository erases the orders of a memory store by hand.
+type erasingRepository struct {
+	domain.UserDataRepository
+	store *memory.Store
+}
+
+func (r erasingRepository) EraseUser(ctx context.Context, erasure *domain.UserErasure) error {
+	orders, err := r.store.ListOrders(ctx, domain.OrderListQuery{UserID: erasure.UserID, Limit: 100})
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		erasure.OrderIDs = append(erasure.OrderIDs, order.ID)
+	}
+	return nil
+}
+
+func TestUserDataRepository_InvalidatesErasedOrders(t *testing.T) {
+	next := &countingRepository{OrderRepository: memory.NewStore()}
+	local := NewLocalStore(100, time.Minute)
+	orders := NewOrderRepository(next, local, time.Minute, time.Second)
+	order := newOrder(t, orders)
+	ctx := context.Background()
+
+	_, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	repo := NewUserDataRepository(erasingRepository{store: next.OrderRepository.(*memory.Store)}, orders)
+	require.NoError(t, repo.EraseUser(ctx, &domain.UserErasure{UserID: order.UserID}))
+
+	cached, err := local.Get(ctx, orderKey(order.ID))
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, cached)
+}
+
+// returningRepository records returns without storing them.
+type returningRepository struct {
+	domain.ReturnRepository
+}
+
+func (returningRepository) CreateReturn(context.Context, *domain.OrderReturn, *domain.OrderStatusHistory) error {
+	return nil
+}
+
+func TestReturnRepository_InvalidatesMovedOrders(t *testing.T) {
+	next := &countingRepository{OrderRepository: memory.NewStore()}
+	local := NewLocalStore(100, time.Minute)
+	orders := NewOrderRepository(next, local, time.Minute, time.Second)
+	order := newOrder(t, orders)
+	ctx := context.Background()
+
+	_, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	repo := NewReturnRepository(returningRepository{}, orders)
+	ret := &domain.OrderReturn{ID: uuid.New(), OrderID: order.ID}
+	require.NoError(t, repo.CreateReturn(ctx, ret, &domain.OrderStatusHistory{OrderID: order.ID, Status: domain.StatusReturnRequested}))
+
+	cached, err := local.Get(ctx, orderKey(order.ID))
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, cached)
+}
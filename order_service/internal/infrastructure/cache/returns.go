@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+)
+
+// ReturnRepository drops the cached copy of an order whose status a return
+// changes.
+type ReturnRepository struct {
+	domain.ReturnRepository
+	orders *OrderRepository
+}
+
+// NewReturnRepository invalidates the orders moved by returns created
+// through next in the cache of orders.
+func NewReturnRepository(next domain.ReturnRepository, orders *OrderRepository) *ReturnRepository {
+	return &ReturnRepository{ReturnRepository: next, orders: orders}
+}
+
+func (r *ReturnRepository) CreateReturn(ctx context.Context, ret *domain.OrderReturn, entry *domain.OrderStatusHistory) error {
+	if entry != nil {
+		defer r.orders.invalidate(ctx, ret.OrderID)
+	}
+	return r.ReturnRepository.CreateReturn(ctx, ret, entry)
+}
@@ -0,0 +1,136 @@
+// Package cache keeps orders looked up by ID in Redis, in process memory or
+// both, in front of the order repository.
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrMiss is returned by Store.Get when the key holds no value.
+var ErrMiss = errors.New("cache miss")
+
+// Store holds values by key until they expire.
+type Store interface {
+	// Get returns the value of key, or ErrMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores the value under key for ttl, replacing any value.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Add stores the value under key for ttl unless key already holds one.
+	Add(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// LocalStore is a Store in process memory holding up to a fixed number of
+// values. Values are kept for at most the store's TTL, however long they
+// were stored for, which bounds how stale a value another instance changed
+// can get.
+type LocalStore struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]localEntry
+}
+
+type localEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func NewLocalStore(size int, ttl time.Duration) *LocalStore {
+	return &LocalStore{size: size, ttl: ttl, now: time.Now, entries: map[string]localEntry{}}
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok || !s.now().Before(e.expires) {
+		return nil, ErrMiss
+	}
+	return e.value, nil
+}
+
+func (s *LocalStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(key, value, ttl)
+	return nil
+}
+
+func (s *LocalStore) Add(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && s.now().Before(e.expires) {
+		return nil
+	}
+	s.put(key, value, ttl)
+	return nil
+}
+
+func (s *LocalStore) put(key string, value []byte, ttl time.Duration) {
+	now := s.now()
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= s.size {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		// Still full of live values: make room for this one.
+		for k := range s.entries {
+			if len(s.entries) < s.size {
+				break
+			}
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = localEntry{value: value, expires: now.Add(min(ttl, s.ttl))}
+}
+
+// FallbackStore writes to a shared store, such as Redis, and to a local one,
+// and reads the local store while the shared one fails.
+type FallbackStore struct {
+	shared Store
+	local  Store
+	down   atomic.Bool
+}
+
+func NewFallbackStore(shared, local Store) *FallbackStore {
+	return &FallbackStore{shared: shared, local: local}
+}
+
+func (s *FallbackStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.shared.Get(ctx, key)
+	if err == nil || errors.Is(err, ErrMiss) {
+		s.report(nil)
+		return value, err
+	}
+	s.report(err)
+	return s.local.Get(ctx, key)
+}
+
+func (s *FallbackStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.local.Set(ctx, key, value, ttl)
+	return s.shared.Set(ctx, key, value, ttl)
+}
+
+func (s *FallbackStore) Add(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.local.Add(ctx, key, value, ttl)
+	return s.shared.Add(ctx, key, value, ttl)
+}
+
+// report logs when the shared store starts and stops failing.
+func (s *FallbackStore) report(err error) {
+	if failing := err != nil; s.down.Swap(failing) != failing {
+		if failing {
+			log.Printf("Order cache unavailable, using the local cache: %v", err)
+		} else {
+			log.Println("Order cache available again")
+		}
+	}
+}
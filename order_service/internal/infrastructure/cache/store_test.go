@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustJSON(t *testing.T, v any) []byte {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+func TestLocalStore(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := NewLocalStore(2, time.Minute)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, store.Add(ctx, "a", []byte("2"), time.Hour))
+	value, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(value), "Add keeps the stored value")
+
+	// Values live for the store's TTL at most.
+	now = now.Add(time.Minute)
+	_, err = store.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	require.NoError(t, store.Add(ctx, "a", []byte("2"), time.Hour))
+	value, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(value))
+
+	// A full store makes room.
+	require.NoError(t, store.Set(ctx, "b", []byte("3"), time.Hour))
+	require.NoError(t, store.Set(ctx, "c", []byte("4"), time.Hour))
+	assert.Len(t, store.entries, 2)
+	value, err = store.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, "4", string(value))
+}
+
+// failingStore fails every call while down.
+type failingStore struct {
+	Store
+	down bool
+}
+
+var errDown = errors.New("connection refused")
+
+func (s *failingStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s.down {
+		return nil, errDown
+	}
+	return s.Store.Get(ctx, key)
+}
+
+func (s *failingStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if s.down {
+		return errDown
+	}
+	return s.Store.Set(ctx, key, value, ttl)
+}
+
+func (s *failingStore) Add(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if s.down {
+		return errDown
+	}
+	return s.Store.Add(ctx, key, value, ttl)
+}
+
+func TestFallbackStore(t *testing.T) {
+	shared := &failingStore{Store: NewLocalStore(10, time.Hour)}
+	store := NewFallbackStore(shared, NewLocalStore(10, time.Hour))
+	ctx := context.Background()
+
+	require.NoError(t, store.Add(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, shared.Set(ctx, "a", []byte("2"), time.Hour))
+	value, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(value), "the shared store is read while it works")
+
+	shared.down = true
+	value, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(value))
+
+	// Writes reach the local store while the shared one fails.
+	assert.ErrorIs(t, store.Set(ctx, "a", tombstone, time.Hour), errDown)
+	value, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, value)
+
+	shared.down = false
+	value, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(value))
+}
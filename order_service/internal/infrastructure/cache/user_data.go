@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+)
+
+// UserDataRepository drops the cached copies of the orders whose personal
+// data it erases.
+type UserDataRepository struct {
+	domain.UserDataRepository
+	orders *OrderRepository
+}
+
+// NewUserDataRepository invalidates the orders erased through next in the
+// cache of orders.
+func NewUserDataRepository(next domain.UserDataRepository, orders *OrderRepository) *UserDataRepository {
+	return &UserDataRepository{UserDataRepository: next, orders: orders}
+}
+
+func (r *UserDataRepository) EraseUser(ctx context.Context, erasure *domain.UserErasure) error {
+	err := r.UserDataRepository.EraseUser(ctx, erasure)
+	for _, id := range erasure.OrderIDs {
+		r.orders.invalidate(ctx, id)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+// Package gateway exposes the order service's REST/JSON API by transcoding
+// HTTP requests to the gRPC server, following the google.api.http rules in
+// proto/order.proto.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewHandler returns an HTTP handler serving the REST routes under /api/
+// by calling the gRPC server at grpcAddr, and the OpenAPI document at
+// /swagger.json. gRPC status codes are mapped to HTTP statuses by the
+// gateway runtime, e.g. InvalidArgument to 400 and NotFound to 404.
+func NewHandler(ctx context.Context, grpcAddr string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		// JSON field names match the proto and the other services' APIs.
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterOrderServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("registering order gateway: %w", err)
+	}
+
+	root := http.NewServeMux()
+	root.Handle("/api/", mux)
+	root.HandleFunc("GET /swagger.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(pb.OpenAPI)
+	})
+	return root, nil
+}
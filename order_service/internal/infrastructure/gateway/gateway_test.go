@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const knownOrderID = "6f1c2a8e-4a51-4a8e-9f0e-3c0d5f0b7a11"
+
+type stubOrderServer struct {
+	pb.UnimplementedOrderServiceServer
+}
+
+func (stubOrderServer) GetOrder(_ context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	if req.OrderId != knownOrderID {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &pb.Order{OrderId: req.OrderId, Status: "CREATED"}, nil
+}
+
+func (stubOrderServer) ListOrders(_ context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	return &pb.ListOrdersResponse{
+		Orders:        []*pb.Order{{OrderId: knownOrderID, UserId: req.UserId}},
+		NextPageToken: "next",
+	}, nil
+}
+
+func (stubOrderServer) CancelOrder(_ context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	return nil, status.Error(codes.FailedPrecondition, "order can no longer be canceled")
+}
+
+func (stubOrderServer) CreateOrder(_ context.Context, req *pb.CreateOrderRequest) (*pb.OrderResponse, error) {
+	return &pb.OrderResponse{OrderId: knownOrderID, Status: "CREATED"}, nil
+}
+
+func (stubOrderServer) ExportUserData(_ context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	data, err := structpb.NewStruct(map[string]any{"user_id": req.UserId, "orders": []any{}})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ExportUserDataResponse{Data: data}, nil
+}
+
+func newTestGateway(t *testing.T) http.Handler {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	pb.RegisterOrderServiceServer(srv, stubOrderServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	h, err := NewHandler(ctx, lis.Addr().String())
+	require.NoError(t, err)
+	return h
+}
+
+func serve(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGateway_Routes(t *testing.T) {
+	h := newTestGateway(t)
+
+	rec := serve(h, http.MethodGet, "/api/v1/orders/"+knownOrderID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var order map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &order))
+	assert.Equal(t, knownOrderID, order["order_id"])
+
+	rec = serve(h, http.MethodGet, "/api/v1/orders?user_id=u-1&page_size=10", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"next_page_token":"next"`)
+
+	rec = serve(h, http.MethodPost, "/api/v1/orders", `{"user_id":"u-1","items":[],"shipping_address":{"city":"Addis Ababa"}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(h, http.MethodGet, "/api/v1/orders:exportUserData?user_id=u-1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data":{"user_id":"u-1","orders":[]}}`, rec.Body.String())
+}
+
+func TestGateway_MapsStatusCodes(t *testing.T) {
+	h := newTestGateway(t)
+
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/api/v1/orders/unknown", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodGet, "/api/v1/orders", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodPost, "/api/v1/orders/"+knownOrderID+":cancel", `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodPost, "/api/v1/orders", `not json`).Code)
+}
+
+func TestGateway_ServesOpenAPI(t *testing.T) {
+	h := newTestGateway(t)
+
+	rec := serve(h, http.MethodGet, "/swagger.json", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var doc struct {
+		Paths map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Contains(t, doc.Paths, "/api/v1/orders")
+	assert.Contains(t, doc.Paths, "/api/v1/orders/{order_id}")
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The order, return and amendment RPCs are open to any authenticated caller,
+// so each handler checks what the caller may act on: users their own orders,
+// admins any order.
+
+// caller returns the identity of the caller, who must have sent a token.
+func caller(ctx context.Context) (*auth.Identity, error) {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+	return id, nil
+}
+
+// mayAccess reports whether the caller may access what belongs to userID.
+func mayAccess(id *auth.Identity, userID uuid.UUID) bool {
+	subject, err := uuid.Parse(id.Subject)
+	return (err == nil && subject == userID) || id.HasRole(auth.RoleAdmin)
+}
+
+// userFor resolves the user a request acts on: the caller, unless an admin
+// names another user.
+func userFor(ctx context.Context, requested string) (uuid.UUID, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	subject := id.Subject
+	if requested != "" && requested != id.Subject {
+		if !id.HasRole(auth.RoleAdmin) {
+			return uuid.Nil, status.Error(codes.PermissionDenied, "users can only access their own orders")
+		}
+		subject = requested
+	}
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, invalidArgument("user_id", "invalid user_id")
+	}
+	return userID, nil
+}
+
+// orderFor loads an order the caller may access. The orders of other users
+// are not found, so that order IDs cannot be probed.
+func (h *OrderHandler) orderFor(ctx context.Context, orderID uuid.UUID) (*domain.Order, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := h.getOrderUC.Execute(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !mayAccess(id, order.UserID) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubOrders serves one order and one return; the handlers under test reach
+// nothing else before checking access.
+type stubOrders struct {
+	domain.OrderRepository
+	domain.ReturnRepository
+	order *domain.Order
+	ret   *domain.OrderReturn
+}
+
+func (s *stubOrders) GetOrderByID(_ context.Context, id uuid.UUID) (*domain.Order, error) {
+	if id != s.order.ID {
+		return nil, domain.ErrOrderNotFound
+	}
+	order := *s.order
+	return &order, nil
+}
+
+func (s *stubOrders) GetReturn(_ context.Context, id uuid.UUID) (*domain.OrderReturn, error) {
+	if id != s.ret.ID {
+		return nil, domain.ErrReturnNotFound
+	}
+	ret := *s.ret
+	return &ret, nil
+}
+
+type watchStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s watchStream) Context() context.Context        { return s.ctx }
+func (s watchStream) Send(*pb.OrderStatusEvent) error { return nil }
+
+func TestOrderHandler_ForeignCaller(t *testing.T) {
+	owner := uuid.New()
+	order := &domain.Order{ID: uuid.New(), UserID: owner, Status: domain.StatusDelivered, Currency: "ETB"}
+	ret := &domain.OrderReturn{ID: uuid.New(), OrderID: order.ID, UserID: owner, Status: domain.ReturnRequested}
+	repo := &stubOrders{order: order, ret: ret}
+	h := NewOrderHandler(nil, usecases.NewGetOrderUseCase(repo), nil, nil, nil, nil, nil, nil,
+		usecases.NewReturnsUseCase(repo, repo, nil, nil, 14*24*time.Hour),
+		usecases.NewAmendOrderUseCase(repo, nil, nil, nil, "ET", nil, time.Second), nil)
+
+	stranger := uuid.NewString()
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: stranger, Roles: []string{auth.RoleBuyer}})
+	orderID, userID := order.ID.String(), owner.String()
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+		code codes.Code
+	}{
+		{"GetOrder", func(ctx context.Context) error {
+			_, err := h.GetOrder(ctx, &pb.GetOrderRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"GetOrderStatus", func(ctx context.Context) error {
+			_, err := h.GetOrderStatus(ctx, &pb.GetOrderStatusRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"GetOrderHistory", func(ctx context.Context) error {
+			_, err := h.GetOrderHistory(ctx, &pb.GetOrderHistoryRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"CancelOrder", func(ctx context.Context) error {
+			_, err := h.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"WatchOrder", func(ctx context.Context) error {
+			return h.WatchOrder(&pb.WatchOrderRequest{OrderId: orderID}, watchStream{ctx: ctx})
+		}, codes.NotFound},
+		{"ListOrderAmendments", func(ctx context.Context) error {
+			_, err := h.ListOrderAmendments(ctx, &pb.ListOrderAmendmentsRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"ListReturns of the order", func(ctx context.Context) error {
+			_, err := h.ListReturns(ctx, &pb.ListReturnsRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"GetReturn", func(ctx context.Context) error {
+			_, err := h.GetReturn(ctx, &pb.GetReturnRequest{ReturnId: ret.ID.String()})
+			return err
+		}, codes.NotFound},
+		{"RequestReturn of the order", func(ctx context.Context) error {
+			_, err := h.RequestReturn(ctx, &pb.RequestReturnRequest{OrderId: orderID, Reason: "broken"})
+			return err
+		}, codes.NotFound},
+		{"AmendOrder of the order", func(ctx context.Context) error {
+			_, err := h.AmendOrder(ctx, &pb.AmendOrderRequest{OrderId: orderID})
+			return err
+		}, codes.NotFound},
+		{"CreateOrder for the owner", func(ctx context.Context) error {
+			_, err := h.CreateOrder(ctx, &pb.CreateOrderRequest{UserId: userID, ShippingAddress: &pb.Address{City: "Addis Ababa"}})
+			return err
+		}, codes.PermissionDenied},
+		{"ListOrders of the owner", func(ctx context.Context) error {
+			_, err := h.ListOrders(ctx, &pb.ListOrdersRequest{UserId: userID})
+			return err
+		}, codes.PermissionDenied},
+		{"WatchUserOrders of the owner", func(ctx context.Context) error {
+			return h.WatchUserOrders(&pb.WatchUserOrdersRequest{UserId: userID}, watchStream{ctx: ctx})
+		}, codes.PermissionDenied},
+		{"ListReturns of the owner", func(ctx context.Context) error {
+			_, err := h.ListReturns(ctx, &pb.ListReturnsRequest{UserId: userID})
+			return err
+		}, codes.PermissionDenied},
+		{"RequestReturn for the owner", func(ctx context.Context) error {
+			_, err := h.RequestReturn(ctx, &pb.RequestReturnRequest{OrderId: orderID, UserId: userID, Reason: "broken"})
+			return err
+		}, codes.PermissionDenied},
+		{"AmendOrder for the owner", func(ctx context.Context) error {
+			_, err := h.AmendOrder(ctx, &pb.AmendOrderRequest{OrderId: orderID, UserId: userID})
+			return err
+		}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(ctx)
+			assert.Equal(t, tt.code, status.Code(statusError("/order.OrderService/"+tt.name, err)), "%v", err)
+
+			err = tt.call(context.Background())
+			assert.Equal(t, codes.Unauthenticated, status.Code(err), "a token is required")
+		})
+	}
+}
+
+func TestOrderHandler_OwnerAndAdmin(t *testing.T) {
+	owner := uuid.New()
+	order := &domain.Order{ID: uuid.New(), UserID: owner, Status: domain.StatusCreated, Currency: "ETB"}
+	ret := &domain.OrderReturn{ID: uuid.New(), OrderID: order.ID, UserID: owner, Status: domain.ReturnRequested}
+	repo := &stubOrders{order: order, ret: ret}
+	h := NewOrderHandler(nil, usecases.NewGetOrderUseCase(repo), nil, nil, nil, nil, nil, nil,
+		usecases.NewReturnsUseCase(repo, repo, nil, nil, 14*24*time.Hour), nil, nil)
+
+	for name, id := range map[string]*auth.Identity{
+		"owner": {Subject: owner.String(), Roles: []string{auth.RoleBuyer}},
+		"admin": {Subject: uuid.NewString(), Roles: []string{auth.RoleAdmin}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), id)
+			got, err := h.GetOrder(ctx, &pb.GetOrderRequest{OrderId: order.ID.String()})
+			require.NoError(t, err)
+			assert.Equal(t, owner.String(), got.UserId)
+
+			gotReturn, err := h.GetReturn(ctx, &pb.GetReturnRequest{ReturnId: ret.ID.String()})
+			require.NoError(t, err)
+			assert.Equal(t, ret.ID.String(), gotReturn.ReturnId)
+		})
+	}
+
+	// Users act on themselves when they name no user.
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: owner.String()})
+	userID, err := userFor(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, owner, userID)
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+)
+
+// AmendOrder records the caller's token subject as the author of the
+// amendment; without a token the user is.
+func (h *OrderHandler) AmendOrder(ctx context.Context, req *pb.AmendOrderRequest) (*pb.Order, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+	userID, err := userFor(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	input := usecases.AmendOrderInput{OrderID: orderID, UserID: userID}
+	if id, ok := auth.FromContext(ctx); ok {
+		input.AmendedBy = id.Subject
+	}
+	for _, item := range req.Items {
+		itemID, err := uuid.Parse(item.ItemId)
+		if err != nil {
+			return nil, invalidArgument("item_id", "invalid item_id")
+		}
+		input.Items = append(input.Items, usecases.ItemChangeInput{ItemID: itemID, Quantity: int(item.Quantity)})
+	}
+	if req.ShippingAddress != nil {
+		shipping := toAddressInput(req.ShippingAddress)
+		input.ShippingAddress = &shipping
+	}
+	if req.BillingAddress != nil {
+		billing := toAddressInput(req.BillingAddress)
+		input.BillingAddress = &billing
+	}
+
+	order, err := h.amendOrderUC.Execute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return toPBOrder(order), nil
+}
+
+func (h *OrderHandler) ListOrderAmendments(ctx context.Context, req *pb.ListOrderAmendmentsRequest) (*pb.ListOrderAmendmentsResponse, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+	if _, err := h.orderFor(ctx, orderID); err != nil {
+		return nil, err
+	}
+	amendments, err := h.amendOrderUC.ListAmendments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListOrderAmendmentsResponse{}
+	for i := range amendments {
+		resp.Amendments = append(resp.Amendments, toPBAmendment(&amendments[i]))
+	}
+	return resp, nil
+}
+
+func toPBAmendment(a *domain.OrderAmendment) *pb.OrderAmendment {
+	out := &pb.OrderAmendment{
+		AmendmentId:   a.ID.String(),
+		AmendedBy:     a.AmendedBy,
+		PreviousTotal: a.PreviousTotal,
+		NewTotal:      a.NewTotal,
+		AmendedAt:     a.AmendedAt.UTC().Format(time.RFC3339Nano),
+	}
+	for _, c := range a.Changes {
+		change := &pb.AmendmentChange{Field: string(c.Field), From: c.From, To: c.To}
+		if c.ItemID != nil {
+			change.ItemId = c.ItemID.String()
+		}
+		out.Changes = append(out.Changes, change)
+	}
+	return out
+}
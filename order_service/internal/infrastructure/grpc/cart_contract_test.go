@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestCartCheckoutContract places an order the way the cart service's
+// OrderClient does at checkout: the cart's request with a placeholder seller
+// and a four-field address, and the user service's access token of the cart's
+// owner as bearer metadata. It runs through the server's interceptors.
+func TestCartCheckoutContract(t *testing.T) {
+	const secret = "shared-secret"
+	h, _ := newCreateHandler()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryErrorInterceptor(), auth.UnaryInterceptor(auth.NewVerifier(secret), auth.Policy{})),
+	)
+	pb.RegisterOrderServiceServer(server, h)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := pb.NewOrderServiceClient(conn)
+
+	userID := uuid.NewString()
+	// As the user service signs them: roles, the first role and an expiry.
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": userID, "roles": []string{auth.RoleBuyer}, "role": auth.RoleBuyer, "exp": time.Now().Add(15 * time.Minute).Unix(),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+	req := &pb.CreateOrderRequest{
+		UserId: userID,
+		Items: []*pb.OrderItem{{
+			ProductId: uuid.NewString(), SellerId: "mock-seller-id", ProductName: "Lamp", UnitPrice: 40, Quantity: 2,
+		}},
+		ShippingAddress: &pb.Address{FullName: "Abebe Kebede", Phone: "+251911234567", City: "Addis Ababa", Street: "Bole Road"},
+		TotalAmount:     80,
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	got, err := client.CreateOrder(ctx, req)
+	require.NoError(t, err)
+	assert.NotEmpty(t, got.OrderId)
+	assert.Equal(t, 80.0, got.TotalAmount)
+
+	// Without the forwarded token checkout fails.
+	_, err = client.CreateOrder(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
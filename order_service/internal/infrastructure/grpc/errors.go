@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the ErrorInfo domain of the service's errors.
+const errorDomain = "order_service"
+
+// UnaryErrorInterceptor turns the errors handlers return into gRPC statuses;
+// see statusError. It is the outermost interceptor, so it also sees the
+// errors of the others.
+func UnaryErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, statusError(info.FullMethod, err)
+	}
+}
+
+// StreamErrorInterceptor is UnaryErrorInterceptor for streaming calls.
+func StreamErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return statusError(info.FullMethod, handler(srv, ss))
+	}
+}
+
+// statusError maps an error to a status; the REST gateway derives the HTTP
+// status from the code. Statuses pass through. Domain errors get the code of
+// their kind and an ErrorInfo with their reason, plus a BadRequest naming the
+// field of an invalid argument or a PreconditionFailure. Anything else is
+// logged and reported as Internal without its message, which may come from
+// the database.
+func statusError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		log.Printf("%s failed: %v", method, err)
+		return status.Error(codes.Internal, "internal error")
+	}
+	code := kindCode(err)
+	if code == codes.Unavailable && domainErr.Err != nil {
+		log.Printf("%s failed: %v: %v", method, err, domainErr.Err)
+	}
+
+	st := status.New(code, err.Error())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: domainErr.Reason, Domain: errorDomain}}
+	switch {
+	case code == codes.InvalidArgument && domainErr.Field != "":
+		details = append(details, &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: domainErr.Field, Description: err.Error()},
+		}})
+	case code == codes.FailedPrecondition:
+		details = append(details, &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: domainErr.Reason, Description: err.Error()},
+		}})
+	}
+	if detailed, derr := st.WithDetails(details...); derr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// kindCode returns the code of a domain error's kind. A concurrent update is
+// Aborted, for the client to retry the whole read-modify-write; other
+// conflicts are AlreadyExists.
+func kindCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, domain.ErrInvalidArgument):
+		return codes.InvalidArgument
+	case errors.Is(err, domain.ErrVersionConflict):
+		return codes.Aborted
+	case errors.Is(err, domain.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return codes.FailedPrecondition
+	case errors.Is(err, domain.ErrUnavailable):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// invalidArgument reports a malformed request field.
+func invalidArgument(field, message string) error {
+	return &domain.Error{Kind: domain.ErrInvalidArgument, Reason: "INVALID_FIELD", Field: field, Message: message}
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+		reason  string
+	}{
+		{"not found", domain.ErrOrderNotFound, codes.NotFound, "order not found", "ORDER_NOT_FOUND"},
+		{"wrapped invalid argument", fmt.Errorf("%w: the reason must be at most 500 characters", usecases.ErrInvalidCancellation),
+			codes.InvalidArgument, "invalid cancellation: the reason must be at most 500 characters", "INVALID_CANCELLATION"},
+		{"version conflict", &domain.VersionConflictError{OrderID: uuid.Nil, Version: 2}, codes.Aborted,
+			"order 00000000-0000-0000-0000-000000000000 is no longer at version 2: order was modified concurrently", "VERSION_CONFLICT"},
+		{"other conflict", domain.ErrPromotionCodeTaken, codes.AlreadyExists, "promotion code already exists", "PROMOTION_CODE_TAKEN"},
+		{"unavailable", usecases.ErrWatchLagging, codes.Unavailable, "watch fell behind the status feed", "WATCH_LAGGING"},
+		{"database cause", &domain.Error{Kind: domain.ErrUnavailable, Reason: "DATABASE_UNAVAILABLE", Message: "database unavailable", Err: errors.New("dial tcp 10.0.0.5:5432: connection refused")},
+			codes.Unavailable, "database unavailable", "DATABASE_UNAVAILABLE"},
+		{"unclassified", errors.New(`pq: relation "orders" does not exist`), codes.Internal, "internal error", ""},
+		{"status", status.Error(codes.PermissionDenied, "requires one of the roles admin"), codes.PermissionDenied, "requires one of the roles admin", ""},
+		{"canceled", fmt.Errorf("loading order: %w", context.Canceled), codes.Canceled, "loading order: context canceled", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusError("/order.OrderService/GetOrder", tt.err))
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+			var reason string
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.ErrorInfo); ok {
+					reason = info.Reason
+					assert.Equal(t, errorDomain, info.Domain)
+				}
+			}
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestStatusError_Details(t *testing.T) {
+	st := status.Convert(statusError("/order.OrderService/CreateOrder", invalidArgument("items[0].product_id", "invalid items[0].product_id")))
+	require.Len(t, st.Details(), 2)
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "items[0].product_id", badRequest.FieldViolations[0].Field)
+
+	st = status.Convert(statusError("/order.OrderService/CancelOrder", usecases.ErrOrderNotCancelable))
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 2)
+	precondition, ok := st.Details()[1].(*errdetails.PreconditionFailure)
+	require.True(t, ok)
+	assert.Equal(t, "ORDER_NOT_CANCELABLE", precondition.Violations[0].Type)
+}
+
+func TestUnaryErrorInterceptor(t *testing.T) {
+	interceptor := UnaryErrorInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/GetOrder"}
+
+	resp, err := interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, domain.ErrOrderNotFound
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err = interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
@@ -2,36 +2,79 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc"
 )
 
 type OrderHandler struct {
 	pb.UnimplementedOrderServiceServer
 	createOrderUC *usecases.CreateOrderUseCase
 	getOrderUC    *usecases.GetOrderUseCase
+	listOrdersUC  *usecases.ListOrdersUseCase
+	cancelOrderUC *usecases.CancelOrderUseCase
+	watchUC       *usecases.WatchOrderStatusUseCase
+	searchUC      *usecases.SearchOrdersUseCase
+	sellerUC      *usecases.SellerOrdersUseCase
+	promotionsUC  *usecases.PromotionsUseCase
+	returnsUC     *usecases.ReturnsUseCase
+	amendOrderUC  *usecases.AmendOrderUseCase
+	userDataUC    *usecases.UserDataUseCase
 }
 
-func NewOrderHandler(createUC *usecases.CreateOrderUseCase, getUC *usecases.GetOrderUseCase) *OrderHandler {
+func NewOrderHandler(
+	createUC *usecases.CreateOrderUseCase,
+	getUC *usecases.GetOrderUseCase,
+	listUC *usecases.ListOrdersUseCase,
+	cancelUC *usecases.CancelOrderUseCase,
+	watchUC *usecases.WatchOrderStatusUseCase,
+	searchUC *usecases.SearchOrdersUseCase,
+	sellerUC *usecases.SellerOrdersUseCase,
+	promotionsUC *usecases.PromotionsUseCase,
+	returnsUC *usecases.ReturnsUseCase,
+	amendUC *usecases.AmendOrderUseCase,
+	userDataUC *usecases.UserDataUseCase,
+) *OrderHandler {
 	return &OrderHandler{
 		createOrderUC: createUC,
 		getOrderUC:    getUC,
+		listOrdersUC:  listUC,
+		cancelOrderUC: cancelUC,
+		watchUC:       watchUC,
+		searchUC:      searchUC,
+		sellerUC:      sellerUC,
+		promotionsUC:  promotionsUC,
+		returnsUC:     returnsUC,
+		amendOrderUC:  amendUC,
+		userDataUC:    userDataUC,
 	}
 }
 
+// CreateOrder places an order for the caller; admins may name another user.
 func (h *OrderHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.OrderResponse, error) {
-	userID, err := uuid.Parse(req.UserId)
+	userID, err := userFor(ctx, req.UserId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		return nil, err
+	}
+	if req.ShippingAddress == nil {
+		return nil, invalidArgument("shipping_address", "shipping_address is required")
 	}
 
 	var items []usecases.OrderItemInput
-	for _, item := range req.Items {
-		pID, _ := uuid.Parse(item.ProductId)
+	for i, item := range req.Items {
+		pID, err := uuid.Parse(item.ProductId)
+		if err != nil {
+			field := fmt.Sprintf("items[%d].product_id", i)
+			return nil, invalidArgument(field, "invalid "+field)
+		}
+		// The cart service does not know the sellers of products yet and
+		// sends a placeholder; such lines are stored without a seller.
 		sID, _ := uuid.Parse(item.SellerId)
 		items = append(items, usecases.OrderItemInput{
 			ProductID:   pID,
@@ -39,42 +82,47 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderReque
 			ProductName: item.ProductName,
 			UnitPrice:   item.UnitPrice,
 			Quantity:    int(item.Quantity),
+			Category:    item.Category,
+			WeightKg:    item.WeightKg,
 		})
 	}
 
 	input := usecases.CreateOrderInput{
-		UserID: userID,
-		Items:  items,
-		ShippingAddress: usecases.AddressInput{
-			FullName: req.ShippingAddress.FullName,
-			Phone:    req.ShippingAddress.Phone,
-			City:     req.ShippingAddress.City,
-			Street:   req.ShippingAddress.Street,
-		},
-		TotalAmount: req.TotalAmount,
+		UserID:          userID,
+		Items:           items,
+		ShippingAddress: toAddressInput(req.ShippingAddress),
+		CouponCodes:     req.CouponCodes,
+	}
+	if req.BillingAddress != nil {
+		billing := toAddressInput(req.BillingAddress)
+		input.BillingAddress = &billing
 	}
 
 	order, err := h.createOrderUC.Execute(ctx, input)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	return &pb.OrderResponse{
-		OrderId:   order.ID.String(),
-		Status:    string(order.Status),
-		CreatedAt: order.CreatedAt.String(),
+		OrderId:        order.ID.String(),
+		Status:         string(order.Status),
+		CreatedAt:      order.CreatedAt.String(),
+		TotalAmount:    order.TotalAmount,
+		DiscountAmount: order.DiscountAmount,
+		TaxAmount:      order.TaxAmount,
+		ShippingAmount: order.ShippingAmount,
 	}, nil
 }
 
 func (h *OrderHandler) GetOrderStatus(ctx context.Context, req *pb.GetOrderStatusRequest) (*pb.OrderResponse, error) {
 	orderID, err := uuid.Parse(req.OrderId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid order_id")
+		return nil, invalidArgument("order_id", "invalid order_id")
 	}
 
-	order, err := h.getOrderUC.Execute(ctx, orderID)
+	order, err := h.orderFor(ctx, orderID)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "order not found")
+		return nil, err
 	}
 
 	return &pb.OrderResponse{
@@ -83,3 +131,260 @@ func (h *OrderHandler) GetOrderStatus(ctx context.Context, req *pb.GetOrderStatu
 		CreatedAt: order.CreatedAt.String(),
 	}, nil
 }
+
+func (h *OrderHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+
+	order, err := h.orderFor(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return toPBOrder(order), nil
+}
+
+func (h *OrderHandler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	userID, err := userFor(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if req.PageSize < 0 {
+		return nil, invalidArgument("page_size", "page_size must not be negative")
+	}
+
+	out, err := h.listOrdersUC.Execute(ctx, usecases.ListOrdersInput{
+		UserID:    userID,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListOrdersResponse{NextPageToken: out.NextPageToken}
+	for i := range out.Orders {
+		resp.Orders = append(resp.Orders, toPBOrder(&out.Orders[i]))
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+	if _, err := h.orderFor(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	order, err := h.cancelOrderUC.Execute(ctx, usecases.CancelOrderInput{
+		OrderID: orderID,
+		Actor:   callerActor(ctx),
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toPBOrder(order), nil
+}
+
+// sortFields maps the wire sort fields onto the domain ones.
+var sortFields = map[pb.OrderSortField]domain.OrderSortField{
+	pb.OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED:  domain.SortByCreatedAt,
+	pb.OrderSortField_ORDER_SORT_FIELD_CREATED_AT:   domain.SortByCreatedAt,
+	pb.OrderSortField_ORDER_SORT_FIELD_UPDATED_AT:   domain.SortByUpdatedAt,
+	pb.OrderSortField_ORDER_SORT_FIELD_TOTAL_AMOUNT: domain.SortByTotalAmount,
+}
+
+// SearchOrders is restricted to admins by the server's auth policy.
+func (h *OrderHandler) SearchOrders(ctx context.Context, req *pb.SearchOrdersRequest) (*pb.SearchOrdersResponse, error) {
+	input := usecases.SearchOrdersInput{
+		MinTotal:      req.MinTotal,
+		MaxTotal:      req.MaxTotal,
+		City:          strings.TrimSpace(req.City),
+		PhoneFragment: strings.TrimSpace(req.Phone),
+		Ascending:     req.Ascending,
+		Page:          int(req.Page),
+		PageSize:      int(req.PageSize),
+	}
+	if req.Page < 0 {
+		return nil, invalidArgument("page", "page must not be negative")
+	}
+	if req.PageSize < 0 {
+		return nil, invalidArgument("page_size", "page_size must not be negative")
+	}
+	var err error
+	if input.UserID, err = optionalUUID(req.UserId); err != nil {
+		return nil, invalidArgument("user_id", "invalid user_id")
+	}
+	if input.SellerID, err = optionalUUID(req.SellerId); err != nil {
+		return nil, invalidArgument("seller_id", "invalid seller_id")
+	}
+	if input.ProductID, err = optionalUUID(req.ProductId); err != nil {
+		return nil, invalidArgument("product_id", "invalid product_id")
+	}
+	if input.CreatedFrom, err = optionalTime(req.CreatedFrom); err != nil {
+		return nil, invalidArgument("created_from", "invalid created_from")
+	}
+	if input.CreatedTo, err = optionalTime(req.CreatedTo); err != nil {
+		return nil, invalidArgument("created_to", "invalid created_to")
+	}
+	sortBy, ok := sortFields[req.SortBy]
+	if !ok {
+		return nil, invalidArgument("sort_by", "invalid sort_by")
+	}
+	input.SortBy = sortBy
+	for _, s := range req.Statuses {
+		input.Statuses = append(input.Statuses, domain.OrderStatus(normalizeEnum(s)))
+	}
+
+	out, err := h.searchUC.Execute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchOrdersResponse{
+		TotalCount: out.TotalCount,
+		Page:       int32(out.Page),
+		PageSize:   int32(out.PageSize),
+	}
+	for i := range out.Orders {
+		resp.Orders = append(resp.Orders, toPBOrder(&out.Orders[i]))
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) WatchOrder(req *pb.WatchOrderRequest, stream pb.OrderService_WatchOrderServer) error {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return invalidArgument("order_id", "invalid order_id")
+	}
+	if _, err := h.orderFor(stream.Context(), orderID); err != nil {
+		return err
+	}
+
+	return h.watchUC.WatchOrder(stream.Context(), orderID, req.AfterSequence, sendStatusChange(stream))
+}
+
+func (h *OrderHandler) WatchUserOrders(req *pb.WatchUserOrdersRequest, stream pb.OrderService_WatchUserOrdersServer) error {
+	userID, err := userFor(stream.Context(), req.UserId)
+	if err != nil {
+		return err
+	}
+
+	return h.watchUC.WatchUserOrders(stream.Context(), userID, req.AfterSequence, sendStatusChange(stream))
+}
+
+func sendStatusChange(stream grpc.ServerStreamingServer[pb.OrderStatusEvent]) func(domain.OrderStatusChange) error {
+	return func(c domain.OrderStatusChange) error {
+		return stream.Send(&pb.OrderStatusEvent{
+			OrderId:   c.OrderID.String(),
+			UserId:    c.UserID.String(),
+			Status:    string(c.Status),
+			Sequence:  c.Sequence,
+			ChangedAt: c.ChangedAt.UTC().Format(time.RFC3339Nano),
+		})
+	}
+}
+
+// optionalUUID parses an optional ID field; empty yields uuid.Nil.
+func optionalUUID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(s)
+}
+
+// optionalTime parses an optional RFC 3339 field; empty yields the zero time.
+func optionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func toAddressInput(a *pb.Address) usecases.AddressInput {
+	return usecases.AddressInput{
+		FullName:     a.FullName,
+		Phone:        a.Phone,
+		Country:      a.Country,
+		Region:       a.Region,
+		City:         a.City,
+		Street:       a.Street,
+		AddressLine2: a.AddressLine2,
+		PostalCode:   a.PostalCode,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+	}
+}
+
+func toPBAddress(a *domain.OrderAddress) *pb.Address {
+	if a == nil {
+		return nil
+	}
+	return &pb.Address{
+		FullName:     a.FullName,
+		Phone:        a.Phone,
+		City:         a.City,
+		Street:       a.Street,
+		Country:      a.Country,
+		Region:       a.Region,
+		PostalCode:   a.PostalCode,
+		AddressLine2: a.AddressLine2,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+	}
+}
+
+func toPBOrder(order *domain.Order) *pb.Order {
+	out := &pb.Order{
+		OrderId:          order.ID.String(),
+		UserId:           order.UserID.String(),
+		Status:           string(order.Status),
+		TotalAmount:      order.TotalAmount,
+		Currency:         order.Currency,
+		CreatedAt:        order.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:        order.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		Subtotal:         order.Subtotal,
+		DiscountAmount:   order.DiscountAmount,
+		TaxAmount:        order.TaxAmount,
+		PricesIncludeTax: order.PricesIncludeTax,
+		ShippingAmount:   order.ShippingAmount,
+		ShippingZone:     order.ShippingZone,
+		ShippingAddress:  toPBAddress(order.Address(domain.AddressShipping)),
+		BillingAddress:   toPBAddress(order.Address(domain.AddressBilling)),
+	}
+	for _, d := range order.Discounts {
+		out.Discounts = append(out.Discounts, &pb.OrderDiscount{
+			PromotionId: d.PromotionID.String(),
+			Code:        d.Code,
+			Description: d.Description,
+			Amount:      d.Amount,
+		})
+	}
+	for _, item := range order.Items {
+		out.Items = append(out.Items, &pb.OrderItem{
+			ItemId:        item.ID.String(),
+			ProductId:     item.ProductID.String(),
+			SellerId:      item.SellerID.String(),
+			ProductName:   item.ProductName,
+			UnitPrice:     item.UnitPrice,
+			Quantity:      int32(item.Quantity),
+			Category:      item.Category,
+			TaxableAmount: item.TaxableAmount,
+			TaxRate:       item.TaxRate,
+			TaxAmount:     item.TaxAmount,
+			WeightKg:      item.WeightKg,
+		})
+	}
+	for _, line := range domain.TaxBreakdown(order.Items) {
+		out.TaxBreakdown = append(out.TaxBreakdown, &pb.TaxBreakdown{
+			Rate:          line.Rate,
+			TaxableAmount: line.TaxableAmount,
+			TaxAmount:     line.TaxAmount,
+		})
+	}
+	return out
+}
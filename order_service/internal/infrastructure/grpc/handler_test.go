@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/memory"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/tax"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// freeShipping quotes every order at no charge.
+type freeShipping struct{}
+
+func (freeShipping) QuoteShipping(context.Context, domain.OrderAddress, []domain.OrderItem, float64, string) (*domain.ShippingQuote, error) {
+	return &domain.ShippingQuote{}, nil
+}
+
+// discardEvents publishes nothing.
+type discardEvents struct{ domain.OrderEventProducer }
+
+func (discardEvents) EmitOrderCreated(context.Context, *domain.Order) error { return nil }
+
+// newCreateHandler serves CreateOrder on an in-memory store.
+func newCreateHandler() (*OrderHandler, *memory.Store) {
+	store := memory.NewStore()
+	create := usecases.NewCreateOrderUseCase(store, store, tax.NewCalculator(tax.Rules{}), freeShipping{}, "ET", discardEvents{}, time.Second)
+	return NewOrderHandler(create, usecases.NewGetOrderUseCase(store), nil, nil, nil, nil, nil, nil, nil, nil, nil), store
+}
+
+func TestOrderHandler_CreateOrder_PlaceholderSeller(t *testing.T) {
+	h, store := newCreateHandler()
+	userID := uuid.New()
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: userID.String(), Roles: []string{auth.RoleBuyer}})
+
+	got, err := h.CreateOrder(ctx, &pb.CreateOrderRequest{
+		UserId:          userID.String(),
+		Items:           []*pb.OrderItem{{ProductId: uuid.NewString(), SellerId: "mock-seller-id", ProductName: "Lamp", UnitPrice: 40, Quantity: 1}},
+		ShippingAddress: &pb.Address{FullName: "Abebe Kebede", Phone: "+251911234567", City: "Addis Ababa", Street: "Bole Road"},
+	})
+	require.NoError(t, err)
+
+	order, err := store.GetOrderByID(context.Background(), uuid.MustParse(got.OrderId))
+	require.NoError(t, err)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, uuid.Nil, order.Items[0].SellerID, "stored without a seller")
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+)
+
+func (h *OrderHandler) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+	if _, err := h.orderFor(ctx, orderID); err != nil {
+		return nil, err
+	}
+	history, err := h.getOrderUC.History(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetOrderHistoryResponse{}
+	for i := range history {
+		resp.Entries = append(resp.Entries, toPBHistoryEntry(&history[i]))
+	}
+	return resp, nil
+}
+
+// callerActor attributes a change to the caller's token subject; calls
+// without a token are attributed to an unknown user.
+func callerActor(ctx context.Context) domain.Actor {
+	actor := domain.Actor{Type: domain.ActorUser}
+	if id, ok := auth.FromContext(ctx); ok {
+		actor.ID = id.Subject
+	}
+	return actor
+}
+
+func toPBHistoryEntry(e *domain.OrderStatusHistory) *pb.OrderHistoryEntry {
+	return &pb.OrderHistoryEntry{
+		FromStatus:    string(e.FromStatus),
+		Status:        string(e.Status),
+		ActorType:     string(e.Actor.Type),
+		ActorId:       e.Actor.ID,
+		SourceEventId: e.SourceEventID,
+		Reason:        e.Reason,
+		Metadata:      e.Metadata,
+		Sequence:      e.Sequence,
+		ChangedAt:     e.ChangedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+)
+
+// promotionKinds maps the wire promotion kinds onto the domain ones.
+var promotionKinds = map[pb.PromotionKind]domain.PromotionKind{
+	pb.PromotionKind_PROMOTION_KIND_PERCENTAGE:  domain.PromotionPercentage,
+	pb.PromotionKind_PROMOTION_KIND_FIXED:       domain.PromotionFixed,
+	pb.PromotionKind_PROMOTION_KIND_BUY_X_GET_Y: domain.PromotionBuyXGetY,
+}
+
+// The promotion RPCs are restricted to admins by the server's auth policy.
+
+func (h *OrderHandler) CreatePromotion(ctx context.Context, req *pb.CreatePromotionRequest) (*pb.Promotion, error) {
+	in := req.Promotion
+	if in == nil {
+		return nil, invalidArgument("promotion", "promotion is required")
+	}
+	kind, ok := promotionKinds[in.Kind]
+	if !ok {
+		return nil, invalidArgument("kind", "invalid kind")
+	}
+	promotion := domain.Promotion{
+		Code:           in.Code,
+		Description:    in.Description,
+		Kind:           kind,
+		Value:          in.Value,
+		BuyQuantity:    int(in.BuyQuantity),
+		GetQuantity:    int(in.GetQuantity),
+		MinSubtotal:    in.MinSubtotal,
+		MaxUses:        int(in.MaxUses),
+		MaxUsesPerUser: int(in.MaxUsesPerUser),
+		Stackable:      in.Stackable,
+	}
+	if in.ProductId != "" {
+		productID, err := uuid.Parse(in.ProductId)
+		if err != nil {
+			return nil, invalidArgument("product_id", "invalid product_id")
+		}
+		promotion.ProductID = &productID
+	}
+	var err error
+	if promotion.StartsAt, err = optionalTimePtr(in.StartsAt); err != nil {
+		return nil, invalidArgument("starts_at", "invalid starts_at")
+	}
+	if promotion.EndsAt, err = optionalTimePtr(in.EndsAt); err != nil {
+		return nil, invalidArgument("ends_at", "invalid ends_at")
+	}
+
+	created, err := h.promotionsUC.Create(ctx, promotion)
+	if err != nil {
+		return nil, err
+	}
+	return toPBPromotion(created), nil
+}
+
+func (h *OrderHandler) ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	promotions, err := h.promotionsUC.List(ctx, req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListPromotionsResponse{}
+	for i := range promotions {
+		resp.Promotions = append(resp.Promotions, toPBPromotion(&promotions[i]))
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) SetPromotionActive(ctx context.Context, req *pb.SetPromotionActiveRequest) (*pb.Promotion, error) {
+	id, err := uuid.Parse(req.PromotionId)
+	if err != nil {
+		return nil, invalidArgument("promotion_id", "invalid promotion_id")
+	}
+	promotion, err := h.promotionsUC.SetActive(ctx, id, req.Active)
+	if err != nil {
+		return nil, err
+	}
+	return toPBPromotion(promotion), nil
+}
+
+// optionalTimePtr parses an optional RFC 3339 field; empty yields nil.
+func optionalTimePtr(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func toPBPromotion(p *domain.Promotion) *pb.Promotion {
+	out := &pb.Promotion{
+		PromotionId:    p.ID.String(),
+		Code:           p.Code,
+		Description:    p.Description,
+		Value:          p.Value,
+		BuyQuantity:    int32(p.BuyQuantity),
+		GetQuantity:    int32(p.GetQuantity),
+		MinSubtotal:    p.MinSubtotal,
+		StartsAt:       formatOptionalTime(p.StartsAt),
+		EndsAt:         formatOptionalTime(p.EndsAt),
+		MaxUses:        int32(p.MaxUses),
+		MaxUsesPerUser: int32(p.MaxUsesPerUser),
+		Uses:           int32(p.Uses),
+		Stackable:      p.Stackable,
+		Active:         p.Active,
+		CreatedAt:      p.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	for wire, kind := range promotionKinds {
+		if kind == p.Kind {
+			out.Kind = wire
+		}
+	}
+	if p.ProductID != nil {
+		out.ProductId = p.ProductID.String()
+	}
+	return out
+}
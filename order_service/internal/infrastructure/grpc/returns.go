@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+)
+
+func (h *OrderHandler) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.Return, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+	userID, err := userFor(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	input := usecases.RequestReturnInput{OrderID: orderID, UserID: userID, Reason: req.Reason}
+	for _, item := range req.Items {
+		itemID, err := uuid.Parse(item.ItemId)
+		if err != nil {
+			return nil, invalidArgument("item_id", "invalid item_id")
+		}
+		input.Items = append(input.Items, usecases.ReturnItemInput{OrderItemID: itemID, Quantity: int(item.Quantity)})
+	}
+
+	ret, err := h.returnsUC.Request(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return toPBReturn(ret), nil
+}
+
+func (h *OrderHandler) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.Return, error) {
+	id, err := uuid.Parse(req.ReturnId)
+	if err != nil {
+		return nil, invalidArgument("return_id", "invalid return_id")
+	}
+	who, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := h.returnsUC.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !mayAccess(who, ret.UserID) {
+		return nil, domain.ErrReturnNotFound
+	}
+	return toPBReturn(ret), nil
+}
+
+func (h *OrderHandler) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	if req.PageSize < 0 {
+		return nil, invalidArgument("page_size", "page_size must not be negative")
+	}
+	input := usecases.ListReturnsInput{PageSize: int(req.PageSize)}
+	var err error
+	if input.OrderID, err = optionalUUID(req.OrderId); err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+	// The returns of an order, or else those of the caller or of the user an
+	// admin names.
+	if input.OrderID != uuid.Nil {
+		if _, err := h.orderFor(ctx, input.OrderID); err != nil {
+			return nil, err
+		}
+	}
+	if input.OrderID == uuid.Nil || req.UserId != "" {
+		if input.UserID, err = userFor(ctx, req.UserId); err != nil {
+			return nil, err
+		}
+	}
+	return h.listReturns(ctx, input)
+}
+
+// The return review RPCs are restricted to admins by the server's auth
+// policy.
+
+func (h *OrderHandler) SearchReturns(ctx context.Context, req *pb.SearchReturnsRequest) (*pb.ListReturnsResponse, error) {
+	if req.PageSize < 0 {
+		return nil, invalidArgument("page_size", "page_size must not be negative")
+	}
+	input := usecases.ListReturnsInput{PageSize: int(req.PageSize)}
+	for _, s := range req.Statuses {
+		input.Statuses = append(input.Statuses, domain.ReturnStatus(normalizeEnum(s)))
+	}
+	return h.listReturns(ctx, input)
+}
+
+func (h *OrderHandler) ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.Return, error) {
+	id, err := uuid.Parse(req.ReturnId)
+	if err != nil {
+		return nil, invalidArgument("return_id", "invalid return_id")
+	}
+	ret, err := h.returnsUC.Approve(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPBReturn(ret), nil
+}
+
+func (h *OrderHandler) RejectReturn(ctx context.Context, req *pb.RejectReturnRequest) (*pb.Return, error) {
+	id, err := uuid.Parse(req.ReturnId)
+	if err != nil {
+		return nil, invalidArgument("return_id", "invalid return_id")
+	}
+	ret, err := h.returnsUC.Reject(ctx, id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return toPBReturn(ret), nil
+}
+
+func (h *OrderHandler) listReturns(ctx context.Context, input usecases.ListReturnsInput) (*pb.ListReturnsResponse, error) {
+	returns, err := h.returnsUC.List(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListReturnsResponse{}
+	for i := range returns {
+		resp.Returns = append(resp.Returns, toPBReturn(&returns[i]))
+	}
+	return resp, nil
+}
+
+func toPBReturn(ret *domain.OrderReturn) *pb.Return {
+	out := &pb.Return{
+		ReturnId:     ret.ID.String(),
+		OrderId:      ret.OrderID.String(),
+		UserId:       ret.UserID.String(),
+		Status:       string(ret.Status),
+		Reason:       ret.Reason,
+		RejectReason: ret.RejectReason,
+		RefundAmount: ret.RefundAmount,
+		RequestedAt:  ret.RequestedAt.UTC().Format(time.RFC3339Nano),
+		DecidedAt:    formatOptionalTime(ret.DecidedAt),
+		PickedUpAt:   formatOptionalTime(ret.PickedUpAt),
+		RefundedAt:   formatOptionalTime(ret.RefundedAt),
+	}
+	for _, item := range ret.Items {
+		out.Items = append(out.Items, &pb.ReturnItem{
+			ItemId:       item.OrderItemID.String(),
+			ProductId:    item.ProductID.String(),
+			SellerId:     item.SellerID.String(),
+			Quantity:     int32(item.Quantity),
+			RefundAmount: item.RefundAmount,
+		})
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/auth"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const dayLayout = "2006-01-02"
+
+// The seller RPCs are restricted to sellers and admins by the server's auth
+// policy.
+
+func (h *OrderHandler) ListSellerOrderLines(ctx context.Context, req *pb.ListSellerOrderLinesRequest) (*pb.ListSellerOrderLinesResponse, error) {
+	sellerID, err := sellerFor(ctx, req.SellerId)
+	if err != nil {
+		return nil, err
+	}
+	if req.PageSize < 0 {
+		return nil, invalidArgument("page_size", "page_size must not be negative")
+	}
+
+	input := usecases.ListSellerLinesInput{
+		SellerID:  sellerID,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+	for _, s := range req.OrderStatuses {
+		input.OrderStatuses = append(input.OrderStatuses, domain.OrderStatus(normalizeEnum(s)))
+	}
+	for _, s := range req.FulfillmentStatuses {
+		input.FulfillmentStatuses = append(input.FulfillmentStatuses, domain.FulfillmentStatus(normalizeEnum(s)))
+	}
+
+	out, err := h.sellerUC.ListLines(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListSellerOrderLinesResponse{Lines: toPBSellerLines(out.Lines), NextPageToken: out.NextPageToken}, nil
+}
+
+func (h *OrderHandler) UpdateLineFulfillment(ctx context.Context, req *pb.UpdateLineFulfillmentRequest) (*pb.UpdateLineFulfillmentResponse, error) {
+	sellerID, err := sellerFor(ctx, req.SellerId)
+	if err != nil {
+		return nil, err
+	}
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, invalidArgument("order_id", "invalid order_id")
+	}
+
+	input := usecases.UpdateFulfillmentInput{
+		SellerID: sellerID,
+		OrderID:  orderID,
+		Status:   domain.FulfillmentStatus(normalizeEnum(req.FulfillmentStatus)),
+	}
+	for _, id := range req.ItemIds {
+		itemID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, invalidArgument("item_ids", "invalid item_ids")
+		}
+		input.ItemIDs = append(input.ItemIDs, itemID)
+	}
+
+	lines, err := h.sellerUC.UpdateFulfillment(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateLineFulfillmentResponse{Lines: toPBSellerLines(lines)}, nil
+}
+
+func (h *OrderHandler) GetSellerDailySales(ctx context.Context, req *pb.GetSellerDailySalesRequest) (*pb.GetSellerDailySalesResponse, error) {
+	sellerID, err := sellerFor(ctx, req.SellerId)
+	if err != nil {
+		return nil, err
+	}
+	from, err := time.Parse(dayLayout, req.From)
+	if err != nil {
+		return nil, invalidArgument("from", "invalid from")
+	}
+	to, err := time.Parse(dayLayout, req.To)
+	if err != nil {
+		return nil, invalidArgument("to", "invalid to")
+	}
+
+	sales, err := h.sellerUC.DailySales(ctx, sellerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GetSellerDailySalesResponse{}
+	for _, day := range sales {
+		resp.Days = append(resp.Days, &pb.DailySales{
+			Day:     day.Day,
+			Orders:  day.Orders,
+			Units:   day.Units,
+			Revenue: day.Revenue,
+		})
+	}
+	return resp, nil
+}
+
+// sellerFor resolves the seller a request acts on: the caller, unless an
+// admin names another seller.
+func sellerFor(ctx context.Context, requested string) (uuid.UUID, error) {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+	subject := id.Subject
+	switch {
+	case requested != "" && requested != id.Subject:
+		if !id.HasRole(auth.RoleAdmin) {
+			return uuid.Nil, status.Error(codes.PermissionDenied, "sellers can only access their own orders")
+		}
+		subject = requested
+	case !id.HasRole(auth.RoleSeller):
+		return uuid.Nil, invalidArgument("seller_id", "seller_id is required")
+	}
+	sellerID, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, invalidArgument("seller_id", "invalid seller_id")
+	}
+	return sellerID, nil
+}
+
+func normalizeEnum(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+func toPBSellerLines(lines []domain.SellerOrderLine) []*pb.SellerOrderLine {
+	var out []*pb.SellerOrderLine
+	for _, line := range lines {
+		out = append(out, &pb.SellerOrderLine{
+			OrderId:           line.OrderID.String(),
+			ItemId:            line.ID.String(),
+			ProductId:         line.ProductID.String(),
+			ProductName:       line.ProductName,
+			UnitPrice:         line.UnitPrice,
+			Quantity:          int32(line.Quantity),
+			OrderStatus:       string(line.OrderStatus),
+			FulfillmentStatus: string(line.FulfillmentStatus),
+			OrderedAt:         line.OrderedAt.UTC().Format(time.RFC3339Nano),
+			PackedAt:          formatOptionalTime(line.PackedAt),
+			HandedOverAt:      formatOptionalTime(line.HandedOverAt),
+		})
+	}
+	return out
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
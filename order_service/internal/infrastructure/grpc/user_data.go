@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func (h *OrderHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	userID, err := userFor(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	data, err := h.userDataUC.Export(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// The export is the JSON of the domain types, as in the archive files.
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the export of user %s: %w", userID, err)
+	}
+	doc := &structpb.Struct{}
+	if err := doc.UnmarshalJSON(b); err != nil {
+		return nil, fmt.Errorf("encoding the export of user %s: %w", userID, err)
+	}
+	return &pb.ExportUserDataResponse{Data: doc}, nil
+}
+
+func (h *OrderHandler) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, invalidArgument("user_id", "invalid user_id")
+	}
+	erasure, err := h.userDataUC.Erase(ctx, userID, callerActor(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EraseUserDataResponse{
+		UserId:   erasure.UserID.String(),
+		Orders:   int32(erasure.Orders),
+		ErasedAt: erasure.ErasedAt.Format(time.RFC3339Nano),
+	}, nil
+}
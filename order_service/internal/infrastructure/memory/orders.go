@@ -0,0 +1,399 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateOrder(ctx context.Context, order *domain.Order, items []domain.OrderItem, addresses []domain.OrderAddress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orders[order.ID]; ok {
+		return fmt.Errorf("order %s already exists", order.ID)
+	}
+	// Check every redemption before recording any, so a refused order
+	// leaves nothing behind.
+	for _, d := range order.Discounts {
+		if err := s.checkRedemption(d.PromotionID, order.UserID); err != nil {
+			return err
+		}
+	}
+	for _, d := range order.Discounts {
+		s.promotions[d.PromotionID].Uses++
+		s.redemptions = append(s.redemptions, domain.PromotionRedemption{
+			ID:          uuid.New(),
+			PromotionID: d.PromotionID,
+			UserID:      order.UserID,
+			OrderID:     order.ID,
+			RedeemedAt:  order.CreatedAt,
+		})
+	}
+
+	order.Version = 1
+	stored := cloneOrder(order)
+	stored.Items = slices.Clone(items)
+	for i := range stored.Items {
+		if stored.Items[i].FulfillmentStatus == "" {
+			stored.Items[i].FulfillmentStatus = domain.FulfillmentPending
+		}
+	}
+	stored.Addresses = slices.Clone(addresses)
+	s.orders[order.ID] = stored
+	return nil
+}
+
+func (s *Store) GetOrderByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[id]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	return cloneOrder(order), nil
+}
+
+func (s *Store) ListOrders(ctx context.Context, query domain.OrderListQuery) ([]domain.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var orders []domain.Order
+	for _, o := range s.orders {
+		if o.UserID != query.UserID {
+			continue
+		}
+		if after := query.After; after != nil && !newerFirst(after.CreatedAt, after.ID, o.CreatedAt, o.ID) {
+			continue
+		}
+		out := cloneOrder(o)
+		out.Addresses = nil
+		orders = append(orders, *out)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return newerFirst(orders[i].CreatedAt, orders[i].ID, orders[j].CreatedAt, orders[j].ID)
+	})
+	return limit(orders, query.Limit), nil
+}
+
+// newerFirst reports whether (at, id) comes before (otherAt, otherID) in the
+// newest-first listings.
+func newerFirst(at time.Time, id uuid.UUID, otherAt time.Time, otherID uuid.UUID) bool {
+	if !at.Equal(otherAt) {
+		return at.After(otherAt)
+	}
+	return idLess(otherID, id)
+}
+
+func (s *Store) ChangeStatus(ctx context.Context, entry *domain.OrderStatusHistory, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[entry.OrderID]
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	if order.Version != version {
+		return &domain.VersionConflictError{OrderID: entry.OrderID, Version: version}
+	}
+	order.Status, order.UpdatedAt = entry.Status, entry.ChangedAt
+	order.Version++
+	s.sequence++
+	entry.Sequence = s.sequence
+	s.history = append(s.history, cloneHistory(*entry))
+	return nil
+}
+
+func (s *Store) ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]domain.OrderStatusHistory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var history []domain.OrderStatusHistory
+	for _, h := range s.history {
+		if h.OrderID == orderID {
+			history = append(history, cloneHistory(h))
+		}
+	}
+	return history, nil
+}
+
+func (s *Store) ListStatusChanges(ctx context.Context, query domain.StatusChangeQuery) ([]domain.OrderStatusChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes []domain.OrderStatusChange
+	for _, h := range s.history {
+		order, ok := s.orders[h.OrderID]
+		switch {
+		case !ok, h.Sequence <= query.AfterSequence:
+			continue
+		case query.OrderID != uuid.Nil && h.OrderID != query.OrderID:
+			continue
+		case query.UserID != uuid.Nil && order.UserID != query.UserID:
+			continue
+		}
+		changes = append(changes, domain.OrderStatusChange{
+			OrderID:   h.OrderID,
+			UserID:    order.UserID,
+			Status:    h.Status,
+			Sequence:  h.Sequence,
+			ChangedAt: h.ChangedAt,
+		})
+	}
+	return limit(changes, query.Limit), nil
+}
+
+func (s *Store) SearchOrders(ctx context.Context, query domain.OrderSearchQuery) ([]domain.Order, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var orders []domain.Order
+	for _, o := range s.orders {
+		if matchesSearch(o, query) {
+			out := cloneOrder(o)
+			out.Addresses = nil
+			orders = append(orders, *out)
+		}
+	}
+	total := int64(len(orders))
+
+	key := func(o *domain.Order) any {
+		switch query.SortBy {
+		case domain.SortByUpdatedAt:
+			return o.UpdatedAt
+		case domain.SortByTotalAmount:
+			return o.TotalAmount
+		default:
+			return o.CreatedAt
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		a, b := &orders[i], &orders[j]
+		if query.Descending {
+			a, b = b, a
+		}
+		less, equal := compare(key(a), key(b))
+		if equal {
+			return idLess(a.ID, b.ID)
+		}
+		return less
+	})
+
+	if query.Offset >= len(orders) {
+		return nil, total, nil
+	}
+	return limit(orders[query.Offset:], query.Limit), total, nil
+}
+
+// compare orders two sort keys of the same type.
+func compare(a, b any) (less, equal bool) {
+	switch a := a.(type) {
+	case time.Time:
+		b := b.(time.Time)
+		return a.Before(b), a.Equal(b)
+	case float64:
+		b := b.(float64)
+		return a < b, a == b
+	}
+	return false, true
+}
+
+// matchesSearch applies the filters of a search to an order; the item and
+// address filters match any of its items and its shipping address.
+func matchesSearch(o *domain.Order, query domain.OrderSearchQuery) bool {
+	hasItem := func(match func(domain.OrderItem) bool) bool {
+		return slices.ContainsFunc(o.Items, match)
+	}
+	shipping := o.Address(domain.AddressShipping)
+	switch {
+	case query.UserID != uuid.Nil && o.UserID != query.UserID:
+		return false
+	case query.SellerID != uuid.Nil && !hasItem(func(i domain.OrderItem) bool { return i.SellerID == query.SellerID }):
+		return false
+	case query.ProductID != uuid.Nil && !hasItem(func(i domain.OrderItem) bool { return i.ProductID == query.ProductID }):
+		return false
+	case len(query.Statuses) > 0 && !slices.Contains(query.Statuses, o.Status):
+		return false
+	case query.MinTotal != nil && o.TotalAmount < *query.MinTotal:
+		return false
+	case query.MaxTotal != nil && o.TotalAmount > *query.MaxTotal:
+		return false
+	case !query.CreatedFrom.IsZero() && o.CreatedAt.Before(query.CreatedFrom):
+		return false
+	case !query.CreatedTo.IsZero() && !o.CreatedAt.Before(query.CreatedTo):
+		return false
+	case query.City != "" && (shipping == nil || !strings.EqualFold(shipping.City, query.City)):
+		return false
+	case query.PhoneFragment != "" && (shipping == nil || !strings.Contains(shipping.Phone, query.PhoneFragment)):
+		return false
+	}
+	return true
+}
+
+func (s *Store) ListSellerLines(ctx context.Context, query domain.SellerLineQuery) ([]domain.SellerOrderLine, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var lines []domain.SellerOrderLine
+	for _, o := range s.orders {
+		if len(query.OrderStatuses) > 0 && !slices.Contains(query.OrderStatuses, o.Status) {
+			continue
+		}
+		for _, item := range o.Items {
+			switch {
+			case item.SellerID != query.SellerID:
+				continue
+			case len(query.FulfillmentStatuses) > 0 && !slices.Contains(query.FulfillmentStatuses, item.FulfillmentStatus):
+				continue
+			case query.After != nil && !newerFirst(query.After.CreatedAt, query.After.ID, o.CreatedAt, item.ID):
+				continue
+			}
+			lines = append(lines, domain.SellerOrderLine{OrderItem: item, OrderStatus: o.Status, OrderedAt: o.CreatedAt})
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return newerFirst(lines[i].OrderedAt, lines[i].ID, lines[j].OrderedAt, lines[j].ID)
+	})
+	return limit(lines, query.Limit), nil
+}
+
+func (s *Store) UpdateLineFulfillment(ctx context.Context, orderID uuid.UUID, itemIDs []uuid.UUID, status domain.FulfillmentStatus, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from := domain.FulfillmentBefore(status)
+	if o, ok := s.orders[orderID]; ok {
+		for i := range o.Items {
+			item := &o.Items[i]
+			if !slices.Contains(itemIDs, item.ID) || !slices.Contains(from, item.FulfillmentStatus) {
+				continue
+			}
+			item.FulfillmentStatus = status
+			switch status {
+			case domain.FulfillmentPacked:
+				item.PackedAt = &at
+			case domain.FulfillmentHandedOver:
+				if item.PackedAt == nil {
+					item.PackedAt = &at
+				}
+				item.HandedOverAt = &at
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) SellerDailySales(ctx context.Context, query domain.SellerSalesQuery) ([]domain.SellerDailySales, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	days := map[string]*domain.SellerDailySales{}
+	for _, o := range s.orders {
+		if !slices.Contains(domain.SalesStatuses, o.Status) || o.CreatedAt.Before(query.From) || !o.CreatedAt.Before(query.To) {
+			continue
+		}
+		counted := false
+		for _, item := range o.Items {
+			if item.SellerID != query.SellerID {
+				continue
+			}
+			day := o.CreatedAt.UTC().Format(time.DateOnly)
+			sales, ok := days[day]
+			if !ok {
+				sales = &domain.SellerDailySales{Day: day}
+				days[day] = sales
+			}
+			if !counted {
+				sales.Orders++
+				counted = true
+			}
+			sales.Units += int64(item.Quantity)
+			sales.Revenue += item.UnitPrice * float64(item.Quantity)
+		}
+	}
+
+	var out []domain.SellerDailySales
+	for _, sales := range days {
+		out = append(out, *sales)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Day < out[j].Day })
+	return out, nil
+}
+
+func (s *Store) AmendOrder(ctx context.Context, order *domain.Order, removed []uuid.UUID, amendment *domain.OrderAmendment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.orders[order.ID]
+	switch {
+	case !ok:
+		return domain.ErrOrderNotFound
+	case !stored.Amendable():
+		return domain.ErrOrderNotAmendable
+	case stored.Version != order.Version:
+		return &domain.VersionConflictError{OrderID: order.ID, Version: order.Version}
+	}
+
+	stored.Subtotal = order.Subtotal
+	stored.DiscountAmount = order.DiscountAmount
+	stored.TaxAmount = order.TaxAmount
+	stored.PricesIncludeTax = order.PricesIncludeTax
+	stored.ShippingAmount = order.ShippingAmount
+	stored.ShippingZone = order.ShippingZone
+	stored.TotalAmount = order.TotalAmount
+	stored.UpdatedAt = order.UpdatedAt
+	stored.Version++
+
+	stored.Items = slices.DeleteFunc(stored.Items, func(item domain.OrderItem) bool {
+		return slices.Contains(removed, item.ID)
+	})
+	for _, item := range order.Items {
+		stored.Items = upsert(stored.Items, item, func(i domain.OrderItem) bool { return i.ID == item.ID })
+	}
+	for _, address := range order.Addresses {
+		stored.Addresses = upsert(stored.Addresses, address, func(a domain.OrderAddress) bool { return a.ID == address.ID })
+	}
+	stored.Discounts = slices.Clone(order.Discounts)
+	recorded := *amendment
+	recorded.Changes = slices.Clone(amendment.Changes)
+	s.amendments = append(s.amendments, recorded)
+
+	order.Version++
+	return nil
+}
+
+// upsert replaces the element of s matching v, or appends v.
+func upsert[T any](s []T, v T, match func(T) bool) []T {
+	if i := slices.IndexFunc(s, match); i >= 0 {
+		s[i] = v
+		return s
+	}
+	return append(s, v)
+}
+
+func (s *Store) ListAmendments(ctx context.Context, orderID uuid.UUID) ([]domain.OrderAmendment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var amendments []domain.OrderAmendment
+	for _, a := range s.amendments {
+		if a.OrderID == orderID {
+			amendments = append(amendments, a)
+		}
+	}
+	sort.SliceStable(amendments, func(i, j int) bool {
+		a, b := amendments[i], amendments[j]
+		if !a.AmendedAt.Equal(b.AmendedAt) {
+			return a.AmendedAt.Before(b.AmendedAt)
+		}
+		return idLess(a.ID, b.ID)
+	})
+	return amendments, nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreatePromotion(ctx context.Context, promotion *domain.Promotion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.promotions {
+		if promotion.Code != "" && p.Code == promotion.Code {
+			return domain.ErrPromotionCodeTaken
+		}
+	}
+	stored := *promotion
+	s.promotions[promotion.ID] = &stored
+	return nil
+}
+
+func (s *Store) GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.promotions[id]
+	if !ok {
+		return nil, domain.ErrPromotionNotFound
+	}
+	out := *p
+	return &out, nil
+}
+
+func (s *Store) ListPromotions(ctx context.Context, includeInactive bool) ([]domain.Promotion, error) {
+	promotions := s.selectPromotions(func(p *domain.Promotion) bool { return includeInactive || p.Active })
+	sort.Slice(promotions, func(i, j int) bool {
+		a, b := promotions[i], promotions[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return idLess(a.ID, b.ID)
+	})
+	return promotions, nil
+}
+
+func (s *Store) SetPromotionActive(ctx context.Context, id uuid.UUID, active bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.promotions[id]
+	if !ok {
+		return domain.ErrPromotionNotFound
+	}
+	p.Active = active
+	return nil
+}
+
+func (s *Store) ApplicablePromotions(ctx context.Context, codes []string, at time.Time) ([]domain.Promotion, error) {
+	promotions := s.selectPromotions(func(p *domain.Promotion) bool {
+		return p.Active &&
+			(p.StartsAt == nil || !p.StartsAt.After(at)) &&
+			(p.EndsAt == nil || p.EndsAt.After(at)) &&
+			(p.Code == "" || slices.Contains(codes, p.Code))
+	})
+	sort.Slice(promotions, func(i, j int) bool {
+		a, b := promotions[i], promotions[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return idLess(a.ID, b.ID)
+	})
+	return promotions, nil
+}
+
+func (s *Store) CountRedemptions(ctx context.Context, userID uuid.UUID, promotionIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := map[uuid.UUID]int{}
+	for _, r := range s.redemptions {
+		if r.UserID == userID && slices.Contains(promotionIDs, r.PromotionID) {
+			counts[r.PromotionID]++
+		}
+	}
+	return counts, nil
+}
+
+func (s *Store) selectPromotions(match func(*domain.Promotion) bool) []domain.Promotion {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var promotions []domain.Promotion
+	for _, p := range s.promotions {
+		if match(p) {
+			promotions = append(promotions, *p)
+		}
+	}
+	return promotions
+}
+
+// checkRedemption fails with ErrPromotionExhausted when the promotion is
+// unknown or reached its usage limit, overall or for the user.
+func (s *Store) checkRedemption(promotionID, userID uuid.UUID) error {
+	p, ok := s.promotions[promotionID]
+	if !ok || p.MaxUses > 0 && p.Uses >= p.MaxUses {
+		return domain.ErrPromotionExhausted
+	}
+	if p.MaxUsesPerUser > 0 {
+		used := 0
+		for _, r := range s.redemptions {
+			if r.PromotionID == promotionID && r.UserID == userID {
+				used++
+			}
+		}
+		if used >= p.MaxUsesPerUser {
+			return domain.ErrPromotionExhausted
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package memory keeps orders and promotions in process memory. It backs
+// fast unit tests and passes the same conformance suite as the database
+// repositories; nothing survives a restart.
+package memory
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Store implements domain.OrderRepository and domain.PromotionRepository.
+// Promotions live with the orders because placing an order redeems them.
+// Every method holds the store's lock, so each call is atomic and isolated
+// like a database transaction; values are copied in and out.
+type Store struct {
+	mu          sync.Mutex
+	orders      map[uuid.UUID]*domain.Order
+	history     []domain.OrderStatusHistory
+	sequence    int64
+	amendments  []domain.OrderAmendment
+	promotions  map[uuid.UUID]*domain.Promotion
+	redemptions []domain.PromotionRedemption
+}
+
+func NewStore() *Store {
+	return &Store{
+		orders:     map[uuid.UUID]*domain.Order{},
+		promotions: map[uuid.UUID]*domain.Promotion{},
+	}
+}
+
+// cloneOrder copies the order with its items, discounts and addresses.
+func cloneOrder(o *domain.Order) *domain.Order {
+	out := *o
+	out.Items = slices.Clone(o.Items)
+	out.Discounts = slices.Clone(o.Discounts)
+	out.Addresses = slices.Clone(o.Addresses)
+	return &out
+}
+
+func cloneHistory(h domain.OrderStatusHistory) domain.OrderStatusHistory {
+	h.Metadata = maps.Clone(h.Metadata)
+	return h
+}
+
+// idLess orders IDs like the database does, byte by byte.
+func idLess(a, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
+
+// limit truncates s to n elements; n <= 0 leaves it whole.
+func limit[T any](s []T, n int) []T {
+	if n > 0 && len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+var (
+	_ domain.OrderRepository     = (*Store)(nil)
+	_ domain.PromotionRepository = (*Store)(nil)
+)
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.TestOrderRepository(t, func(t *testing.T) repotest.Repositories {
+		store := NewStore()
+		return repotest.Repositories{Orders: store, Promotions: store}
+	})
+}
@@ -2,148 +2,177 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
-	"time"
+	"strconv"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/events"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/rabbitmq"
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+const consumerQueue = "order_service_events_queue"
+
 type RabbitMQConsumer struct {
-	conn         *amqp.Connection
-	channel      *amqp.Channel
+	conn         *rabbitmq.Manager
+	prefetch     int
+	retry        rabbitmq.Retry
 	updateStatus *usecases.UpdateOrderStatusUseCase
+	returns      *usecases.ReturnsUseCase
+	userData     *usecases.UserDataUseCase
 }
 
-func NewRabbitMQConsumer(url string, updateStatus *usecases.UpdateOrderStatusUseCase) (*RabbitMQConsumer, error) {
-	var conn *amqp.Connection
-	var err error
-
-	// Retry logic for RabbitMQ connection
-	maxRetries := 10
-	for i := 1; i <= maxRetries; i++ {
-		conn, err = amqp.Dial(url)
-		if err == nil {
-			break
-		}
-		log.Printf("Failed to connect to RabbitMQ (attempt %d/%d): %v. Retrying in 5 seconds...", i, maxRetries, err)
-		time.Sleep(5 * time.Second)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	ch, err := conn.Channel()
-	if err != nil {
-		conn.Close()
-		return nil, err
-	}
-
-	err = ch.ExchangeDeclare(
-		"order_events", // name
-		"topic",        // type
-		true,           // durable
-		false,          // auto-deleted
-		false,          // internal
-		false,          // no-wait
-		nil,            // arguments
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, err
-	}
-
+func NewRabbitMQConsumer(conn *rabbitmq.Manager, prefetch int, retry rabbitmq.Retry, updateStatus *usecases.UpdateOrderStatusUseCase, returns *usecases.ReturnsUseCase, userData *usecases.UserDataUseCase) *RabbitMQConsumer {
 	return &RabbitMQConsumer{
 		conn:         conn,
-		channel:      ch,
+		prefetch:     prefetch,
+		retry:        retry,
 		updateStatus: updateStatus,
-	}, nil
-}
-
-func (c *RabbitMQConsumer) Start(ctx context.Context) error {
-	q, err := c.channel.QueueDeclare(
-		"order_service_events_queue", // name
-		true,                         // durable
-		false,                        // delete when unused
-		false,                        // exclusive
-		false,                        // no-wait
-		nil,                          // arguments
-	)
-	if err != nil {
-		return err
+		returns:      returns,
+		userData:     userData,
 	}
+}
 
-	topics := []string{"payment.succeeded", "order.delivered"}
-	for _, topic := range topics {
-		err = c.channel.QueueBind(
-			q.Name,         // queue name
-			topic,          // routing key
-			"order_events", // exchange
-			false,
-			nil,
+// Register declares the consumer's queue and bindings and subscribes to it.
+// It must be called before the connection manager is started; the manager
+// restores the subscription after every reconnection. Events failing with a
+// transient error are retried and then dead-lettered according to retry.
+func (c *RabbitMQConsumer) Register() {
+	c.conn.DeclareTopology(declareExchange)
+	c.conn.DeclareTopology(func(ch *amqp.Channel) error {
+		q, err := ch.QueueDeclare(
+			consumerQueue, // name
+			true,          // durable
+			false,         // delete when unused
+			false,         // exclusive
+			false,         // no-wait
+			nil,           // arguments
 		)
 		if err != nil {
 			return err
 		}
-	}
-
-	msgs, err := c.channel.Consume(
-		q.Name, // queue
-		"",     // consumer
-		true,   // auto-ack
-		false,  // exclusive
-		false,  // no-local
-		false,  // no-wait
-		nil,    // args
-	)
-	if err != nil {
-		return err
-	}
 
-	go func() {
-		for d := range msgs {
-			log.Printf("Received a message: %s", d.RoutingKey)
+		topics := []string{events.TypePaymentSucceeded, events.TypeOrderDelivered, events.TypeReturnPickedUp, events.TypePaymentRefunded, events.TypeUserDeleted}
+		for _, topic := range topics {
+			err = ch.QueueBind(
+				q.Name,         // queue name
+				topic,          // routing key
+				ordersExchange, // exchange
+				false,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	c.conn.ConsumeWithRetry(consumerQueue, c.prefetch, c.retry, c.handle)
+	log.Printf("Order Service consumer registered")
+}
 
-			switch d.RoutingKey {
-			case "payment.succeeded":
-				var event struct {
-					OrderID string `json:"order_id"`
-				}
-				if err := json.Unmarshal(d.Body, &event); err != nil {
-					log.Printf("Error unmarshaling payment.succeeded: %v", err)
-					continue
-				}
-				orderID, _ := uuid.Parse(event.OrderID)
-				_ = c.updateStatus.Execute(ctx, orderID, domain.StatusPaid)
+func (c *RabbitMQConsumer) handle(ctx context.Context, d amqp.Delivery) error {
+	log.Printf("Received a message: %s", d.RoutingKey)
 
-			case "order.delivered":
-				var event struct {
-					OrderID string `json:"order_id"`
-				}
-				if err := json.Unmarshal(d.Body, &event); err != nil {
-					log.Printf("Error unmarshaling order.delivered: %v", err)
-					continue
-				}
-				orderID, _ := uuid.Parse(event.OrderID)
-				_ = c.updateStatus.Execute(ctx, orderID, domain.StatusDelivered)
-			}
+	var (
+		update  usecases.StatusUpdateInput
+		orderID string
+	)
+	switch d.RoutingKey {
+	case events.TypePaymentSucceeded:
+		var event events.PaymentSucceeded
+		env, err := rabbitmq.DecodeEvent(d, &event)
+		if err != nil {
+			return rabbitmq.Permanent(err)
+		}
+		orderID = event.OrderID
+		update = usecases.StatusUpdateInput{
+			Status:  domain.StatusPaid,
+			Reason:  "payment succeeded",
+			Forward: true,
+			Metadata: map[string]string{
+				"payment_id": event.PaymentID,
+				"amount":     strconv.FormatFloat(event.Amount, 'f', -1, 64),
+			},
+			Payment: &usecases.PaymentInput{ID: event.PaymentID, Amount: event.Amount},
 		}
-	}()
+		update.Actor, update.SourceEventID = sourceOf(env)
+	case events.TypeOrderDelivered:
+		var event events.OrderDelivered
+		env, err := rabbitmq.DecodeEvent(d, &event)
+		if err != nil {
+			return rabbitmq.Permanent(err)
+		}
+		orderID = event.OrderID
+		update = usecases.StatusUpdateInput{Status: domain.StatusDelivered, Reason: "order delivered", Forward: true}
+		update.Actor, update.SourceEventID = sourceOf(env)
+	case events.TypeReturnPickedUp:
+		var event events.ReturnPickedUp
+		if _, err := rabbitmq.DecodeEvent(d, &event); err != nil {
+			return rabbitmq.Permanent(err)
+		}
+		id, err := uuid.Parse(event.ReturnID)
+		if err != nil {
+			return rabbitmq.Permanent(fmt.Errorf("invalid return_id in %s: %w", d.RoutingKey, err))
+		}
+		return eventError(c.returns.MarkPickedUp(ctx, id))
+	case events.TypePaymentRefunded:
+		var event events.PaymentRefunded
+		if _, err := rabbitmq.DecodeEvent(d, &event); err != nil {
+			return rabbitmq.Permanent(err)
+		}
+		// Refunds of returns carry the return ID; other refunds are not
+		// tracked here.
+		id, err := uuid.Parse(event.RefundID)
+		if err != nil {
+			return nil
+		}
+		err = c.returns.MarkRefunded(ctx, id)
+		if errors.Is(err, domain.ErrReturnNotFound) {
+			return nil
+		}
+		return eventError(err)
+	case events.TypeUserDeleted:
+		var event events.UserDeleted
+		env, err := rabbitmq.DecodeEvent(d, &event)
+		if err != nil {
+			return rabbitmq.Permanent(err)
+		}
+		id, err := uuid.Parse(event.UserID)
+		if err != nil {
+			return rabbitmq.Permanent(fmt.Errorf("invalid user_id in %s: %w", d.RoutingKey, err))
+		}
+		actor, _ := sourceOf(env)
+		_, err = c.userData.Erase(ctx, id, actor)
+		return eventError(err)
+	default:
+		return rabbitmq.Permanent(fmt.Errorf("unexpected routing key %q", d.RoutingKey))
+	}
+
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return rabbitmq.Permanent(fmt.Errorf("invalid order_id in %s: %w", d.RoutingKey, err))
+	}
+	update.OrderID = id
+	return eventError(c.updateStatus.Execute(ctx, update))
+}
 
-	log.Printf("Order Service consumer started")
-	return nil
+// sourceOf attributes a status change to the service that published the
+// triggering event.
+func sourceOf(env events.Envelope) (domain.Actor, string) {
+	return domain.Actor{Type: domain.ActorService, ID: env.Producer}, env.EventID
 }
 
-func (c *RabbitMQConsumer) Close() {
-	if c.channel != nil {
-		c.channel.Close()
-	}
-	if c.conn != nil {
-		c.conn.Close()
+// eventError dead-letters events that retrying cannot make succeed: those
+// for missing orders or returns, invalid ones, and those for orders or
+// returns not in a status the event applies to.
+func eventError(err error) error {
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidArgument) || errors.Is(err, domain.ErrPreconditionFailed) {
+		return rabbitmq.Permanent(err)
 	}
+	return err
 }
@@ -0,0 +1,38 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/application/usecases"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/rabbitmq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"missing order", domain.ErrOrderNotFound, true},
+		{"missing return", domain.ErrReturnNotFound, true},
+		{"return in another status", domain.ErrReturnConflict, true},
+		{"invalid", fmt.Errorf("%w: quantity must be positive", usecases.ErrInvalidAmendment), true},
+		{"not returnable", usecases.ErrOrderNotReturnable, true},
+		{"concurrent update", domain.ErrVersionConflict, false},
+		{"database down", errors.New("dial tcp: connection refused"), false},
+		{"canceled", context.Canceled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := eventError(tt.err)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.permanent, rabbitmq.IsPermanent(err))
+		})
+	}
+	assert.NoError(t, eventError(nil))
+}
@@ -2,129 +2,199 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
-	"log"
 	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
-	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/events"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/rabbitmq"
 )
 
+const producerName = "order-service"
+
+// RabbitMQProducer publishes order events with publisher confirms and
+// mandatory routing; an event that the broker drops or cannot route is
+// reported as an error.
 type RabbitMQProducer struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
+	publisher *rabbitmq.Publisher
 }
 
-func NewRabbitMQProducer(url string) (*RabbitMQProducer, error) {
-	var conn *amqp.Connection
-	var err error
+func NewRabbitMQProducer(conn *rabbitmq.Manager, confirmTimeout time.Duration, format events.Format) *RabbitMQProducer {
+	conn.DeclareTopology(declareExchange)
+	return &RabbitMQProducer{publisher: rabbitmq.NewPublisher(conn, confirmTimeout, format)}
+}
 
-	// Retry logic for RabbitMQ connection
-	maxRetries := 10
-	for i := 1; i <= maxRetries; i++ {
-		conn, err = amqp.Dial(url)
-		if err == nil {
-			break
-		}
-		log.Printf("Failed to connect to RabbitMQ (attempt %d/%d): %v. Retrying in 5 seconds...", i, maxRetries, err)
-		time.Sleep(5 * time.Second)
-	}
+func (p *RabbitMQProducer) Close() {
+	p.publisher.Close()
+}
 
-	if err != nil {
-		return nil, err
+func (p *RabbitMQProducer) EmitOrderCreated(ctx context.Context, order *domain.Order) error {
+	event := events.OrderCreated{
+		OrderID:          order.ID.String(),
+		UserID:           order.UserID.String(),
+		TotalAmount:      order.TotalAmount,
+		Currency:         order.Currency,
+		Items:            eventItems(order.Items),
+		Subtotal:         order.Subtotal,
+		DiscountAmount:   order.DiscountAmount,
+		TaxAmount:        order.TaxAmount,
+		PricesIncludeTax: order.PricesIncludeTax,
+		ShippingAmount:   order.ShippingAmount,
 	}
-
-	ch, err := conn.Channel()
-	if err != nil {
-		conn.Close()
-		return nil, err
+	for _, d := range order.Discounts {
+		event.Discounts = append(event.Discounts, events.Discount{
+			PromotionID: d.PromotionID.String(),
+			Code:        d.Code,
+			Description: d.Description,
+			Amount:      d.Amount,
+		})
 	}
-	err = ch.ExchangeDeclare(
-		"order_events", // name
-		"topic",        // type
-		true,           // durable
-		false,          // auto-deleted
-		false,          // internal
-		false,          // no-wait
-		nil,            // arguments
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, err
+	for _, line := range domain.TaxBreakdown(order.Items) {
+		event.TaxBreakdown = append(event.TaxBreakdown, events.TaxBreakdown{
+			Rate:          line.Rate,
+			TaxableAmount: line.TaxableAmount,
+			TaxAmount:     line.TaxAmount,
+		})
 	}
-	return &RabbitMQProducer{conn: conn, channel: ch}, nil
-}
 
-type OrderCreatedEvent struct {
-	OrderID     string      `json:"order_id"`
-	UserID      string      `json:"user_id"`
-	TotalAmount float64     `json:"total_amount"`
-	Currency    string      `json:"currency"`
-	Items       interface{} `json:"items"`
-	Timestamp   time.Time   `json:"timestamp"`
+	return p.emit(ctx, order, event, events.WithOccurredAt(order.CreatedAt))
 }
 
-type OrderPaidEvent struct {
-	OrderID   string    `json:"order_id"`
-	Amount    float64   `json:"amount"`
-	Timestamp time.Time `json:"timestamp"`
+// EmitOrderAmended publishes the order as priced after an amendment, so the
+// payment service authorizes the new amount.
+func (p *RabbitMQProducer) EmitOrderAmended(ctx context.Context, order *domain.Order, amendment *domain.OrderAmendment) error {
+	event := events.OrderAmended{
+		OrderID:          order.ID.String(),
+		UserID:           order.UserID.String(),
+		AmendmentID:      amendment.ID.String(),
+		PreviousAmount:   amendment.PreviousTotal,
+		TotalAmount:      order.TotalAmount,
+		Currency:         order.Currency,
+		Items:            eventItems(order.Items),
+		Subtotal:         order.Subtotal,
+		DiscountAmount:   order.DiscountAmount,
+		TaxAmount:        order.TaxAmount,
+		PricesIncludeTax: order.PricesIncludeTax,
+		ShippingAmount:   order.ShippingAmount,
+	}
+
+	return p.emit(ctx, order, event, events.WithOccurredAt(amendment.AmendedAt))
 }
 
-func (p *RabbitMQProducer) EmitOrderCreated(ctx context.Context, order *domain.Order) error {
-	event := OrderCreatedEvent{
-		OrderID:     order.ID.String(),
-		UserID:      order.UserID.String(),
-		TotalAmount: order.TotalAmount,
-		Currency:    order.Currency,
-		Items:       order.Items,
-		Timestamp:   order.CreatedAt,
+func eventItems(orderItems []domain.OrderItem) []events.OrderItem {
+	items := make([]events.OrderItem, 0, len(orderItems))
+	for _, item := range orderItems {
+		items = append(items, events.OrderItem{
+			ProductID:   item.ProductID.String(),
+			SellerID:    item.SellerID.String(),
+			ProductName: item.ProductName,
+			UnitPrice:   item.UnitPrice,
+			Quantity:    item.Quantity,
+			Category:    item.Category,
+			TaxRate:     item.TaxRate,
+			TaxAmount:   item.TaxAmount,
+		})
 	}
+	return items
+}
 
-	body, err := json.Marshal(event)
-	if err != nil {
-		return err
+func (p *RabbitMQProducer) EmitOrderPaid(ctx context.Context, order *domain.Order) error {
+	event := events.OrderPaid{
+		OrderID:  order.ID.String(),
+		Amount:   order.TotalAmount,
+		Currency: order.Currency,
 	}
 
-	return p.channel.PublishWithContext(ctx,
-		"order_events",  // exchange
-		"order.created", // routing key
-		false,           // mandatory
-		false,           // immediate
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
+	return p.emit(ctx, order, event)
+}
+
+func (p *RabbitMQProducer) EmitReturnApproved(ctx context.Context, order *domain.Order, ret *domain.OrderReturn) error {
+	event := events.ReturnApproved{
+		ReturnID: ret.ID.String(),
+		OrderID:  order.ID.String(),
+	}
+	for _, item := range ret.Items {
+		event.Items = append(event.Items, events.ReturnedItem{
+			ProductID: item.ProductID.String(),
+			SellerID:  item.SellerID.String(),
+			Quantity:  item.Quantity,
 		})
+	}
+	if a := order.Address(domain.AddressShipping); a != nil {
+		event.PickupAddress = events.PickupAddress{
+			FullName:     a.FullName,
+			Phone:        a.Phone,
+			Country:      a.Country,
+			Region:       a.Region,
+			City:         a.City,
+			Street:       a.Street,
+			AddressLine2: a.AddressLine2,
+			PostalCode:   a.PostalCode,
+		}
+	}
+
+	return p.emit(ctx, order, event)
 }
 
-func (p *RabbitMQProducer) Close() {
-	if p.channel != nil {
-		p.channel.Close()
+// EmitPaymentRefundRequested asks for the refund of a whole payment. The
+// payment ID is the refund ID, so a payment is refunded once however often
+// it is reported; without items, every line is refunded.
+func (p *RabbitMQProducer) EmitPaymentRefundRequested(ctx context.Context, order *domain.Order, paymentID string, amount float64) error {
+	event := events.RefundRequested{
+		RefundID: paymentID,
+		OrderID:  order.ID.String(),
+		Amount:   amount,
+		Currency: order.Currency,
+		Reason:   "payment received after the order ended",
+	}
+	return p.emit(ctx, order, event)
+}
+
+// EmitRefundRequested asks for the refund of a return. The return ID is the
+// refund ID, so the payment service refunds each return once.
+func (p *RabbitMQProducer) EmitRefundRequested(ctx context.Context, order *domain.Order, ret *domain.OrderReturn) error {
+	event := events.RefundRequested{
+		RefundID: ret.ID.String(),
+		OrderID:  order.ID.String(),
+		Amount:   ret.RefundAmount,
+		Currency: order.Currency,
+		Reason:   ret.Reason,
 	}
-	if p.conn != nil {
-		p.conn.Close()
+	for _, item := range ret.Items {
+		event.Items = append(event.Items, events.RefundedItem{
+			ProductID: item.ProductID.String(),
+			SellerID:  item.SellerID.String(),
+			Amount:    item.SaleAmount,
+		})
 	}
+
+	return p.emit(ctx, order, event)
 }
 
-func (p *RabbitMQProducer) EmitOrderPaid(ctx context.Context, order *domain.Order) error {
-	event := OrderPaidEvent{
-		OrderID:   order.ID.String(),
-		Amount:    order.TotalAmount,
-		Timestamp: time.Now(),
+func (p *RabbitMQProducer) EmitOrderStatusChanged(ctx context.Context, change *domain.OrderStatusChange) error {
+	event := events.OrderStatusChanged{
+		OrderID:   change.OrderID.String(),
+		UserID:    change.UserID.String(),
+		Status:    string(change.Status),
+		Sequence:  change.Sequence,
+		ChangedAt: change.ChangedAt,
 	}
 
-	body, err := json.Marshal(event)
+	env, err := events.New(producerName, event,
+		events.WithCorrelationID(event.OrderID), events.WithOccurredAt(change.ChangedAt))
 	if err != nil {
 		return err
 	}
+	return p.publisher.PublishEvent(ctx, env)
+}
 
-	return p.channel.PublishWithContext(ctx,
-		"order_events", // exchange
-		"order.paid",   // routing key
-		false,          // mandatory
-		false,          // immediate
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		})
+// emit wraps the payload in an envelope correlated by order ID. Publishing
+// waits for the connection if it is being re-established and then for the
+// broker's confirmation, both bounded by ctx.
+func (p *RabbitMQProducer) emit(ctx context.Context, order *domain.Order, payload events.Payload, opts ...events.Option) error {
+	opts = append([]events.Option{events.WithCorrelationID(order.ID.String())}, opts...)
+	env, err := events.New(producerName, payload, opts...)
+	if err != nil {
+		return err
+	}
+	return p.publisher.PublishEvent(ctx, env)
 }
@@ -0,0 +1,151 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/events"
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/rabbitmq"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// statusFeedBuffer is how many changes a watcher may fall behind before it
+// is dropped and has to resume from the history.
+const statusFeedBuffer = 64
+
+// StatusFeed fans order.status_changed events out to the watchers of this
+// replica. Every replica consumes them from its own exclusive queue, so each
+// sees the changes recorded by all replicas.
+type StatusFeed struct {
+	conn     *rabbitmq.Manager
+	prefetch int
+	queue    string
+
+	mu   sync.Mutex
+	subs map[*subscription]struct{}
+}
+
+type subscription struct {
+	ch    chan domain.OrderStatusChange
+	match func(domain.OrderStatusChange) bool
+}
+
+func NewStatusFeed(conn *rabbitmq.Manager, prefetch int) *StatusFeed {
+	return &StatusFeed{
+		conn:     conn,
+		prefetch: prefetch,
+		queue:    "order_service_status_feed." + uuid.NewString(),
+		subs:     map[*subscription]struct{}{},
+	}
+}
+
+// Register declares the replica's queue and subscribes to it. It must be
+// called before the connection manager is started. Changes published while
+// the connection is down are lost, so all watchers are dropped when it goes
+// away and resume from the history.
+func (f *StatusFeed) Register() {
+	f.conn.DeclareTopology(declareExchange)
+	f.conn.DeclareTopology(func(ch *amqp.Channel) error {
+		_, err := ch.QueueDeclare(
+			f.queue, // name
+			false,   // durable
+			true,    // delete when unused
+			true,    // exclusive
+			false,   // no-wait
+			nil,     // arguments
+		)
+		if err != nil {
+			return err
+		}
+		return ch.QueueBind(
+			f.queue,                       // queue name
+			events.TypeOrderStatusChanged, // routing key
+			ordersExchange,                // exchange
+			false,
+			nil,
+		)
+	})
+	f.conn.Consume(f.queue, f.prefetch, f.handle)
+	f.conn.OnStateChange(func(state rabbitmq.State) {
+		if state != rabbitmq.StateConnected {
+			f.dropAll()
+		}
+	})
+}
+
+func (f *StatusFeed) Subscribe(match func(domain.OrderStatusChange) bool) (<-chan domain.OrderStatusChange, func()) {
+	sub := &subscription{ch: make(chan domain.OrderStatusChange, statusFeedBuffer), match: match}
+	f.mu.Lock()
+	f.subs[sub] = struct{}{}
+	f.mu.Unlock()
+
+	return sub.ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subs[sub]; ok {
+			delete(f.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (f *StatusFeed) handle(_ context.Context, d amqp.Delivery) error {
+	var event events.OrderStatusChanged
+	if _, err := rabbitmq.DecodeEvent(d, &event); err != nil {
+		return rabbitmq.Permanent(err)
+	}
+	change, err := toStatusChange(event)
+	if err != nil {
+		return rabbitmq.Permanent(err)
+	}
+	f.broadcast(change)
+	return nil
+}
+
+func (f *StatusFeed) broadcast(change domain.OrderStatusChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for sub := range f.subs {
+		if !sub.match(change) {
+			continue
+		}
+		select {
+		case sub.ch <- change:
+		default:
+			log.Printf("Dropping status watcher that fell behind at sequence %d", change.Sequence)
+			delete(f.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (f *StatusFeed) dropAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for sub := range f.subs {
+		delete(f.subs, sub)
+		close(sub.ch)
+	}
+}
+
+func toStatusChange(event events.OrderStatusChanged) (domain.OrderStatusChange, error) {
+	orderID, err := uuid.Parse(event.OrderID)
+	if err != nil {
+		return domain.OrderStatusChange{}, fmt.Errorf("invalid order_id in %s: %w", events.TypeOrderStatusChanged, err)
+	}
+	userID, err := uuid.Parse(event.UserID)
+	if err != nil {
+		return domain.OrderStatusChange{}, fmt.Errorf("invalid user_id in %s: %w", events.TypeOrderStatusChanged, err)
+	}
+	return domain.OrderStatusChange{
+		OrderID:   orderID,
+		UserID:    userID,
+		Status:    domain.OrderStatus(event.Status),
+		Sequence:  event.Sequence,
+		ChangedAt: event.ChangedAt,
+	}, nil
+}
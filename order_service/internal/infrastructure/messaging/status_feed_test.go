@@ -0,0 +1,50 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusFeed_BroadcastMatchesSubscribers(t *testing.T) {
+	feed := NewStatusFeed(nil, 0)
+	orderID := uuid.New()
+	mine, unsubscribe := feed.Subscribe(func(c domain.OrderStatusChange) bool { return c.OrderID == orderID })
+	defer unsubscribe()
+	others, unsubscribeOthers := feed.Subscribe(func(c domain.OrderStatusChange) bool { return c.OrderID != orderID })
+	defer unsubscribeOthers()
+
+	change := domain.OrderStatusChange{OrderID: orderID, Status: domain.StatusPaid, Sequence: 3}
+	feed.broadcast(change)
+
+	assert.Equal(t, change, <-mine)
+	assert.Empty(t, others)
+}
+
+func TestStatusFeed_DropsLaggingSubscriber(t *testing.T) {
+	feed := NewStatusFeed(nil, 0)
+	ch, unsubscribe := feed.Subscribe(func(domain.OrderStatusChange) bool { return true })
+
+	for i := 0; i <= statusFeedBuffer; i++ {
+		feed.broadcast(domain.OrderStatusChange{Sequence: int64(i + 1)})
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	assert.Equal(t, statusFeedBuffer, received)
+	unsubscribe() // no double close
+}
+
+func TestStatusFeed_DropAllClosesSubscribers(t *testing.T) {
+	feed := NewStatusFeed(nil, 0)
+	ch, _ := feed.Subscribe(func(domain.OrderStatusChange) bool { return true })
+
+	feed.dropAll()
+
+	_, ok := <-ch
+	assert.False(t, ok)
+}
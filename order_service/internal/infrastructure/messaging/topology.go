@@ -0,0 +1,22 @@
+package messaging
+
+import (
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/events"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const ordersExchange = events.Exchange
+
+// declareExchange is registered with the connection manager so the exchange
+// exists again after the broker restarts.
+func declareExchange(ch *amqp.Channel) error {
+	return ch.ExchangeDeclare(
+		ordersExchange, // name
+		"topic",        // type
+		true,           // durable
+		false,          // auto-deleted
+		false,          // internal
+		false,          // no-wait
+		nil,            // arguments
+	)
+}
@@ -0,0 +1,171 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// archivedOrderRow is a row of archived_orders.
+type archivedOrderRow struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Status     domain.OrderStatus
+	CreatedAt  time.Time
+	ArchivedAt time.Time
+	Document   domain.ArchivedOrder `gorm:"serializer:json"`
+	ExportedTo string
+	ExportedAt *time.Time
+}
+
+func (archivedOrderRow) TableName() string {
+	return "archived_orders"
+}
+
+type PostgresArchiveRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresArchiveRepository(db *gorm.DB) *PostgresArchiveRepository {
+	return &PostgresArchiveRepository{db: db}
+}
+
+func (r *PostgresArchiveRepository) ArchiveOrders(ctx context.Context, query domain.ArchiveQuery) (int, error) {
+	var archived int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		selected := forUpdate(tx.Model(&domain.Order{}), "SKIP LOCKED").
+			Where("status IN ? AND updated_at < ?", domain.ArchivableStatuses, query.Before).
+			Order("updated_at, id").Limit(query.Limit)
+		var ids []uuid.UUID
+		if err := selected.Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+			return err
+		}
+
+		docs, err := loadArchive(tx, ids)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		rows := make([]archivedOrderRow, 0, len(docs))
+		for _, doc := range docs {
+			doc.ArchivedAt = now
+			rows = append(rows, archivedOrderRow{
+				ID: doc.Order.ID, UserID: doc.Order.UserID, Status: doc.Order.Status,
+				CreatedAt: doc.Order.CreatedAt, ArchivedAt: now, Document: *doc,
+			})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		if err := deleteOrders(tx, ids); err != nil {
+			return err
+		}
+		archived = len(rows)
+		return nil
+	})
+	return archived, dbError(err)
+}
+
+// forUpdate locks the rows the query selects until the transaction ends.
+// SQLite is only used by the tests and locks the whole database.
+func forUpdate(db *gorm.DB, options string) *gorm.DB {
+	if db.Dialector.Name() == "sqlite" {
+		return db
+	}
+	return db.Clauses(clause.Locking{Strength: "UPDATE", Options: options})
+}
+
+// loadArchive reads the orders with everything recorded about them.
+func loadArchive(tx *gorm.DB, ids []uuid.UUID) ([]*domain.ArchivedOrder, error) {
+	var orders []domain.Order
+	if err := tx.Preload("Items").Preload("Discounts").Preload("Addresses").Where("id IN ?", ids).Order("id").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	docs := make([]*domain.ArchivedOrder, len(orders))
+	byID := make(map[uuid.UUID]*domain.ArchivedOrder, len(orders))
+	for i := range orders {
+		docs[i] = &domain.ArchivedOrder{Order: orders[i]}
+		byID[orders[i].ID] = docs[i]
+	}
+
+	var history []domain.OrderStatusHistory
+	if err := tx.Where("order_id IN ?", ids).Order("sequence").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	for _, h := range history {
+		byID[h.OrderID].StatusHistory = append(byID[h.OrderID].StatusHistory, h)
+	}
+	var amendments []domain.OrderAmendment
+	if err := tx.Where("order_id IN ?", ids).Order("amended_at, id").Find(&amendments).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range amendments {
+		byID[a.OrderID].Amendments = append(byID[a.OrderID].Amendments, a)
+	}
+	var returns []domain.OrderReturn
+	if err := tx.Preload("Items").Where("order_id IN ?", ids).Order("requested_at, id").Find(&returns).Error; err != nil {
+		return nil, err
+	}
+	for _, ret := range returns {
+		byID[ret.OrderID].Returns = append(byID[ret.OrderID].Returns, ret)
+	}
+	return docs, nil
+}
+
+// deleteOrders deletes the orders and the rows that belong to them. The
+// foreign keys would cascade, but not on SQLite. Promotion redemptions are
+// kept: they count towards per-user limits.
+func deleteOrders(tx *gorm.DB, ids []uuid.UUID) error {
+	returnIDs := tx.Model(&domain.OrderReturn{}).Select("id").Where("order_id IN ?", ids)
+	if err := tx.Where("return_id IN (?)", returnIDs).Delete(&domain.OrderReturnItem{}).Error; err != nil {
+		return err
+	}
+	for _, model := range []any{&domain.OrderReturn{}, &domain.OrderAmendment{}, &domain.OrderStatusHistory{},
+		&domain.OrderDiscount{}, &domain.OrderAddress{}, &domain.OrderItem{}} {
+		if err := tx.Where("order_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Where("id IN ?", ids).Delete(&domain.Order{}).Error
+}
+
+func (r *PostgresArchiveRepository) PendingExports(ctx context.Context, limit int) ([]domain.ArchivedOrder, error) {
+	var rows []archivedOrderRow
+	err := r.db.WithContext(ctx).Where("exported_at IS NULL").Order("archived_at, id").Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+	docs := make([]domain.ArchivedOrder, len(rows))
+	for i, row := range rows {
+		docs[i] = row.Document
+	}
+	return docs, nil
+}
+
+func (r *PostgresArchiveRepository) MarkExported(ctx context.Context, ids []uuid.UUID, location string) error {
+	err := r.db.WithContext(ctx).Model(&archivedOrderRow{}).Where("id IN ?", ids).
+		Updates(map[string]any{"exported_to": location, "exported_at": time.Now()}).Error
+	return dbError(err)
+}
+
+// archivedOrder reads an archived order from db, failing with
+// gorm.ErrRecordNotFound when there is none.
+func archivedOrder(db *gorm.DB, id uuid.UUID) (*domain.ArchivedOrder, error) {
+	var row archivedOrderRow
+	if err := db.Select("document").First(&row, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &row.Document, nil
+}
+
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
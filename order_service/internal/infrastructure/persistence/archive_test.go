@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPostgresArchiveRepository(t *testing.T) {
+	// A database of its own: archiving would take other tests' orders.
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderAddress{}, &domain.OrderStatusHistory{},
+		&domain.PromotionRedemption{}, &domain.OrderDiscount{}, &domain.OrderReturn{}, &domain.OrderReturnItem{},
+		&domain.OrderAmendment{}, &archivedOrderRow{}))
+
+	orders := NewPostgresOrderRepository(db)
+	repo := NewPostgresArchiveRepository(db)
+	ctx := context.Background()
+	cutoff := time.Now().UTC().Add(-365 * 24 * time.Hour).Truncate(time.Second)
+
+	create := func(status domain.OrderStatus, updatedAt time.Time) *domain.Order {
+		id := uuid.New()
+		order := &domain.Order{ID: id, UserID: uuid.New(), Status: status, Currency: "ETB", TotalAmount: 40,
+			CreatedAt: updatedAt.Add(-time.Hour), UpdatedAt: updatedAt}
+		items := []domain.OrderItem{{ID: uuid.New(), OrderID: id, ProductID: uuid.New(), SellerID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 1}}
+		addresses := []domain.OrderAddress{{ID: uuid.New(), OrderID: id, Kind: domain.AddressShipping, FullName: "Abebe Kebede", Country: "ET", City: "Addis Ababa"}}
+		require.NoError(t, orders.CreateOrder(ctx, order, items, addresses))
+		order.Items, order.Addresses = items, addresses
+		return order
+	}
+	delivered := create(domain.StatusDelivered, cutoff.Add(-48*time.Hour))
+	canceled := create(domain.StatusCanceled, cutoff.Add(-24*time.Hour))
+	recent := create(domain.StatusDelivered, cutoff.Add(time.Hour))
+	unfinished := create(domain.StatusShipped, cutoff.Add(-48*time.Hour))
+
+	history := &domain.OrderStatusHistory{ID: uuid.New(), OrderID: delivered.ID, FromStatus: domain.StatusShipped, Status: domain.StatusDelivered, ChangedAt: cutoff.Add(-48 * time.Hour)}
+	require.NoError(t, db.Create(history).Error)
+	returnID := uuid.New()
+	require.NoError(t, db.Create(&domain.OrderReturn{ID: returnID, OrderID: delivered.ID, UserID: delivered.UserID, Status: domain.ReturnRejected,
+		RequestedAt: cutoff.Add(-47 * time.Hour), UpdatedAt: cutoff.Add(-47 * time.Hour),
+		Items: []domain.OrderReturnItem{{ID: uuid.New(), ReturnID: returnID, OrderItemID: delivered.Items[0].ID, Quantity: 1}}}).Error)
+	redemption := &domain.PromotionRedemption{ID: uuid.New(), PromotionID: uuid.New(), UserID: delivered.UserID, OrderID: delivered.ID}
+	require.NoError(t, db.Create(redemption).Error)
+
+	n, err := repo.ArchiveOrders(ctx, domain.ArchiveQuery{Before: cutoff, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "the least recently updated first")
+	n, err = repo.ArchiveOrders(ctx, domain.ArchiveQuery{Before: cutoff, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// The live rows are gone, except the redemption.
+	for table, id := range map[string]uuid.UUID{"orders": delivered.ID, "order_items": delivered.Items[0].ID, "order_addresses": delivered.Addresses[0].ID,
+		"order_status_histories": history.ID, "order_returns": returnID, "promotion_redemptions": redemption.ID} {
+		var count int64
+		require.NoError(t, db.Table(table).Where("id = ?", id).Count(&count).Error)
+		assert.Equal(t, map[bool]int64{true: 1, false: 0}[table == "promotion_redemptions"], count, table)
+	}
+	var live []uuid.UUID
+	require.NoError(t, db.Model(&domain.Order{}).Order("updated_at").Pluck("id", &live).Error)
+	assert.Equal(t, []uuid.UUID{unfinished.ID, recent.ID}, live)
+
+	// Archived orders are still served.
+	got, err := orders.GetOrderByID(ctx, delivered.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDelivered, got.Status)
+	assert.Len(t, got.Items, 1)
+	assert.Len(t, got.Addresses, 1)
+	gotHistory, err := orders.ListStatusHistory(ctx, delivered.ID)
+	require.NoError(t, err)
+	require.Len(t, gotHistory, 1)
+	assert.Equal(t, domain.StatusShipped, gotHistory[0].FromStatus)
+	_, err = orders.GetOrderByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	pending, err := repo.PendingExports(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, delivered.ID, pending[0].Order.ID)
+	assert.Equal(t, canceled.ID, pending[1].Order.ID)
+	require.Len(t, pending[0].Returns, 1)
+	assert.Len(t, pending[0].Returns[0].Items, 1)
+	assert.False(t, pending[0].ArchivedAt.IsZero())
+
+	require.NoError(t, repo.MarkExported(ctx, []uuid.UUID{delivered.ID}, "/archive/orders/1.jsonl.gz"))
+	pending, err = repo.PendingExports(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, canceled.ID, pending[0].Order.ID)
+}
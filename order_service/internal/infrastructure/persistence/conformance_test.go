@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/repotest"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/infrastructure/schema"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// postgresDSNEnv names a disposable database the conformance suite runs
+// against besides SQLite; it is migrated and left with the suite's rows.
+const postgresDSNEnv = "ORDER_SERVICE_TEST_POSTGRES_DSN"
+
+func TestConformance_SQLite(t *testing.T) {
+	repotest.TestOrderRepository(t, func(t *testing.T) repotest.Repositories {
+		// A database of its own per subtest, on one connection, so the
+		// concurrent writers queue instead of failing on SQLite's lock.
+		db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+		t.Cleanup(func() { sqlDB.Close() })
+		require.NoError(t, db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderAddress{}, &domain.OrderStatusHistory{},
+			&domain.Promotion{}, &domain.PromotionRedemption{}, &domain.OrderDiscount{}, &domain.OrderAmendment{}, &domain.OrderReturn{}, &domain.OrderReturnItem{}, &archivedOrderRow{}))
+		return repotest.Repositories{Orders: NewPostgresOrderRepository(db), Promotions: NewPostgresPromotionRepository(db), Returns: NewPostgresReturnRepository(db)}
+	})
+}
+
+func TestConformance_Postgres(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+	m, err := schema.Open(dsn, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, m.Up())
+	m.Close()
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	repotest.TestOrderRepository(t, func(t *testing.T) repotest.Repositories {
+		return repotest.Repositories{Orders: NewPostgresOrderRepository(db), Promotions: NewPostgresPromotionRepository(db), Returns: NewPostgresReturnRepository(db)}
+	})
+}
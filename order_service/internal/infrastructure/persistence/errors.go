@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// dbError translates a database error into a domain error, so that the
+// transport can classify it without leaking the database's message. It
+// relies on gorm translating constraint violations, which the service
+// enables with TranslateError. Domain and context errors pass through.
+func dbError(err error) error {
+	var domainErr *domain.Error
+	switch {
+	case err == nil, errors.As(err, &domainErr),
+		errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return &domain.Error{Kind: domain.ErrNotFound, Reason: "RECORD_NOT_FOUND", Message: "record not found", Err: err}
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return &domain.Error{Kind: domain.ErrConflict, Reason: "DUPLICATE_RECORD", Message: "record already exists", Err: err}
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return &domain.Error{Kind: domain.ErrPreconditionFailed, Reason: "REFERENCED_RECORD_MISSING", Message: "a referenced record does not exist", Err: err}
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return &domain.Error{Kind: domain.ErrInvalidArgument, Reason: "CONSTRAINT_VIOLATED", Message: "value violates a constraint", Err: err}
+	case unreachable(err):
+		return &domain.Error{Kind: domain.ErrUnavailable, Reason: "DATABASE_UNAVAILABLE", Message: "database unavailable", Err: err}
+	default:
+		return err
+	}
+}
+
+// unreachable reports whether err means the database cannot serve requests
+// right now: the connection failed or broke, or the server is shutting down
+// or out of connections.
+func unreachable(err error) bool {
+	var (
+		netErr     net.Error
+		connectErr *pgconn.ConnectError
+		pgErr      *pgconn.PgError
+	)
+	switch {
+	case errors.Is(err, driver.ErrBadConn), errors.As(err, &netErr), errors.As(err, &connectErr):
+		return true
+	case errors.As(err, &pgErr):
+		// Classes 08 (connection exception), 53 (insufficient resources)
+		// and 57P (operator intervention).
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "53") || strings.HasPrefix(pgErr.Code, "57P")
+	default:
+		return false
+	}
+}
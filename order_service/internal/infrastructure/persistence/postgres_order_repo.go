@@ -2,47 +2,399 @@ package persistence
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// PostgresOrderRepository writes to the primary database and routes its
+// read-only methods through a Router.
 type PostgresOrderRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	router *Router
 }
 
+// NewPostgresOrderRepository reads and writes db.
 func NewPostgresOrderRepository(db *gorm.DB) *PostgresOrderRepository {
-	return &PostgresOrderRepository{db: db}
+	return NewRoutedOrderRepository(NewRouter(db, nil, RouterOptions{}))
 }
 
-func (r *PostgresOrderRepository) CreateOrder(ctx context.Context, order *domain.Order, items []domain.OrderItem, address *domain.OrderAddress) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(order).Error; err != nil {
+// NewRoutedOrderRepository writes to the router's primary and reads from its
+// replicas. Written orders and their users stick to the primary.
+func NewRoutedOrderRepository(router *Router) *PostgresOrderRepository {
+	return &PostgresOrderRepository{db: router.Primary(), router: router}
+}
+
+func (r *PostgresOrderRepository) CreateOrder(ctx context.Context, order *domain.Order, items []domain.OrderItem, addresses []domain.OrderAddress) error {
+	defer r.router.Stick(order.ID, order.UserID)
+	return dbError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		order.Version = 1
+		if err := tx.Omit("Discounts", "Addresses").Create(order).Error; err != nil {
 			return err
 		}
 		if err := tx.Create(&items).Error; err != nil {
 			return err
 		}
-		if err := tx.Create(address).Error; err != nil {
+		if err := tx.Create(&addresses).Error; err != nil {
 			return err
 		}
-		return nil
-	})
+		return redeemPromotions(tx, order)
+	}))
 }
 
 func (r *PostgresOrderRepository) GetOrderByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
 	var order domain.Order
-	if err := r.db.WithContext(ctx).Preload("Items").First(&order, "id = ?", id).Error; err != nil {
-		return nil, err
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		err := db.Preload("Items").Preload("Discounts").Preload("Addresses").First(&order, "id = ?", id).Error
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		// Finished orders may have been archived.
+		archived, err := archivedOrder(db, id)
+		if err != nil {
+			return err
+		}
+		order = archived.Order
+		return nil
+	}, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, dbError(err)
 	}
 	return &order, nil
 }
 
-func (r *PostgresOrderRepository) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status domain.OrderStatus) error {
-	return r.db.WithContext(ctx).Model(&domain.Order{}).Where("id = ?", orderID).Update("status", status).Error
+func (r *PostgresOrderRepository) ListOrders(ctx context.Context, query domain.OrderListQuery) ([]domain.Order, error) {
+	var orders []domain.Order
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		tx := db.Preload("Items").Preload("Discounts").Where("user_id = ?", query.UserID)
+		if after := query.After; after != nil {
+			tx = tx.Where("created_at < ? OR (created_at = ? AND id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+		}
+		return tx.Order("created_at DESC, id DESC").Limit(query.Limit).Find(&orders).Error
+	}, query.UserID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return orders, nil
+}
+
+func (r *PostgresOrderRepository) ChangeStatus(ctx context.Context, entry *domain.OrderStatusHistory, version int64) error {
+	// Also on a version conflict, so that the caller reloads the order from
+	// the primary.
+	defer r.router.Stick(entry.OrderID)
+	return dbError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.Order{}).
+			Where("id = ? AND version = ?", entry.OrderID, version).
+			Updates(map[string]any{
+				"status":     entry.Status,
+				"updated_at": entry.ChangedAt,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return versionError(tx, entry.OrderID, version)
+		}
+		return tx.Create(entry).Error
+	}))
+}
+
+// versionError explains why an update of the order at version matched no
+// row: the order does not exist or is at another version.
+func versionError(tx *gorm.DB, orderID uuid.UUID, version int64) error {
+	var count int64
+	if err := tx.Model(&domain.Order{}).Where("id = ?", orderID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrOrderNotFound
+	}
+	return &domain.VersionConflictError{OrderID: orderID, Version: version}
+}
+
+func (r *PostgresOrderRepository) ListStatusHistory(ctx context.Context, orderID uuid.UUID) ([]domain.OrderStatusHistory, error) {
+	var history []domain.OrderStatusHistory
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		if err := db.Where("order_id = ?", orderID).Order("sequence").Find(&history).Error; err != nil || len(history) > 0 {
+			return err
+		}
+		archived, err := archivedOrder(db, orderID)
+		if err == nil {
+			history = archived.StatusHistory
+		}
+		return ignoreNotFound(err)
+	}, orderID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return history, nil
+}
+
+func (r *PostgresOrderRepository) ListStatusChanges(ctx context.Context, query domain.StatusChangeQuery) ([]domain.OrderStatusChange, error) {
+	// Always on the primary: watches replay from here what was recorded
+	// before they subscribed to the live feed, and a lagging replica would
+	// leave a gap.
+	tx := r.db.WithContext(ctx).
+		Table("order_status_histories AS h").
+		Select("h.order_id, o.user_id, h.status, h.sequence, h.changed_at").
+		Joins("JOIN orders o ON o.id = h.order_id").
+		Where("h.sequence > ?", query.AfterSequence)
+	if query.OrderID != uuid.Nil {
+		tx = tx.Where("h.order_id = ?", query.OrderID)
+	}
+	if query.UserID != uuid.Nil {
+		tx = tx.Where("o.user_id = ?", query.UserID)
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var changes []domain.OrderStatusChange
+	if err := tx.Order("h.sequence").Scan(&changes).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return changes, nil
+}
+
+// sortColumns whitelists the columns search results can be ordered by.
+var sortColumns = map[domain.OrderSortField]string{
+	domain.SortByCreatedAt:   "orders.created_at",
+	domain.SortByUpdatedAt:   "orders.updated_at",
+	domain.SortByTotalAmount: "orders.total_amount",
+}
+
+func (r *PostgresOrderRepository) SearchOrders(ctx context.Context, query domain.OrderSearchQuery) ([]domain.Order, int64, error) {
+	column, ok := sortColumns[query.SortBy]
+	if !ok {
+		column = sortColumns[domain.SortByCreatedAt]
+	}
+	direction := " ASC"
+	if query.Descending {
+		direction = " DESC"
+	}
+
+	var (
+		total  int64
+		orders []domain.Order
+	)
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		if err := db.Model(&domain.Order{}).Scopes(searchFilters(query)).Count(&total).Error; err != nil || total == 0 {
+			return err
+		}
+		return db.Preload("Items").Preload("Discounts").Scopes(searchFilters(query)).
+			Order(column + direction).Order("orders.id" + direction).
+			Offset(query.Offset).Limit(query.Limit).
+			Find(&orders).Error
+	}, query.UserID)
+	if err != nil {
+		return nil, 0, dbError(err)
+	}
+	return orders, total, nil
+}
+
+// searchFilters applies the filters of a search. Item and address filters
+// are EXISTS subqueries so that an order matching on several items or
+// addresses is counted once.
+func searchFilters(query domain.OrderSearchQuery) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if query.UserID != uuid.Nil {
+			tx = tx.Where("orders.user_id = ?", query.UserID)
+		}
+		if query.SellerID != uuid.Nil {
+			tx = tx.Where("EXISTS (SELECT 1 FROM order_items i WHERE i.order_id = orders.id AND i.seller_id = ?)", query.SellerID)
+		}
+		if query.ProductID != uuid.Nil {
+			tx = tx.Where("EXISTS (SELECT 1 FROM order_items i WHERE i.order_id = orders.id AND i.product_id = ?)", query.ProductID)
+		}
+		if len(query.Statuses) > 0 {
+			tx = tx.Where("orders.status IN ?", query.Statuses)
+		}
+		if query.MinTotal != nil {
+			tx = tx.Where("orders.total_amount >= ?", *query.MinTotal)
+		}
+		if query.MaxTotal != nil {
+			tx = tx.Where("orders.total_amount <= ?", *query.MaxTotal)
+		}
+		if !query.CreatedFrom.IsZero() {
+			tx = tx.Where("orders.created_at >= ?", query.CreatedFrom)
+		}
+		if !query.CreatedTo.IsZero() {
+			tx = tx.Where("orders.created_at < ?", query.CreatedTo)
+		}
+		if query.City != "" {
+			tx = tx.Where("EXISTS (SELECT 1 FROM order_addresses a WHERE a.order_id = orders.id AND a.kind = ? AND LOWER(a.city) = LOWER(?))", domain.AddressShipping, query.City)
+		}
+		if query.PhoneFragment != "" {
+			tx = tx.Where(`EXISTS (SELECT 1 FROM order_addresses a WHERE a.order_id = orders.id AND a.kind = ? AND a.phone LIKE ? ESCAPE '\')`, domain.AddressShipping, "%"+escapeLike(query.PhoneFragment)+"%")
+		}
+		return tx
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike makes s match literally inside a LIKE pattern.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func (r *PostgresOrderRepository) ListSellerLines(ctx context.Context, query domain.SellerLineQuery) ([]domain.SellerOrderLine, error) {
+	var lines []domain.SellerOrderLine
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		tx := db.
+			Table("order_items AS i").
+			Select("i.*, o.status AS order_status, o.created_at AS ordered_at").
+			Joins("JOIN orders o ON o.id = i.order_id").
+			Where("i.seller_id = ?", query.SellerID)
+		if len(query.OrderStatuses) > 0 {
+			tx = tx.Where("o.status IN ?", query.OrderStatuses)
+		}
+		if len(query.FulfillmentStatuses) > 0 {
+			tx = tx.Where("i.fulfillment_status IN ?", query.FulfillmentStatuses)
+		}
+		if after := query.After; after != nil {
+			tx = tx.Where("o.created_at < ? OR (o.created_at = ? AND i.id < ?)", after.CreatedAt, after.CreatedAt, after.ID)
+		}
+		return tx.Order("o.created_at DESC, i.id DESC").Limit(query.Limit).Scan(&lines).Error
+	}, query.SellerID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return lines, nil
+}
+
+// UpdateLineFulfillment moves the given lines to status, stamping the time.
+// Lines that are already at or past status are left alone, so concurrent
+// updates cannot move a line back.
+func (r *PostgresOrderRepository) UpdateLineFulfillment(ctx context.Context, orderID uuid.UUID, itemIDs []uuid.UUID, status domain.FulfillmentStatus, at time.Time) error {
+	updates := map[string]any{"fulfillment_status": status}
+	switch status {
+	case domain.FulfillmentPacked:
+		updates["packed_at"] = at
+	case domain.FulfillmentHandedOver:
+		updates["packed_at"] = gorm.Expr("COALESCE(packed_at, ?)", at)
+		updates["handed_over_at"] = at
+	}
+	err := r.db.WithContext(ctx).Model(&domain.OrderItem{}).
+		Where("order_id = ? AND id IN ? AND fulfillment_status IN ?", orderID, itemIDs, domain.FulfillmentBefore(status)).
+		Updates(updates).Error
+	return dbError(err)
+}
+
+func (r *PostgresOrderRepository) SellerDailySales(ctx context.Context, query domain.SellerSalesQuery) ([]domain.SellerDailySales, error) {
+	day := r.utcDay("o.created_at")
+	var sales []domain.SellerDailySales
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		return db.
+			Table("order_items AS i").
+			Select(day+" AS day, COUNT(DISTINCT i.order_id) AS orders, SUM(i.quantity) AS units, SUM(i.unit_price * i.quantity) AS revenue").
+			Joins("JOIN orders o ON o.id = i.order_id").
+			Where("i.seller_id = ? AND o.status IN ?", query.SellerID, domain.SalesStatuses).
+			Where("o.created_at >= ? AND o.created_at < ?", query.From, query.To).
+			Group(day).
+			Order("day").
+			Scan(&sales).Error
+	})
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return sales, nil
+}
+
+// utcDay formats a timestamp column as its UTC date, YYYY-MM-DD. SQLite is
+// only used by the tests.
+func (r *PostgresOrderRepository) utcDay(column string) string {
+	if r.db.Dialector.Name() == "sqlite" {
+		return "strftime('%Y-%m-%d', " + column + ")"
+	}
+	return "to_char(" + column + " AT TIME ZONE 'UTC', 'YYYY-MM-DD')"
 }
 
-func (r *PostgresOrderRepository) AddStatusHistory(ctx context.Context, history *domain.OrderStatusHistory) error {
-	return r.db.WithContext(ctx).Create(history).Error
+func (r *PostgresOrderRepository) AmendOrder(ctx context.Context, order *domain.Order, removed []uuid.UUID, amendment *domain.OrderAmendment) error {
+	defer r.router.Stick(order.ID, order.UserID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The version condition keeps a payment, cancellation or other
+		// amendment that landed while the amendment was priced from being
+		// overwritten.
+		res := tx.Model(&domain.Order{}).
+			Where("id = ? AND version = ? AND status IN ?", order.ID, order.Version, []domain.OrderStatus{domain.StatusCreated, domain.StatusPending}).
+			Updates(map[string]any{
+				"subtotal":           order.Subtotal,
+				"discount_amount":    order.DiscountAmount,
+				"tax_amount":         order.TaxAmount,
+				"prices_include_tax": order.PricesIncludeTax,
+				"shipping_amount":    order.ShippingAmount,
+				"shipping_zone":      order.ShippingZone,
+				"total_amount":       order.TotalAmount,
+				"updated_at":         order.UpdatedAt,
+				"version":            gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var current domain.Order
+			err := tx.Select("status").First(&current, "id = ?", order.ID).Error
+			if err == nil && !current.Amendable() {
+				return domain.ErrOrderNotAmendable
+			}
+			return versionError(tx, order.ID, order.Version)
+		}
+
+		if len(removed) > 0 {
+			if err := tx.Where("order_id = ? AND id IN ?", order.ID, removed).Delete(&domain.OrderItem{}).Error; err != nil {
+				return err
+			}
+		}
+		for i := range order.Items {
+			if err := tx.Save(&order.Items[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range order.Addresses {
+			if err := tx.Save(&order.Addresses[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("order_id = ?", order.ID).Delete(&domain.OrderDiscount{}).Error; err != nil {
+			return err
+		}
+		if len(order.Discounts) > 0 {
+			if err := tx.Create(&order.Discounts).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(amendment).Error
+	})
+	if err != nil {
+		return dbError(err)
+	}
+	order.Version++
+	return nil
+}
+
+func (r *PostgresOrderRepository) ListAmendments(ctx context.Context, orderID uuid.UUID) ([]domain.OrderAmendment, error) {
+	var amendments []domain.OrderAmendment
+	err := r.router.Read(ctx, func(db *gorm.DB) error {
+		if err := db.Where("order_id = ?", orderID).Order("amended_at, id").Find(&amendments).Error; err != nil || len(amendments) > 0 {
+			return err
+		}
+		archived, err := archivedOrder(db, orderID)
+		if err == nil {
+			amendments = archived.Amendments
+		}
+		return ignoreNotFound(err)
+	}, orderID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return amendments, nil
 }
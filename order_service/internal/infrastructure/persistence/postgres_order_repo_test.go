@@ -8,16 +8,19 @@ import (
 	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func setupTestDB() *gorm.DB {
-	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic("failed to connect database")
 	}
-	db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderAddress{}, &domain.OrderStatusHistory{})
+	db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderAddress{}, &domain.OrderStatusHistory{},
+		&domain.Promotion{}, &domain.PromotionRedemption{}, &domain.OrderDiscount{},
+		&domain.OrderReturn{}, &domain.OrderReturnItem{}, &domain.OrderAmendment{}, &archivedOrderRow{})
 	return db
 }
 
@@ -33,36 +36,60 @@ func TestPostgresOrderRepository_CreateOrder(t *testing.T) {
 		ID:          orderID,
 		UserID:      userID,
 		Status:      domain.StatusCreated,
-		TotalAmount: 100.0,
+		TotalAmount: 115.0,
 		Currency:    "USD",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+		TaxAmount:   15.0,
 	}
 
 	items := []domain.OrderItem{
 		{
-			ID:          uuid.New(),
-			OrderID:     orderID,
-			ProductID:   uuid.New(),
-			SellerID:    uuid.New(),
-			ProductName: "Product 1",
-			UnitPrice:   50.0,
-			Quantity:    2,
+			ID:            uuid.New(),
+			OrderID:       orderID,
+			ProductID:     uuid.New(),
+			SellerID:      uuid.New(),
+			ProductName:   "Product 1",
+			UnitPrice:     50.0,
+			Quantity:      2,
+			Category:      "books",
+			TaxableAmount: 100.0,
+			TaxRate:       0.15,
+			TaxAmount:     15.0,
 		},
 	}
 
-	address := &domain.OrderAddress{
-		ID:         uuid.New(),
-		OrderID:    orderID,
-		FullName:   "John Doe",
-		Phone:      "1234567890",
-		Country:    "USA",
-		City:       "New York",
-		Street:     "Fifth Avenue",
-		PostalCode: "10001",
+	lat, lon := 40.7484, -73.9857
+	addresses := []domain.OrderAddress{
+		{
+			ID:         uuid.New(),
+			OrderID:    orderID,
+			Kind:       domain.AddressShipping,
+			FullName:   "John Doe",
+			Phone:      "+12125550123",
+			Country:    "US",
+			Region:     "NY",
+			City:       "New York",
+			Street:     "Fifth Avenue",
+			PostalCode: "10001",
+			Latitude:   &lat,
+			Longitude:  &lon,
+		},
+		{
+			ID:           uuid.New(),
+			OrderID:      orderID,
+			Kind:         domain.AddressBilling,
+			FullName:     "Acme Inc",
+			Country:      "US",
+			Region:       "NY",
+			City:         "New York",
+			Street:       "Broadway 1",
+			AddressLine2: "Suite 200",
+			PostalCode:   "10004",
+		},
 	}
 
-	err := repo.CreateOrder(ctx, order, items, address)
+	err := repo.CreateOrder(ctx, order, items, addresses)
 	assert.NoError(t, err)
 
 	// Verify order exists
@@ -71,6 +98,7 @@ func TestPostgresOrderRepository_CreateOrder(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, orderID, savedOrder.ID)
 	assert.Equal(t, domain.StatusCreated, savedOrder.Status)
+	assert.Equal(t, 15.0, savedOrder.TaxAmount)
 
 	// Verify items exist
 	var savedItems []domain.OrderItem
@@ -78,12 +106,20 @@ func TestPostgresOrderRepository_CreateOrder(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, savedItems, 1)
 	assert.Equal(t, "Product 1", savedItems[0].ProductName)
+	assert.Equal(t, 0.15, savedItems[0].TaxRate)
+	assert.Equal(t, "books", savedItems[0].Category)
 
-	// Verify address exists
-	var savedAddress domain.OrderAddress
-	err = db.Where("order_id = ?", orderID).First(&savedAddress).Error
+	// Verify the addresses are loaded with the order
+	saved, err := repo.GetOrderByID(ctx, orderID)
 	assert.NoError(t, err)
-	assert.Equal(t, "John Doe", savedAddress.FullName)
+	if shipping := saved.Address(domain.AddressShipping); assert.NotNil(t, shipping) {
+		assert.Equal(t, "John Doe", shipping.FullName)
+		assert.Equal(t, &lat, shipping.Latitude)
+	}
+	if billing := saved.Address(domain.AddressBilling); assert.NotNil(t, billing) {
+		assert.Equal(t, "Suite 200", billing.AddressLine2)
+		assert.Nil(t, billing.Latitude)
+	}
 }
 
 func TestPostgresOrderRepository_GetOrderByID(t *testing.T) {
@@ -115,45 +151,367 @@ func TestPostgresOrderRepository_GetOrderByID(t *testing.T) {
 	assert.Equal(t, "Item A", fetchedOrder.Items[0].ProductName)
 }
 
-func TestPostgresOrderRepository_UpdateOrderStatus(t *testing.T) {
+func TestPostgresOrderRepository_ChangeStatus(t *testing.T) {
 	db := setupTestDB()
 	repo := NewPostgresOrderRepository(db)
 	ctx := context.Background()
 
 	orderID := uuid.New()
 	order := &domain.Order{
-		ID:     orderID,
-		Status: domain.StatusPending,
+		ID:      orderID,
+		Status:  domain.StatusPending,
+		Version: 1,
 	}
 	db.Create(order)
 
-	err := repo.UpdateOrderStatus(ctx, orderID, domain.StatusPaid)
-	assert.NoError(t, err)
+	paid := &domain.OrderStatusHistory{
+		ID:            uuid.New(),
+		OrderID:       orderID,
+		FromStatus:    domain.StatusPending,
+		Status:        domain.StatusPaid,
+		Actor:         domain.Actor{Type: domain.ActorService, ID: "payment_service"},
+		SourceEventID: "evt-1",
+		Reason:        "payment succeeded",
+		Metadata:      map[string]string{"payment_id": "pay-1"},
+		ChangedAt:     time.Now(),
+	}
+	require.NoError(t, repo.ChangeStatus(ctx, paid, 1))
+	assert.NotZero(t, paid.Sequence)
 
 	var updatedOrder domain.Order
 	db.First(&updatedOrder, "id = ?", orderID)
 	assert.Equal(t, domain.StatusPaid, updatedOrder.Status)
+	assert.Equal(t, int64(2), updatedOrder.Version)
+
+	// A writer still at version 1 lost the race and records nothing.
+	err := repo.ChangeStatus(ctx, &domain.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, FromStatus: domain.StatusPending, Status: domain.StatusCanceled, ChangedAt: time.Now()}, 1)
+	var conflict *domain.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, int64(1), conflict.Version)
+	db.First(&updatedOrder, "id = ?", orderID)
+	assert.Equal(t, domain.StatusPaid, updatedOrder.Status)
+
+	delivered := &domain.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, FromStatus: domain.StatusPaid, Status: domain.StatusDelivered, Actor: domain.Actor{Type: domain.ActorSystem}, ChangedAt: time.Now()}
+	require.NoError(t, repo.ChangeStatus(ctx, delivered, 2))
+
+	history, err := repo.ListStatusHistory(ctx, orderID)
+	require.NoError(t, err)
+	require.Len(t, history, 2, "the losing writer's entry was rolled back")
+	assert.Equal(t, domain.StatusPending, history[0].FromStatus)
+	assert.Equal(t, paid.Actor, history[0].Actor)
+	assert.Equal(t, "evt-1", history[0].SourceEventID)
+	assert.Equal(t, "payment succeeded", history[0].Reason)
+	assert.Equal(t, paid.Metadata, history[0].Metadata)
+	assert.Equal(t, domain.StatusPaid, history[1].FromStatus)
+	assert.Equal(t, domain.StatusDelivered, history[1].Status)
+
+	err = repo.ChangeStatus(ctx, &domain.OrderStatusHistory{ID: uuid.New(), OrderID: uuid.New(), Status: domain.StatusPaid, ChangedAt: time.Now()}, 1)
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
 }
 
-func TestPostgresOrderRepository_AddStatusHistory(t *testing.T) {
+func TestPostgresOrderRepository_ListStatusChanges(t *testing.T) {
 	db := setupTestDB()
 	repo := NewPostgresOrderRepository(db)
 	ctx := context.Background()
 
-	historyID := uuid.New()
-	orderID := uuid.New()
-	history := &domain.OrderStatusHistory{
-		ID:        historyID,
-		OrderID:   orderID,
-		Status:    domain.StatusPaid,
-		ChangedAt: time.Now(),
+	userID := uuid.New()
+	first := &domain.Order{ID: uuid.New(), UserID: userID, Status: domain.StatusCreated}
+	second := &domain.Order{ID: uuid.New(), UserID: userID, Status: domain.StatusCreated}
+	other := &domain.Order{ID: uuid.New(), UserID: uuid.New(), Status: domain.StatusCreated}
+	db.Create(first)
+	db.Create(second)
+	db.Create(other)
+
+	record := func(orderID uuid.UUID, status domain.OrderStatus) int64 {
+		var order domain.Order
+		db.First(&order, "id = ?", orderID)
+		history := &domain.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: status, ChangedAt: time.Now()}
+		assert.NoError(t, repo.ChangeStatus(ctx, history, order.Version))
+		return history.Sequence
+	}
+	paid := record(first.ID, domain.StatusPaid)
+	record(other.ID, domain.StatusPaid)
+	record(second.ID, domain.StatusPaid)
+	delivered := record(first.ID, domain.StatusDelivered)
+	assert.Greater(t, delivered, paid)
+
+	changes, err := repo.ListStatusChanges(ctx, domain.StatusChangeQuery{OrderID: first.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, domain.StatusPaid, changes[0].Status)
+		assert.Equal(t, userID, changes[0].UserID)
+		assert.Equal(t, delivered, changes[1].Sequence)
+	}
+
+	changes, err = repo.ListStatusChanges(ctx, domain.StatusChangeQuery{OrderID: first.ID, AfterSequence: paid})
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+
+	changes, err = repo.ListStatusChanges(ctx, domain.StatusChangeQuery{UserID: userID, Limit: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, first.ID, changes[0].OrderID)
+		assert.Equal(t, second.ID, changes[1].OrderID)
+	}
+}
+
+func TestPostgresOrderRepository_GetOrderByID_NotFound(t *testing.T) {
+	repo := NewPostgresOrderRepository(setupTestDB())
+
+	_, err := repo.GetOrderByID(context.Background(), uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
+
+func TestPostgresOrderRepository_CreateOrder_Duplicate(t *testing.T) {
+	repo := NewPostgresOrderRepository(setupTestDB())
+	ctx := context.Background()
+	order := &domain.Order{ID: uuid.New(), UserID: uuid.New(), Status: domain.StatusCreated, Currency: "ETB", CreatedAt: time.Now()}
+	items := []domain.OrderItem{{ID: uuid.New(), OrderID: order.ID, ProductID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 1}}
+	addresses := []domain.OrderAddress{{ID: uuid.New(), OrderID: order.ID, Kind: domain.AddressShipping, City: "Addis Ababa"}}
+	require.NoError(t, repo.CreateOrder(ctx, order, items, addresses))
+
+	err := repo.CreateOrder(ctx, order, items, addresses)
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	var domainErr *domain.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "DUPLICATE_RECORD", domainErr.Reason)
+	assert.NotContains(t, err.Error(), "UNIQUE", "the database's message stays out of the error")
+}
+
+func TestPostgresOrderRepository_ListOrders(t *testing.T) {
+	db := setupTestDB()
+	repo := NewPostgresOrderRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		order := &domain.Order{ID: uuid.New(), UserID: userID, Status: domain.StatusCreated, CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		db.Create(order)
+		db.Create(&domain.OrderItem{ID: uuid.New(), OrderID: order.ID, ProductName: "Item", Quantity: 1})
+		ids = append(ids, order.ID)
 	}
+	db.Create(&domain.Order{ID: uuid.New(), UserID: uuid.New(), Status: domain.StatusCreated, CreatedAt: base})
 
-	err := repo.AddStatusHistory(ctx, history)
+	page, err := repo.ListOrders(ctx, domain.OrderListQuery{UserID: userID, Limit: 2})
 	assert.NoError(t, err)
+	if assert.Len(t, page, 2) {
+		assert.Equal(t, ids[2], page[0].ID)
+		assert.Equal(t, ids[1], page[1].ID)
+		assert.Len(t, page[0].Items, 1)
+	}
 
-	var savedHistory domain.OrderStatusHistory
-	err = db.First(&savedHistory, "id = ?", historyID).Error
+	after := &domain.OrderCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+	rest, err := repo.ListOrders(ctx, domain.OrderListQuery{UserID: userID, Limit: 2, After: after})
 	assert.NoError(t, err)
-	assert.Equal(t, domain.StatusPaid, savedHistory.Status)
+	if assert.Len(t, rest, 1) {
+		assert.Equal(t, ids[0], rest[0].ID)
+	}
+}
+
+func TestPostgresOrderRepository_SearchOrders(t *testing.T) {
+	db := setupTestDB()
+	repo := NewPostgresOrderRepository(db)
+	ctx := context.Background()
+
+	// The in-memory database is shared between tests, so every search is
+	// scoped by a seller or user created here.
+	sellerID, productID, userID := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	create := func(user uuid.UUID, status domain.OrderStatus, total float64, createdAt time.Time, city, phone string) uuid.UUID {
+		order := &domain.Order{ID: uuid.New(), UserID: user, Status: status, TotalAmount: total, CreatedAt: createdAt, UpdatedAt: createdAt}
+		items := []domain.OrderItem{
+			{ID: uuid.New(), OrderID: order.ID, ProductID: productID, SellerID: sellerID, ProductName: "Mug", Quantity: 1},
+			{ID: uuid.New(), OrderID: order.ID, ProductID: uuid.New(), SellerID: sellerID, ProductName: "Tea", Quantity: 1},
+		}
+		address := domain.OrderAddress{ID: uuid.New(), OrderID: order.ID, Kind: domain.AddressShipping, FullName: "Abebe", Phone: phone, City: city}
+		assert.NoError(t, repo.CreateOrder(ctx, order, items, []domain.OrderAddress{address}))
+		return order.ID
+	}
+	cheap := create(userID, domain.StatusPaid, 10, base, "Addis Ababa", "+251911223344")
+	large := create(userID, domain.StatusCreated, 500, base.Add(time.Hour), "Adama", "+251922_00000")
+	late := create(uuid.New(), domain.StatusPaid, 50, base.Add(2*time.Hour), "addis ababa", "+251933445566")
+
+	ids := func(orders []domain.Order) []uuid.UUID {
+		var out []uuid.UUID
+		for _, o := range orders {
+			out = append(out, o.ID)
+		}
+		return out
+	}
+
+	orders, total, err := repo.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, SortBy: domain.SortByCreatedAt, Descending: true, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total, "orders with several matching items are counted once")
+	assert.Equal(t, []uuid.UUID{late, large}, ids(orders))
+	if assert.NotEmpty(t, orders) {
+		assert.Len(t, orders[0].Items, 2)
+	}
+
+	orders, total, err = repo.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, SortBy: domain.SortByCreatedAt, Descending: true, Offset: 2, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, []uuid.UUID{cheap}, ids(orders))
+
+	orders, _, err = repo.SearchOrders(ctx, domain.OrderSearchQuery{ProductID: productID, City: "ADDIS ABABA", SortBy: domain.SortByTotalAmount, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{cheap, late}, ids(orders))
+
+	orders, _, err = repo.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, PhoneFragment: "2_0", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{large}, ids(orders), "LIKE wildcards in the fragment match literally")
+
+	minTotal, maxTotal := 20.0, 500.0
+	orders, total, err = repo.SearchOrders(ctx, domain.OrderSearchQuery{
+		SellerID:    sellerID,
+		Statuses:    []domain.OrderStatus{domain.StatusPaid},
+		MinTotal:    &minTotal,
+		MaxTotal:    &maxTotal,
+		CreatedFrom: base,
+		CreatedTo:   base.Add(3 * time.Hour),
+		Limit:       10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []uuid.UUID{late}, ids(orders))
+
+	orders, total, err = repo.SearchOrders(ctx, domain.OrderSearchQuery{UserID: userID, CreatedTo: base, Limit: 10})
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, orders)
+}
+
+func TestPostgresOrderRepository_SellerLines(t *testing.T) {
+	db := setupTestDB()
+	repo := NewPostgresOrderRepository(db)
+	ctx := context.Background()
+
+	sellerID := uuid.New()
+	base := time.Date(2026, 5, 1, 22, 30, 0, 0, time.UTC)
+	create := func(status domain.OrderStatus, createdAt time.Time, price float64, qty int) (*domain.Order, uuid.UUID) {
+		order := &domain.Order{ID: uuid.New(), UserID: uuid.New(), Status: status, CreatedAt: createdAt, UpdatedAt: createdAt}
+		items := []domain.OrderItem{
+			{ID: uuid.New(), OrderID: order.ID, SellerID: sellerID, ProductName: "Mug", UnitPrice: price, Quantity: qty},
+			{ID: uuid.New(), OrderID: order.ID, SellerID: uuid.New(), ProductName: "Other", UnitPrice: 99, Quantity: 1},
+		}
+		assert.NoError(t, repo.CreateOrder(ctx, order, items, []domain.OrderAddress{{ID: uuid.New(), OrderID: order.ID, Kind: domain.AddressShipping, FullName: "Abebe"}}))
+		return order, items[0].ID
+	}
+	first, firstLine := create(domain.StatusPaid, base, 10, 2)
+	_, secondLine := create(domain.StatusDelivered, base.Add(2*time.Hour), 5, 1)
+	create(domain.StatusCanceled, base.Add(3*time.Hour), 100, 1)
+
+	lines, err := repo.ListSellerLines(ctx, domain.SellerLineQuery{SellerID: sellerID, OrderStatuses: domain.SalesStatuses, Limit: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, secondLine, lines[0].ID)
+		assert.Equal(t, domain.StatusDelivered, lines[0].OrderStatus)
+		assert.Equal(t, firstLine, lines[1].ID)
+		assert.Equal(t, domain.FulfillmentPending, lines[1].FulfillmentStatus)
+		assert.True(t, lines[1].OrderedAt.Equal(first.CreatedAt))
+	}
+
+	after := &domain.OrderCursor{CreatedAt: lines[0].OrderedAt, ID: lines[0].ID}
+	rest, err := repo.ListSellerLines(ctx, domain.SellerLineQuery{SellerID: sellerID, OrderStatuses: domain.SalesStatuses, Limit: 10, After: after})
+	assert.NoError(t, err)
+	if assert.Len(t, rest, 1) {
+		assert.Equal(t, firstLine, rest[0].ID)
+	}
+
+	at := base.Add(time.Hour)
+	assert.NoError(t, repo.UpdateLineFulfillment(ctx, first.ID, []uuid.UUID{firstLine}, domain.FulfillmentHandedOver, at))
+	assert.NoError(t, repo.UpdateLineFulfillment(ctx, first.ID, []uuid.UUID{firstLine}, domain.FulfillmentPacked, at.Add(time.Hour)), "moving back is a no-op")
+	order, err := repo.GetOrderByID(ctx, first.ID)
+	assert.NoError(t, err)
+	for _, item := range order.Items {
+		if item.ID == firstLine {
+			assert.Equal(t, domain.FulfillmentHandedOver, item.FulfillmentStatus)
+			if assert.NotNil(t, item.PackedAt) && assert.NotNil(t, item.HandedOverAt) {
+				assert.True(t, item.PackedAt.Equal(at))
+				assert.True(t, item.HandedOverAt.Equal(at))
+			}
+		}
+	}
+
+	handedOver, err := repo.ListSellerLines(ctx, domain.SellerLineQuery{SellerID: sellerID, FulfillmentStatuses: []domain.FulfillmentStatus{domain.FulfillmentHandedOver}, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, handedOver, 1)
+
+	sales, err := repo.SellerDailySales(ctx, domain.SellerSalesQuery{SellerID: sellerID, From: base.Add(-24 * time.Hour), To: base.Add(24 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.SellerDailySales{
+		{Day: "2026-05-01", Orders: 1, Units: 2, Revenue: 20},
+		{Day: "2026-05-02", Orders: 1, Units: 1, Revenue: 5},
+	}, sales)
+}
+
+func TestPostgresOrderRepository_AmendOrder(t *testing.T) {
+	db := setupTestDB()
+	repo := NewPostgresOrderRepository(db)
+	ctx := context.Background()
+
+	orderID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	order := &domain.Order{ID: orderID, UserID: uuid.New(), Status: domain.StatusPending, Currency: "ETB", Subtotal: 130, TotalAmount: 130, CreatedAt: now, UpdatedAt: now}
+	items := []domain.OrderItem{
+		{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 3},
+		{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), ProductName: "Cable", UnitPrice: 10, Quantity: 1},
+	}
+	addresses := []domain.OrderAddress{{ID: uuid.New(), OrderID: orderID, Kind: domain.AddressShipping, FullName: "Abebe Kebede", Phone: "+251911234567", Country: "ET", City: "Addis Ababa", Street: "Bole Road"}}
+	require.NoError(t, repo.CreateOrder(ctx, order, items, addresses))
+
+	amended, err := repo.GetOrderByID(ctx, orderID)
+	require.NoError(t, err)
+	cable := amended.Items[1]
+	if cable.ProductName != "Cable" {
+		cable = amended.Items[0]
+	}
+	amended.Items = []domain.OrderItem{items[0]}
+	amended.Items[0].Quantity = 1
+	amended.Addresses[0].Street = "Churchill Avenue"
+	amended.Discounts = []domain.OrderDiscount{{ID: uuid.New(), OrderID: orderID, PromotionID: uuid.New(), Amount: 4}}
+	amended.Subtotal, amended.DiscountAmount, amended.TotalAmount = 40, 4, 36
+	amendment := &domain.OrderAmendment{
+		ID: uuid.New(), OrderID: orderID, AmendedBy: order.UserID.String(),
+		Changes: []domain.AmendmentChange{
+			{Field: domain.AmendQuantity, ItemID: &items[0].ID, From: "3", To: "1"},
+			{Field: domain.AmendQuantity, ItemID: &cable.ID, From: "1", To: "0"},
+			{Field: domain.AmendShippingAddress, From: "Bole Road", To: "Churchill Avenue"},
+		},
+		PreviousTotal: 130, NewTotal: 36, AmendedAt: now,
+	}
+	require.NoError(t, repo.AmendOrder(ctx, amended, []uuid.UUID{cable.ID}, amendment))
+	assert.Equal(t, int64(2), amended.Version)
+
+	saved, err := repo.GetOrderByID(ctx, orderID)
+	require.NoError(t, err)
+	assert.Equal(t, 36.0, saved.TotalAmount)
+	assert.Equal(t, 4.0, saved.DiscountAmount)
+	require.Len(t, saved.Items, 1)
+	assert.Equal(t, 1, saved.Items[0].Quantity)
+	assert.Len(t, saved.Discounts, 1)
+	require.Len(t, saved.Addresses, 1)
+	assert.Equal(t, "Churchill Avenue", saved.Addresses[0].Street)
+
+	history, err := repo.ListAmendments(ctx, orderID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, amendment.Changes, history[0].Changes)
+	assert.Equal(t, 130.0, history[0].PreviousTotal)
+
+	// An amendment computed from an older version is refused.
+	err = repo.AmendOrder(ctx, order, nil, &domain.OrderAmendment{ID: uuid.New(), OrderID: orderID, AmendedAt: now})
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	// Once paid, the order is left alone.
+	require.NoError(t, repo.ChangeStatus(ctx, &domain.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: domain.StatusPaid, ChangedAt: now}, saved.Version))
+	saved.TotalAmount = 1
+	err = repo.AmendOrder(ctx, saved, nil, &domain.OrderAmendment{ID: uuid.New(), OrderID: orderID, AmendedAt: now})
+	assert.ErrorIs(t, err, domain.ErrOrderNotAmendable)
+	history, err = repo.ListAmendments(ctx, orderID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
 }
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresPromotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresPromotionRepository(db *gorm.DB) *PostgresPromotionRepository {
+	return &PostgresPromotionRepository{db: db}
+}
+
+func (r *PostgresPromotionRepository) CreatePromotion(ctx context.Context, promotion *domain.Promotion) error {
+	return dbError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if promotion.Code != "" {
+			var taken int64
+			if err := tx.Model(&domain.Promotion{}).Where("code = ?", promotion.Code).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken > 0 {
+				return domain.ErrPromotionCodeTaken
+			}
+		}
+		err := tx.Create(promotion).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrPromotionCodeTaken
+		}
+		return err
+	}))
+}
+
+func (r *PostgresPromotionRepository) GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	var promotion domain.Promotion
+	if err := r.db.WithContext(ctx).First(&promotion, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPromotionNotFound
+		}
+		return nil, dbError(err)
+	}
+	return &promotion, nil
+}
+
+func (r *PostgresPromotionRepository) ListPromotions(ctx context.Context, includeInactive bool) ([]domain.Promotion, error) {
+	tx := r.db.WithContext(ctx)
+	if !includeInactive {
+		tx = tx.Where("active = ?", true)
+	}
+	var promotions []domain.Promotion
+	if err := tx.Order("created_at DESC, id").Find(&promotions).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return promotions, nil
+}
+
+func (r *PostgresPromotionRepository) SetPromotionActive(ctx context.Context, id uuid.UUID, active bool) error {
+	res := r.db.WithContext(ctx).Model(&domain.Promotion{}).Where("id = ?", id).Update("active", active)
+	if res.Error != nil {
+		return dbError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresPromotionRepository) ApplicablePromotions(ctx context.Context, codes []string, at time.Time) ([]domain.Promotion, error) {
+	tx := r.db.WithContext(ctx).
+		Where("active = ?", true).
+		Where("starts_at IS NULL OR starts_at <= ?", at).
+		Where("ends_at IS NULL OR ends_at > ?", at)
+	if len(codes) > 0 {
+		tx = tx.Where("code = '' OR code IN ?", codes)
+	} else {
+		tx = tx.Where("code = ''")
+	}
+	var promotions []domain.Promotion
+	if err := tx.Order("created_at, id").Find(&promotions).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return promotions, nil
+}
+
+func (r *PostgresPromotionRepository) CountRedemptions(ctx context.Context, userID uuid.UUID, promotionIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := map[uuid.UUID]int{}
+	if len(promotionIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		PromotionID uuid.UUID
+		Count       int
+	}
+	err := r.db.WithContext(ctx).Model(&domain.PromotionRedemption{}).
+		Select("promotion_id, COUNT(*) AS count").
+		Where("user_id = ? AND promotion_id IN ?", userID, promotionIDs).
+		Group("promotion_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+	for _, row := range rows {
+		counts[row.PromotionID] = row.Count
+	}
+	return counts, nil
+}
+
+// redeemPromotions records the order's discounts and counts them against
+// the usage limits of their promotions. The increment locks the promotion
+// row, so concurrent orders of the same user are counted one at a time.
+func redeemPromotions(tx *gorm.DB, order *domain.Order) error {
+	if len(order.Discounts) == 0 {
+		return nil
+	}
+	for _, d := range order.Discounts {
+		res := tx.Model(&domain.Promotion{}).
+			Where("id = ? AND (max_uses = 0 OR uses < max_uses)", d.PromotionID).
+			Update("uses", gorm.Expr("uses + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrPromotionExhausted
+		}
+
+		var promotion domain.Promotion
+		if err := tx.Select("max_uses_per_user").First(&promotion, "id = ?", d.PromotionID).Error; err != nil {
+			return err
+		}
+		if promotion.MaxUsesPerUser > 0 {
+			var used int64
+			err := tx.Model(&domain.PromotionRedemption{}).
+				Where("promotion_id = ? AND user_id = ?", d.PromotionID, order.UserID).
+				Count(&used).Error
+			if err != nil {
+				return err
+			}
+			if used >= int64(promotion.MaxUsesPerUser) {
+				return domain.ErrPromotionExhausted
+			}
+		}
+
+		err := tx.Create(&domain.PromotionRedemption{
+			ID:          uuid.New(),
+			PromotionID: d.PromotionID,
+			UserID:      order.UserID,
+			OrderID:     order.ID,
+			RedeemedAt:  order.CreatedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Create(&order.Discounts).Error
+}
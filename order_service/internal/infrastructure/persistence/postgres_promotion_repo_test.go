@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromotion(code string, mutate func(*domain.Promotion)) *domain.Promotion {
+	p := &domain.Promotion{
+		ID:        uuid.New(),
+		Code:      code,
+		Kind:      domain.PromotionFixed,
+		Value:     5,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	if mutate != nil {
+		mutate(p)
+	}
+	return p
+}
+
+func TestPostgresPromotionRepository_ApplicablePromotions(t *testing.T) {
+	db := setupTestDB()
+	repo := NewPostgresPromotionRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	suffix := uuid.NewString()[:8]
+
+	coded := newPromotion("CODED"+suffix, nil)
+	otherCode := newPromotion("OTHER"+suffix, nil)
+	inactive := newPromotion("OFF"+suffix, func(p *domain.Promotion) { p.Active = false })
+	expired := newPromotion("OLD"+suffix, func(p *domain.Promotion) { p.EndsAt = &past })
+	upcoming := newPromotion("SOON"+suffix, func(p *domain.Promotion) { p.StartsAt = &future })
+	for _, p := range []*domain.Promotion{coded, otherCode, inactive, expired, upcoming} {
+		require.NoError(t, repo.CreatePromotion(ctx, p))
+	}
+	// Reserve the code a second time.
+	assert.ErrorIs(t, repo.CreatePromotion(ctx, newPromotion(coded.Code, nil)), domain.ErrPromotionCodeTaken)
+
+	got, err := repo.ApplicablePromotions(ctx, []string{coded.Code, inactive.Code, expired.Code, upcoming.Code}, now)
+	require.NoError(t, err)
+	var codes []string
+	for _, p := range got {
+		if p.Code != "" {
+			codes = append(codes, p.Code)
+		}
+	}
+	assert.Equal(t, []string{coded.Code}, codes)
+
+	require.NoError(t, repo.SetPromotionActive(ctx, inactive.ID, true))
+	p, err := repo.GetPromotion(ctx, inactive.ID)
+	require.NoError(t, err)
+	assert.True(t, p.Active)
+	assert.ErrorIs(t, repo.SetPromotionActive(ctx, uuid.New(), true), domain.ErrPromotionNotFound)
+}
+
+func TestPostgresOrderRepository_CreateOrder_RedeemsPromotions(t *testing.T) {
+	db := setupTestDB()
+	orders := NewPostgresOrderRepository(db)
+	promotions := NewPostgresPromotionRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	promotion := newPromotion("", func(p *domain.Promotion) { p.MaxUses = 3; p.MaxUsesPerUser = 1 })
+	require.NoError(t, promotions.CreatePromotion(ctx, promotion))
+
+	create := func(user uuid.UUID) (*domain.Order, error) {
+		orderID := uuid.New()
+		order := &domain.Order{ID: orderID, UserID: user, Status: domain.StatusCreated, Currency: "ETB", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		items := []domain.OrderItem{{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), SellerID: uuid.New(), UnitPrice: 20, Quantity: 1}}
+		order.Price(items, []domain.OrderDiscount{{ID: uuid.New(), OrderID: orderID, PromotionID: promotion.ID, Amount: 5}})
+		return order, orders.CreateOrder(ctx, order, items, []domain.OrderAddress{{ID: uuid.New(), OrderID: orderID, Kind: domain.AddressShipping}})
+	}
+
+	order, err := create(userID)
+	require.NoError(t, err)
+	stored, err := orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, stored.TotalAmount)
+	assert.Equal(t, 20.0, stored.Subtotal)
+	require.Len(t, stored.Discounts, 1)
+	assert.Equal(t, 5.0, stored.Discounts[0].Amount)
+
+	counts, err := promotions.CountRedemptions(ctx, userID, []uuid.UUID{promotion.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{promotion.ID: 1}, counts)
+
+	rejected, err := create(userID)
+	assert.ErrorIs(t, err, domain.ErrPromotionExhausted, "one use per user")
+	_, err = orders.GetOrderByID(ctx, rejected.ID)
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound, "the order is rolled back")
+
+	_, err = create(uuid.New())
+	require.NoError(t, err)
+	_, err = create(uuid.New())
+	require.NoError(t, err)
+	_, err = create(uuid.New())
+	assert.ErrorIs(t, err, domain.ErrPromotionExhausted, "three uses in total")
+
+	p, err := promotions.GetPromotion(ctx, promotion.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.Uses)
+}
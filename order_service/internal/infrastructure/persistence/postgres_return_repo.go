@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresReturnRepository struct {
+	db     *gorm.DB
+	router *Router
+}
+
+func NewPostgresReturnRepository(db *gorm.DB) *PostgresReturnRepository {
+	return NewRoutedReturnRepository(NewRouter(db, nil, RouterOptions{}))
+}
+
+// NewRoutedReturnRepository writes to the router's primary. Orders whose
+// status a return changes stick to the primary.
+func NewRoutedReturnRepository(router *Router) *PostgresReturnRepository {
+	return &PostgresReturnRepository{db: router.Primary(), router: router}
+}
+
+// CreateReturn locks the order's row while it counts the units already
+// returned, so concurrent returns of the order are checked one after the
+// other.
+func (r *PostgresReturnRepository) CreateReturn(ctx context.Context, ret *domain.OrderReturn, entry *domain.OrderStatusHistory) error {
+	if entry != nil {
+		defer r.router.Stick(ret.OrderID)
+	}
+	return dbError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order domain.Order
+		if err := forUpdate(tx.Select("id", "status"), "").First(&order, "id = ?", ret.OrderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrOrderNotFound
+			}
+			return err
+		}
+		var items []domain.OrderItem
+		if err := tx.Select("id", "quantity").Where("order_id = ?", ret.OrderID).Find(&items).Error; err != nil {
+			return err
+		}
+		var returned []domain.OrderReturnItem
+		err := tx.Select("order_return_items.order_item_id", "SUM(order_return_items.quantity) AS quantity").
+			Joins("JOIN order_returns ON order_returns.id = order_return_items.return_id").
+			Where("order_returns.order_id = ? AND order_returns.status <> ?", ret.OrderID, domain.ReturnRejected).
+			Group("order_return_items.order_item_id").Find(&returned).Error
+		if err != nil {
+			return err
+		}
+		remaining := make(map[uuid.UUID]int, len(items))
+		for _, item := range items {
+			remaining[item.ID] = item.Quantity
+		}
+		for _, item := range returned {
+			remaining[item.OrderItemID] -= item.Quantity
+		}
+		for _, item := range ret.Items {
+			if item.Quantity > remaining[item.OrderItemID] {
+				return fmt.Errorf("%w: %d units of item %s remain", domain.ErrReturnExceedsOrder, max(remaining[item.OrderItemID], 0), item.OrderItemID)
+			}
+		}
+
+		if err := tx.Omit("Items").Create(ret).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&ret.Items).Error; err != nil {
+			return err
+		}
+		if entry == nil || order.Status == entry.Status {
+			return nil
+		}
+		err = tx.Model(&domain.Order{}).Where("id = ?", ret.OrderID).Updates(map[string]any{
+			"status":     entry.Status,
+			"updated_at": entry.ChangedAt,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		entry.FromStatus = order.Status
+		return tx.Create(entry).Error
+	}))
+}
+
+func (r *PostgresReturnRepository) GetReturn(ctx context.Context, id uuid.UUID) (*domain.OrderReturn, error) {
+	var ret domain.OrderReturn
+	if err := r.db.WithContext(ctx).Preload("Items").First(&ret, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReturnNotFound
+		}
+		return nil, dbError(err)
+	}
+	return &ret, nil
+}
+
+func (r *PostgresReturnRepository) ListReturns(ctx context.Context, query domain.ReturnListQuery) ([]domain.OrderReturn, error) {
+	tx := r.db.WithContext(ctx).Preload("Items")
+	if query.OrderID != uuid.Nil {
+		tx = tx.Where("order_id = ?", query.OrderID)
+	}
+	if query.UserID != uuid.Nil {
+		tx = tx.Where("user_id = ?", query.UserID)
+	}
+	if len(query.Statuses) > 0 {
+		tx = tx.Where("status IN ?", query.Statuses)
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var returns []domain.OrderReturn
+	if err := tx.Order("requested_at DESC, id DESC").Find(&returns).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return returns, nil
+}
+
+func (r *PostgresReturnRepository) UpdateReturn(ctx context.Context, ret *domain.OrderReturn, from domain.ReturnStatus) error {
+	res := r.db.WithContext(ctx).Model(&domain.OrderReturn{}).
+		Where("id = ? AND status = ?", ret.ID, from).
+		Updates(map[string]any{
+			"status":        ret.Status,
+			"reject_reason": ret.RejectReason,
+			"decided_at":    ret.DecidedAt,
+			"picked_up_at":  ret.PickedUpAt,
+			"refunded_at":   ret.RefundedAt,
+			"updated_at":    ret.UpdatedAt,
+		})
+	if res.Error != nil {
+		return dbError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrReturnConflict
+	}
+	return nil
+}
+
+func (r *PostgresReturnRepository) DeliveredAt(ctx context.Context, orderID uuid.UUID) (*time.Time, error) {
+	var history []domain.OrderStatusHistory
+	err := r.db.WithContext(ctx).
+		Where("order_id = ? AND status = ?", orderID, domain.StatusDelivered).
+		Order("changed_at").Limit(1).Find(&history).Error
+	if err != nil || len(history) == 0 {
+		return nil, dbError(err)
+	}
+	return &history[0].ChangedAt, nil
+}
@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresReturnRepository(t *testing.T) {
+	db := setupTestDB()
+	orders := NewPostgresOrderRepository(db)
+	repo := NewPostgresReturnRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	orderID, userID := uuid.New(), uuid.New()
+	item := domain.OrderItem{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), SellerID: uuid.New(), UnitPrice: 40, Quantity: 3}
+	order := &domain.Order{ID: orderID, UserID: userID, Status: domain.StatusDelivered, Currency: "ETB", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, orders.CreateOrder(ctx, order, []domain.OrderItem{item}, []domain.OrderAddress{{ID: uuid.New(), OrderID: orderID, Kind: domain.AddressShipping}}))
+
+	delivered, err := repo.DeliveredAt(ctx, orderID)
+	require.NoError(t, err)
+	assert.Nil(t, delivered, "the order was never recorded as delivered")
+	for _, at := range []time.Time{now.Add(-time.Hour), now} {
+		require.NoError(t, orders.ChangeStatus(ctx, &domain.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, Status: domain.StatusDelivered, ChangedAt: at}, order.Version))
+		order.Version++
+	}
+	delivered, err = repo.DeliveredAt(ctx, orderID)
+	require.NoError(t, err)
+	require.NotNil(t, delivered)
+	assert.True(t, delivered.Equal(now.Add(-time.Hour)), "the first delivery counts")
+
+	newReturn := func(at time.Time) *domain.OrderReturn {
+		id := uuid.New()
+		return &domain.OrderReturn{
+			ID: id, OrderID: orderID, UserID: userID, Status: domain.ReturnRequested, Reason: "damaged",
+			RefundAmount: 40, RequestedAt: at, UpdatedAt: at,
+			Items: []domain.OrderReturnItem{{
+				ID: uuid.New(), ReturnID: id, OrderItemID: item.ID, ProductID: item.ProductID, SellerID: item.SellerID,
+				Quantity: 1, RefundAmount: 40, SaleAmount: 40,
+			}},
+		}
+	}
+	first, second := newReturn(now.Add(-time.Minute)), newReturn(now)
+	require.NoError(t, repo.CreateReturn(ctx, first, nil))
+	require.NoError(t, repo.CreateReturn(ctx, second, nil))
+
+	got, err := repo.GetReturn(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReturnRequested, got.Status)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, item.ID, got.Items[0].OrderItemID)
+	_, err = repo.GetReturn(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrReturnNotFound)
+
+	first.Status, first.RejectReason, first.DecidedAt = domain.ReturnRejected, "worn", &now
+	require.NoError(t, repo.UpdateReturn(ctx, first, domain.ReturnRequested))
+	assert.ErrorIs(t, repo.UpdateReturn(ctx, first, domain.ReturnRequested), domain.ErrReturnConflict, "the return was already decided")
+
+	listed, err := repo.ListReturns(ctx, domain.ReturnListQuery{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, second.ID, listed[0].ID, "newest first")
+	assert.Equal(t, "worn", listed[1].RejectReason)
+	assert.Len(t, listed[1].Items, 1)
+
+	listed, err = repo.ListReturns(ctx, domain.ReturnListQuery{OrderID: orderID, Statuses: []domain.ReturnStatus{domain.ReturnRequested}})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, second.ID, listed[0].ID)
+}
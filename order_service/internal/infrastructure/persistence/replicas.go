@@ -0,0 +1,210 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RouterOptions tune how a Router picks replicas.
+type RouterOptions struct {
+	// MaxLag is the replication lag beyond which a replica is not read from.
+	MaxLag time.Duration
+	// StickyWindow is how long reads of an order or user just written go to
+	// the primary, so that users see their own writes.
+	StickyWindow time.Duration
+	// CheckTimeout bounds one health check of a replica.
+	CheckTimeout time.Duration
+}
+
+// Router sends writes to the primary and spreads reads over the healthy
+// read replicas, round-robin. Reads fall back to the primary when no replica
+// is healthy or the chosen one fails, and for a while after the order or
+// user they concern was written.
+//
+// Only writes of this instance make IDs sticky; another instance may still
+// read them from a lagging replica. A read of given IDs that finds no record
+// on a replica is therefore run again on the primary, so that an order just
+// created elsewhere, as by an event consumer, is found; a stale record read
+// from a replica is not noticed.
+//
+// Reads that feed a write are safe on a lagging replica: the write carries
+// the version it read, fails with a version conflict, and the conflict makes
+// the order stick to the primary for the retry.
+type Router struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+	opts     RouterOptions
+
+	mu     sync.Mutex
+	sticky map[uuid.UUID]time.Time
+}
+
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// NewRouter routes between the primary and the replicas, named by their
+// index in logs. Replicas start healthy; Run keeps checking them.
+func NewRouter(primary *gorm.DB, replicas []*gorm.DB, opts RouterOptions) *Router {
+	if opts.CheckTimeout <= 0 {
+		opts.CheckTimeout = 2 * time.Second
+	}
+	r := &Router{primary: primary, opts: opts, sticky: map[uuid.UUID]time.Time{}}
+	for i, db := range replicas {
+		rep := &replica{name: fmt.Sprintf("replica %d", i), db: db}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+	return r
+}
+
+// Primary is the database writes go to.
+func (r *Router) Primary() *gorm.DB {
+	return r.primary
+}
+
+// Run checks the replicas every interval until ctx is done and forgets
+// expired sticky entries.
+func (r *Router) Run(ctx context.Context, interval time.Duration) {
+	if len(r.replicas) == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		r.CheckReplicas(ctx)
+		r.expireSticky()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckReplicas marks each replica healthy when it answers and lags less
+// than MaxLag.
+func (r *Router) CheckReplicas(ctx context.Context) {
+	for _, rep := range r.replicas {
+		err := r.check(ctx, rep.db)
+		if healthy := err == nil; rep.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.Printf("Database %s is healthy again", rep.name)
+			} else {
+				log.Printf("Database %s is unhealthy, reading from the others: %v", rep.name, err)
+			}
+		}
+	}
+}
+
+func (r *Router) check(ctx context.Context, db *gorm.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.CheckTimeout)
+	defer cancel()
+	// SQLite is only used by the tests and has no replication.
+	if db.Dialector.Name() == "sqlite" {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+
+	// A replica that replayed everything it received is current, however
+	// long ago the primary last wrote.
+	var lag float64
+	err := db.WithContext(ctx).Raw(`SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`).Scan(&lag).Error
+	if err != nil {
+		return err
+	}
+	if d := time.Duration(lag * float64(time.Second)); r.opts.MaxLag > 0 && d > r.opts.MaxLag {
+		return fmt.Errorf("replication lag %s exceeds %s", d.Round(time.Millisecond), r.opts.MaxLag)
+	}
+	return nil
+}
+
+// Stick sends reads of the IDs, orders or users, to the primary for the
+// sticky window.
+func (r *Router) Stick(ids ...uuid.UUID) {
+	if len(r.replicas) == 0 || r.opts.StickyWindow <= 0 {
+		return
+	}
+	until := time.Now().Add(r.opts.StickyWindow)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		if id != uuid.Nil {
+			r.sticky[id] = until
+		}
+	}
+}
+
+func (r *Router) stuck(ids []uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, id := range ids {
+		if until, ok := r.sticky[id]; ok && now.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Router) expireSticky() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for id, until := range r.sticky {
+		if !now.Before(until) {
+			delete(r.sticky, id)
+		}
+	}
+}
+
+// pick returns a healthy replica, taking turns, or nil.
+func (r *Router) pick() *replica {
+	n := uint64(len(r.replicas))
+	for range n {
+		rep := r.replicas[r.next.Add(1)%n]
+		if rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+// Read runs the read-only fn on a replica, unless the IDs it concerns are
+// sticky. A replica that cannot be reached is marked unhealthy until its next
+// check and fn runs again on the primary, as it does when fn finds no record
+// of the IDs on the replica.
+func (r *Router) Read(ctx context.Context, fn func(db *gorm.DB) error, ids ...uuid.UUID) error {
+	if len(r.replicas) > 0 && !r.stuck(ids) {
+		if rep := r.pick(); rep != nil {
+			err := fn(rep.db.WithContext(ctx))
+			if len(ids) > 0 && errors.Is(err, gorm.ErrRecordNotFound) {
+				return fn(r.primary.WithContext(ctx))
+			}
+			if err == nil || ctx.Err() != nil || !unreachable(err) {
+				return err
+			}
+			if rep.healthy.Swap(false) {
+				log.Printf("Database %s failed, reading from the primary: %v", rep.name, err)
+			}
+		}
+	}
+	return fn(r.primary.WithContext(ctx))
+}
@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openRouterDB opens an empty SQLite database with the order tables.
+func openRouterDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderAddress{}, &domain.OrderStatusHistory{}, &domain.OrderDiscount{}, &archivedOrderRow{}))
+	return db
+}
+
+// source tells which database a read ran on by the marker order each holds.
+func source(t *testing.T, router *Router, ids ...uuid.UUID) string {
+	var order domain.Order
+	require.NoError(t, router.Read(context.Background(), func(db *gorm.DB) error {
+		return db.First(&order).Error
+	}, ids...))
+	return string(order.Status)
+}
+
+func newRouterFixture(t *testing.T, n int, opts RouterOptions) *Router {
+	primary := openRouterDB(t)
+	require.NoError(t, primary.Create(&domain.Order{ID: uuid.New(), Status: "primary"}).Error)
+	var replicas []*gorm.DB
+	for i := range n {
+		replica := openRouterDB(t)
+		require.NoError(t, replica.Create(&domain.Order{ID: uuid.New(), Status: domain.OrderStatus(fmt.Sprintf("replica %d", i))}).Error)
+		replicas = append(replicas, replica)
+	}
+	return NewRouter(primary, replicas, opts)
+}
+
+func TestRouter_ReadsTakeTurnsOnReplicas(t *testing.T) {
+	router := newRouterFixture(t, 2, RouterOptions{})
+
+	seen := map[string]int{}
+	for range 4 {
+		seen[source(t, router)]++
+	}
+	assert.Equal(t, map[string]int{"replica 0": 2, "replica 1": 2}, seen)
+}
+
+func TestRouter_WithoutReplicasReadsPrimary(t *testing.T) {
+	router := newRouterFixture(t, 0, RouterOptions{StickyWindow: time.Minute})
+	router.Stick(uuid.New())
+	assert.Equal(t, "primary", source(t, router))
+}
+
+func TestRouter_StickyIDsReadPrimary(t *testing.T) {
+	router := newRouterFixture(t, 1, RouterOptions{StickyWindow: time.Minute})
+	userID := uuid.New()
+
+	router.Stick(userID)
+	assert.Equal(t, "primary", source(t, router, userID))
+	assert.Equal(t, "replica 0", source(t, router, uuid.New()))
+
+	// Expired entries read from replicas again.
+	router.mu.Lock()
+	router.sticky[userID] = time.Now().Add(-time.Second)
+	router.mu.Unlock()
+	assert.Equal(t, "replica 0", source(t, router, userID))
+	router.expireSticky()
+	assert.Empty(t, router.sticky)
+}
+
+func TestRouter_SkipsUnhealthyReplicas(t *testing.T) {
+	router := newRouterFixture(t, 2, RouterOptions{})
+
+	replicaDB, err := router.replicas[0].db.DB()
+	require.NoError(t, err)
+	require.NoError(t, replicaDB.Close())
+	router.CheckReplicas(context.Background())
+	assert.False(t, router.replicas[0].healthy.Load())
+	assert.True(t, router.replicas[1].healthy.Load())
+
+	for range 3 {
+		assert.Equal(t, "replica 1", source(t, router))
+	}
+}
+
+func TestRouter_FallsBackToPrimaryWhenReplicaFails(t *testing.T) {
+	router := newRouterFixture(t, 1, RouterOptions{})
+
+	// The connection breaks while the replica is still believed healthy; the
+	// failed read marks it and reruns on the primary.
+	require.NoError(t, router.replicas[0].db.Callback().Query().Before("gorm:query").Register("test:bad_conn", func(db *gorm.DB) {
+		db.AddError(driver.ErrBadConn)
+	}))
+	assert.Equal(t, "primary", source(t, router))
+	assert.False(t, router.replicas[0].healthy.Load())
+	assert.Equal(t, "primary", source(t, router))
+}
+
+func TestRoutedOrderRepository_ReadsOwnWrites(t *testing.T) {
+	primary, replica := openRouterDB(t), openRouterDB(t)
+	repo := NewRoutedOrderRepository(NewRouter(primary, []*gorm.DB{replica}, RouterOptions{StickyWindow: time.Minute}))
+	ctx := context.Background()
+
+	orderID := uuid.New()
+	order := &domain.Order{ID: orderID, UserID: uuid.New(), Status: domain.StatusPending, Currency: "ETB", Subtotal: 40, TotalAmount: 40}
+	items := []domain.OrderItem{{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), SellerID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 1}}
+	addresses := []domain.OrderAddress{{ID: uuid.New(), OrderID: orderID, Kind: domain.AddressShipping, FullName: "Abebe Kebede",
+		Phone: "+251911234567", Country: "ET", City: "Addis Ababa", Street: "Bole Road"}}
+	require.NoError(t, repo.CreateOrder(ctx, order, items, addresses))
+
+	// The replica has not caught up, yet the creator sees the order.
+	got, err := repo.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, got.ID)
+	orders, err := repo.ListOrders(ctx, domain.OrderListQuery{UserID: order.UserID, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+
+	// Others read the replica.
+	orders, err = repo.ListOrders(ctx, domain.OrderListQuery{UserID: uuid.New(), Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+}
+
+func TestRoutedOrderRepository_ReadsPrimaryWhenReplicaLacksOrder(t *testing.T) {
+	primary, replica := openRouterDB(t), openRouterDB(t)
+	ctx := context.Background()
+
+	// Created by another instance: this one's router has no sticky entry.
+	orderID := uuid.New()
+	order := &domain.Order{ID: orderID, UserID: uuid.New(), Status: domain.StatusPending, Currency: "ETB", Subtotal: 40, TotalAmount: 40}
+	items := []domain.OrderItem{{ID: uuid.New(), OrderID: orderID, ProductID: uuid.New(), SellerID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 1}}
+	addresses := []domain.OrderAddress{{ID: uuid.New(), OrderID: orderID, Kind: domain.AddressShipping, FullName: "Abebe Kebede", Country: "ET", City: "Addis Ababa"}}
+	require.NoError(t, NewPostgresOrderRepository(primary).CreateOrder(ctx, order, items, addresses))
+
+	repo := NewRoutedOrderRepository(NewRouter(primary, []*gorm.DB{replica}, RouterOptions{StickyWindow: time.Minute}))
+	got, err := repo.GetOrderByID(ctx, orderID)
+	require.NoError(t, err)
+	assert.Equal(t, orderID, got.ID)
+
+	_, err = repo.GetOrderByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresUserDataRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresUserDataRepository(db *gorm.DB) *PostgresUserDataRepository {
+	return &PostgresUserDataRepository{db: db}
+}
+
+// UserData reads the live orders before the archived ones: an order archived
+// in between is then read twice rather than missed.
+func (r *PostgresUserDataRepository) UserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	db := r.db.WithContext(ctx)
+	data := &domain.UserData{UserID: userID}
+	var ids []uuid.UUID
+	if err := db.Model(&domain.Order{}).Where("user_id = ?", userID).Pluck("id", &ids).Error; err != nil {
+		return nil, dbError(err)
+	}
+	seen := make(map[uuid.UUID]bool)
+	if len(ids) > 0 {
+		live, err := loadArchive(db, ids)
+		if err != nil {
+			return nil, dbError(err)
+		}
+		for _, doc := range live {
+			seen[doc.Order.ID] = true
+			data.Orders = append(data.Orders, *doc)
+		}
+	}
+	var rows []archivedOrderRow
+	if err := db.Select("id", "document").Where("user_id = ?", userID).Find(&rows).Error; err != nil {
+		return nil, dbError(err)
+	}
+	for _, row := range rows {
+		if !seen[row.ID] {
+			data.Orders = append(data.Orders, row.Document)
+		}
+	}
+	slices.SortFunc(data.Orders, func(a, b domain.ArchivedOrder) int {
+		if c := a.Order.CreatedAt.Compare(b.Order.CreatedAt); c != 0 {
+			return c
+		}
+		return slices.Compare(a.Order.ID[:], b.Order.ID[:])
+	})
+
+	if err := db.Where("user_id = ?", userID).Order("redeemed_at, id").Find(&data.Redemptions).Error; err != nil {
+		return nil, dbError(err)
+	}
+	return data, nil
+}
+
+func (r *PostgresUserDataRepository) EraseUser(ctx context.Context, erasure *domain.UserErasure) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the live orders keeps archival from copying addresses
+		// about to be erased; archival skips locked orders.
+		var ids []uuid.UUID
+		err := forUpdate(tx.Model(&domain.Order{}), "").Where("user_id = ?", erasure.UserID).Order("id").Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			err := tx.Model(&domain.OrderAddress{}).Where("order_id IN ?", ids).Updates(map[string]any{
+				"full_name": domain.ErasedValue, "phone": "", "street": "", "address_line2": "", "postal_code": "",
+				"latitude": nil, "longitude": nil,
+			}).Error
+			if err != nil {
+				return err
+			}
+			var amendments []domain.OrderAmendment
+			if err := tx.Where("order_id IN ?", ids).Find(&amendments).Error; err != nil {
+				return err
+			}
+			for i := range amendments {
+				if !amendments[i].Erase() {
+					continue
+				}
+				if err := tx.Model(&amendments[i]).Select("changes").Updates(&amendments[i]).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		var rows []archivedOrderRow
+		if err := forUpdate(tx, "").Where("user_id = ?", erasure.UserID).Order("id").Find(&rows).Error; err != nil {
+			return err
+		}
+		for i := range rows {
+			rows[i].Document.Erase()
+			if err := tx.Model(&rows[i]).Select("document").Updates(&rows[i]).Error; err != nil {
+				return err
+			}
+			ids = append(ids, rows[i].ID)
+		}
+
+		erasure.OrderIDs, erasure.Orders = ids, len(ids)
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(erasure).Error
+	})
+	return dbError(err)
+}
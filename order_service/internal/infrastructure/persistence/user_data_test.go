@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPostgresUserDataRepository(t *testing.T) {
+	// A database of its own, so that archiving takes only these orders.
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, db.AutoMigrate(&domain.Order{}, &domain.OrderItem{}, &domain.OrderAddress{}, &domain.OrderStatusHistory{},
+		&domain.PromotionRedemption{}, &domain.OrderDiscount{}, &domain.OrderReturn{}, &domain.OrderReturnItem{},
+		&domain.OrderAmendment{}, &archivedOrderRow{}, &domain.UserErasure{}))
+
+	orders := NewPostgresOrderRepository(db)
+	repo := NewPostgresUserDataRepository(db)
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+	start := time.Now().UTC().Add(-2 * 365 * 24 * time.Hour).Truncate(time.Second)
+
+	create := func(userID uuid.UUID, status domain.OrderStatus, createdAt time.Time) *domain.Order {
+		id := uuid.New()
+		lat := 9.01
+		order := &domain.Order{ID: id, UserID: userID, Status: status, Currency: "ETB", TotalAmount: 40, CreatedAt: createdAt, UpdatedAt: createdAt}
+		items := []domain.OrderItem{{ID: uuid.New(), OrderID: id, ProductID: uuid.New(), SellerID: uuid.New(), ProductName: "Lamp", UnitPrice: 40, Quantity: 1}}
+		addresses := []domain.OrderAddress{{ID: uuid.New(), OrderID: id, Kind: domain.AddressShipping, FullName: "Abebe Kebede", Phone: "+251911234567",
+			Country: "ET", Region: "Addis Ababa", City: "Addis Ababa", Street: "Bole Road", PostalCode: "1000", Latitude: &lat}}
+		require.NoError(t, orders.CreateOrder(ctx, order, items, addresses))
+		return order
+	}
+	archived := create(userID, domain.StatusDelivered, start)
+	live := create(userID, domain.StatusCreated, start.Add(time.Hour))
+	other := create(otherID, domain.StatusCreated, start.Add(time.Hour))
+	_, err = NewPostgresArchiveRepository(db).ArchiveOrders(ctx, domain.ArchiveQuery{Before: start.Add(time.Minute), Limit: 10})
+	require.NoError(t, err)
+
+	amendment := &domain.OrderAmendment{ID: uuid.New(), OrderID: live.ID, AmendedBy: userID.String(), AmendedAt: start.Add(2 * time.Hour),
+		Changes: []domain.AmendmentChange{
+			{Field: domain.AmendShippingAddress, From: "Abebe Kebede, +251911234567, Bole Road", To: "Abebe Kebede, +251911234567, CMC Road"},
+			{Field: domain.AmendQuantity, From: "1", To: "2"},
+		}}
+	require.NoError(t, db.Create(amendment).Error)
+	redemption := &domain.PromotionRedemption{ID: uuid.New(), PromotionID: uuid.New(), UserID: userID, OrderID: archived.ID, RedeemedAt: start}
+	require.NoError(t, db.Create(redemption).Error)
+
+	data, err := repo.UserData(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, data.Orders, 2)
+	assert.Equal(t, archived.ID, data.Orders[0].Order.ID, "oldest first")
+	assert.False(t, data.Orders[0].ArchivedAt.IsZero())
+	assert.Equal(t, live.ID, data.Orders[1].Order.ID)
+	assert.True(t, data.Orders[1].ArchivedAt.IsZero())
+	require.Len(t, data.Orders[1].Order.Addresses, 1)
+	assert.Equal(t, "Abebe Kebede", data.Orders[1].Order.Addresses[0].FullName)
+	assert.Len(t, data.Orders[1].Amendments, 1)
+	require.Len(t, data.Redemptions, 1)
+	assert.Equal(t, redemption.ID, data.Redemptions[0].ID)
+
+	erasure := &domain.UserErasure{UserID: userID, ErasedBy: domain.Actor{Type: domain.ActorService, ID: "user-service"}, ErasedAt: time.Now().UTC()}
+	require.NoError(t, repo.EraseUser(ctx, erasure))
+	assert.Equal(t, 2, erasure.Orders)
+	assert.ElementsMatch(t, []uuid.UUID{archived.ID, live.ID}, erasure.OrderIDs)
+
+	data, err = repo.UserData(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, data.Orders, 2)
+	for _, doc := range data.Orders {
+		require.Len(t, doc.Order.Addresses, 1)
+		address := doc.Order.Addresses[0]
+		assert.Equal(t, domain.ErasedValue, address.FullName)
+		assert.Empty(t, address.Phone)
+		assert.Empty(t, address.Street)
+		assert.Empty(t, address.PostalCode)
+		assert.Nil(t, address.Latitude)
+		assert.Equal(t, "Addis Ababa", address.City, "kept for tax records")
+		assert.Equal(t, 40.0, doc.Order.TotalAmount)
+	}
+	changes := data.Orders[1].Amendments[0].Changes
+	assert.Equal(t, domain.ErasedValue, changes[0].From)
+	assert.Equal(t, domain.ErasedValue, changes[0].To)
+	assert.Equal(t, "2", changes[1].To, "quantity changes are kept")
+
+	// Orders of other users are untouched.
+	got, err := orders.GetOrderByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Abebe Kebede", got.Addresses[0].FullName)
+
+	// Erasing again replaces the record.
+	again := &domain.UserErasure{UserID: userID, ErasedBy: domain.Actor{Type: domain.ActorUser, ID: "admin-1"}, ErasedAt: time.Now().UTC()}
+	require.NoError(t, repo.EraseUser(ctx, again))
+	var records []domain.UserErasure
+	require.NoError(t, db.Find(&records).Error)
+	require.Len(t, records, 1)
+	assert.Equal(t, "admin-1", records[0].ErasedBy.ID)
+	assert.Equal(t, 2, records[0].Orders)
+}
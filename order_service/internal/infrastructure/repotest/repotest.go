@@ -0,0 +1,496 @@
+// Package repotest is the conformance suite of domain.OrderRepository and,
+// where implemented, domain.ReturnRepository. Every implementation runs it
+// from its own tests, so the database and in-memory repositories cannot
+// drift apart in what they promise the use cases.
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories are the implementations under test. Promotions must share the
+// orders' storage, since placing an order redeems its promotions, and so
+// must Returns, which is optional.
+type Repositories struct {
+	Orders     domain.OrderRepository
+	Promotions domain.PromotionRepository
+	Returns    domain.ReturnRepository
+}
+
+// TestOrderRepository runs the suite. open returns the repositories each
+// subtest works on; they may share storage with other subtests, which only
+// look at the orders they create.
+func TestOrderRepository(t *testing.T, open func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos Repositories)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"NotFound", testNotFound},
+		{"CreateIsAtomic", testCreateIsAtomic},
+		{"ChangeStatus", testChangeStatus},
+		{"ConcurrentStatusChanges", testConcurrentStatusChanges},
+		{"AmendOrder", testAmendOrder},
+		{"ListOrders", testListOrders},
+		{"SearchOrders", testSearchOrders},
+		{"SellerLines", testSellerLines},
+		{"ConcurrentReturns", testConcurrentReturns},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, open(t))
+		})
+	}
+}
+
+// base is the creation time of the suite's orders, in whole seconds so it
+// survives any database's timestamp precision.
+var base = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+// newOrder returns an unsaved pending order of the user placed at base plus
+// offset, with one line of the seller and a shipping address in Addis Ababa.
+func newOrder(userID, sellerID uuid.UUID, offset time.Duration) (*domain.Order, []domain.OrderItem, []domain.OrderAddress) {
+	id := uuid.New()
+	at := base.Add(offset)
+	order := &domain.Order{
+		ID: id, UserID: userID, Status: domain.StatusPending, Currency: "ETB",
+		Subtotal: 80, TotalAmount: 80, CreatedAt: at, UpdatedAt: at,
+	}
+	items := []domain.OrderItem{{
+		ID: uuid.New(), OrderID: id, ProductID: uuid.New(), SellerID: sellerID,
+		ProductName: "Lamp", UnitPrice: 40, Quantity: 2,
+	}}
+	addresses := []domain.OrderAddress{{
+		ID: uuid.New(), OrderID: id, Kind: domain.AddressShipping, FullName: "Abebe Kebede",
+		Phone: "+251911234567", Country: "ET", City: "Addis Ababa", Street: "Bole Road",
+	}}
+	return order, items, addresses
+}
+
+func create(t *testing.T, repos Repositories, userID, sellerID uuid.UUID, offset time.Duration) *domain.Order {
+	t.Helper()
+	order, items, addresses := newOrder(userID, sellerID, offset)
+	require.NoError(t, repos.Orders.CreateOrder(context.Background(), order, items, addresses))
+	return order
+}
+
+// changeStatus moves the order to status from the version it is at.
+func changeStatus(t *testing.T, repos Repositories, orderID uuid.UUID, status domain.OrderStatus) *domain.OrderStatusHistory {
+	t.Helper()
+	ctx := context.Background()
+	order, err := repos.Orders.GetOrderByID(ctx, orderID)
+	require.NoError(t, err)
+	entry := &domain.OrderStatusHistory{ID: uuid.New(), OrderID: orderID, FromStatus: order.Status, Status: status, ChangedAt: base.Add(time.Hour)}
+	require.NoError(t, repos.Orders.ChangeStatus(ctx, entry, order.Version))
+	return entry
+}
+
+func testCreateAndGet(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	promotion := &domain.Promotion{ID: uuid.New(), Kind: domain.PromotionFixed, Value: 5, Active: true, CreatedAt: base}
+	require.NoError(t, repos.Promotions.CreatePromotion(ctx, promotion))
+
+	order, items, addresses := newOrder(uuid.New(), uuid.New(), 0)
+	order.Discounts = []domain.OrderDiscount{{ID: uuid.New(), OrderID: order.ID, PromotionID: promotion.ID, Amount: 5}}
+	require.NoError(t, repos.Orders.CreateOrder(ctx, order, items, addresses))
+	assert.Equal(t, int64(1), order.Version)
+
+	got, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, order.UserID, got.UserID)
+	assert.Equal(t, domain.StatusPending, got.Status)
+	assert.Equal(t, int64(1), got.Version)
+	assert.True(t, base.Equal(got.CreatedAt))
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, items[0].ID, got.Items[0].ID)
+	assert.Equal(t, domain.FulfillmentPending, got.Items[0].FulfillmentStatus)
+	require.Len(t, got.Discounts, 1)
+	assert.Equal(t, promotion.ID, got.Discounts[0].PromotionID)
+	require.Len(t, got.Addresses, 1)
+	assert.Equal(t, "Bole Road", got.Addresses[0].Street)
+
+	counts, err := repos.Promotions.CountRedemptions(ctx, order.UserID, []uuid.UUID{promotion.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{promotion.ID: 1}, counts)
+
+	// The returned order is a copy.
+	got.Status = domain.StatusCanceled
+	again, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, again.Status)
+}
+
+func testNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	missing := uuid.New()
+
+	_, err := repos.Orders.GetOrderByID(ctx, missing)
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	err = repos.Orders.ChangeStatus(ctx, &domain.OrderStatusHistory{ID: uuid.New(), OrderID: missing, Status: domain.StatusPaid, ChangedAt: base}, 1)
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	err = repos.Orders.AmendOrder(ctx, &domain.Order{ID: missing, Version: 1}, nil, &domain.OrderAmendment{ID: uuid.New(), OrderID: missing, AmendedAt: base})
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	history, err := repos.Orders.ListStatusHistory(ctx, missing)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+	amendments, err := repos.Orders.ListAmendments(ctx, missing)
+	require.NoError(t, err)
+	assert.Empty(t, amendments)
+
+	_, err = repos.Promotions.GetPromotion(ctx, missing)
+	assert.ErrorIs(t, err, domain.ErrPromotionNotFound)
+}
+
+// testCreateIsAtomic places an order whose second promotion is used up: the
+// order, its lines and the first promotion's redemption are all rolled back.
+func testCreateIsAtomic(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	open := &domain.Promotion{ID: uuid.New(), Kind: domain.PromotionFixed, Value: 5, Stackable: true, Active: true, CreatedAt: base}
+	usedUp := &domain.Promotion{ID: uuid.New(), Kind: domain.PromotionFixed, Value: 5, Stackable: true, Active: true, MaxUses: 1, Uses: 1, CreatedAt: base}
+	require.NoError(t, repos.Promotions.CreatePromotion(ctx, open))
+	require.NoError(t, repos.Promotions.CreatePromotion(ctx, usedUp))
+
+	order, items, addresses := newOrder(uuid.New(), uuid.New(), 0)
+	order.Discounts = []domain.OrderDiscount{
+		{ID: uuid.New(), OrderID: order.ID, PromotionID: open.ID, Amount: 5},
+		{ID: uuid.New(), OrderID: order.ID, PromotionID: usedUp.ID, Amount: 5},
+	}
+	err := repos.Orders.CreateOrder(ctx, order, items, addresses)
+	assert.ErrorIs(t, err, domain.ErrPromotionExhausted)
+
+	_, err = repos.Orders.GetOrderByID(ctx, order.ID)
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+	lines, err := repos.Orders.ListSellerLines(ctx, domain.SellerLineQuery{SellerID: items[0].SellerID, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+	p, err := repos.Promotions.GetPromotion(ctx, open.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, p.Uses)
+	counts, err := repos.Promotions.CountRedemptions(ctx, order.UserID, []uuid.UUID{open.ID})
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+func testChangeStatus(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	userID := uuid.New()
+	order := create(t, repos, userID, uuid.New(), 0)
+	other := create(t, repos, userID, uuid.New(), time.Minute)
+
+	paid := &domain.OrderStatusHistory{
+		ID: uuid.New(), OrderID: order.ID, FromStatus: domain.StatusPending, Status: domain.StatusPaid,
+		Actor:         domain.Actor{Type: domain.ActorService, ID: "payment_service"},
+		SourceEventID: "evt-1", Reason: "payment succeeded",
+		Metadata:  map[string]string{"payment_id": "pay-1"},
+		ChangedAt: base.Add(time.Hour),
+	}
+	require.NoError(t, repos.Orders.ChangeStatus(ctx, paid, 1))
+	otherPaid := changeStatus(t, repos, other.ID, domain.StatusPaid)
+	delivered := changeStatus(t, repos, order.ID, domain.StatusDelivered)
+	assert.Greater(t, otherPaid.Sequence, paid.Sequence)
+	assert.Greater(t, delivered.Sequence, otherPaid.Sequence)
+
+	got, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusDelivered, got.Status)
+	assert.Equal(t, int64(3), got.Version)
+
+	history, err := repos.Orders.ListStatusHistory(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, domain.StatusPending, history[0].FromStatus)
+	assert.Equal(t, domain.StatusPaid, history[0].Status)
+	assert.Equal(t, paid.Actor, history[0].Actor)
+	assert.Equal(t, "evt-1", history[0].SourceEventID)
+	assert.Equal(t, "payment succeeded", history[0].Reason)
+	assert.Equal(t, paid.Metadata, history[0].Metadata)
+	assert.Equal(t, paid.Sequence, history[0].Sequence)
+	assert.Equal(t, domain.StatusDelivered, history[1].Status)
+
+	changes, err := repos.Orders.ListStatusChanges(ctx, domain.StatusChangeQuery{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Equal(t, []uuid.UUID{order.ID, other.ID, order.ID}, []uuid.UUID{changes[0].OrderID, changes[1].OrderID, changes[2].OrderID})
+	assert.Equal(t, userID, changes[0].UserID)
+
+	changes, err = repos.Orders.ListStatusChanges(ctx, domain.StatusChangeQuery{OrderID: order.ID, AfterSequence: paid.Sequence})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, domain.StatusDelivered, changes[0].Status)
+
+	changes, err = repos.Orders.ListStatusChanges(ctx, domain.StatusChangeQuery{UserID: userID, Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, changes, 1)
+}
+
+// testConcurrentStatusChanges races writers that all loaded version 1:
+// exactly one wins, and the others record nothing.
+func testConcurrentStatusChanges(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	order := create(t, repos, uuid.New(), uuid.New(), 0)
+
+	const writers = 8
+	statuses := []domain.OrderStatus{domain.StatusPaid, domain.StatusCanceled}
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entry := &domain.OrderStatusHistory{ID: uuid.New(), OrderID: order.ID, FromStatus: domain.StatusPending, Status: statuses[i%2], ChangedAt: base.Add(time.Hour)}
+			errs[i] = repos.Orders.ChangeStatus(ctx, entry, 1)
+		}()
+	}
+	wg.Wait()
+
+	won := 0
+	for _, err := range errs {
+		if err == nil {
+			won++
+			continue
+		}
+		var conflict *domain.VersionConflictError
+		if assert.ErrorAs(t, err, &conflict) {
+			assert.Equal(t, order.ID, conflict.OrderID)
+		}
+	}
+	assert.Equal(t, 1, won)
+
+	got, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got.Version)
+	history, err := repos.Orders.ListStatusHistory(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, got.Status, history[0].Status)
+}
+
+func testAmendOrder(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	order := create(t, repos, uuid.New(), uuid.New(), 0)
+	amend := func(o *domain.Order) error {
+		return repos.Orders.AmendOrder(ctx, o, nil, &domain.OrderAmendment{ID: uuid.New(), OrderID: o.ID, AmendedAt: base.Add(time.Minute)})
+	}
+
+	stale, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	fresh, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+
+	fresh.Items[0].Quantity = 1
+	fresh.Addresses[0].Street = "Churchill Avenue"
+	fresh.Subtotal, fresh.TotalAmount = 40, 40
+	require.NoError(t, amend(fresh))
+	assert.Equal(t, int64(2), fresh.Version)
+
+	got, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, got.TotalAmount)
+	assert.Equal(t, 1, got.Items[0].Quantity)
+	assert.Equal(t, "Churchill Avenue", got.Addresses[0].Street)
+	assert.Equal(t, int64(2), got.Version)
+
+	stale.TotalAmount = 1
+	assert.ErrorIs(t, amend(stale), domain.ErrVersionConflict)
+
+	changeStatus(t, repos, order.ID, domain.StatusPaid)
+	got, err = repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.ErrorIs(t, amend(got), domain.ErrOrderNotAmendable)
+
+	amendments, err := repos.Orders.ListAmendments(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Len(t, amendments, 1, "refused amendments are not recorded")
+	got, err = repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, got.TotalAmount)
+}
+
+func testListOrders(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	userID := uuid.New()
+	oldest := create(t, repos, userID, uuid.New(), 0)
+	middle := create(t, repos, userID, uuid.New(), time.Hour)
+	newest := create(t, repos, userID, uuid.New(), 2*time.Hour)
+	create(t, repos, uuid.New(), uuid.New(), time.Hour)
+
+	page, err := repos.Orders.ListOrders(ctx, domain.OrderListQuery{UserID: userID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, newest.ID, page[0].ID)
+	assert.Equal(t, middle.ID, page[1].ID)
+	assert.Len(t, page[0].Items, 1)
+
+	page, err = repos.Orders.ListOrders(ctx, domain.OrderListQuery{
+		UserID: userID, Limit: 2,
+		After: &domain.OrderCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, oldest.ID, page[0].ID)
+}
+
+func testSearchOrders(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	sellerID := uuid.New()
+	cheap := create(t, repos, uuid.New(), sellerID, 0)
+	dear, items, addresses := newOrder(uuid.New(), sellerID, time.Hour)
+	dear.TotalAmount = 500
+	addresses[0].City, addresses[0].Phone = "Hawassa", "+251922000111"
+	require.NoError(t, repos.Orders.CreateOrder(ctx, dear, items, addresses))
+	create(t, repos, uuid.New(), uuid.New(), 0)
+
+	orders, total, err := repos.Orders.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, SortBy: domain.SortByTotalAmount, Descending: true, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, orders, 2)
+	assert.Equal(t, dear.ID, orders[0].ID)
+	assert.Equal(t, cheap.ID, orders[1].ID)
+
+	orders, total, err = repos.Orders.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, orders, 1)
+	assert.Equal(t, dear.ID, orders[0].ID, "oldest first by default")
+
+	orders, _, err = repos.Orders.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, City: "hawassa", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, dear.ID, orders[0].ID)
+
+	orders, _, err = repos.Orders.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, PhoneFragment: "922000", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+
+	minTotal := 100.0
+	_, total, err = repos.Orders.SearchOrders(ctx, domain.OrderSearchQuery{SellerID: sellerID, MinTotal: &minTotal, Statuses: []domain.OrderStatus{domain.StatusPaid}, Limit: 10})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func testSellerLines(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	sellerID := uuid.New()
+	first := create(t, repos, uuid.New(), sellerID, 0)
+	second := create(t, repos, uuid.New(), sellerID, 24*time.Hour)
+	changeStatus(t, repos, first.ID, domain.StatusPaid)
+	changeStatus(t, repos, second.ID, domain.StatusPaid)
+
+	lines, err := repos.Orders.ListSellerLines(ctx, domain.SellerLineQuery{SellerID: sellerID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, second.ID, lines[0].OrderID, "newest order first")
+	assert.Equal(t, domain.StatusPaid, lines[0].OrderStatus)
+
+	at := base.Add(48 * time.Hour)
+	handedOver := []uuid.UUID{lines[0].ID}
+	require.NoError(t, repos.Orders.UpdateLineFulfillment(ctx, second.ID, handedOver, domain.FulfillmentHandedOver, at))
+	// Packing a handed over line does not move it back, and lines of other
+	// orders are left alone.
+	require.NoError(t, repos.Orders.UpdateLineFulfillment(ctx, second.ID, []uuid.UUID{lines[0].ID, lines[1].ID}, domain.FulfillmentPacked, at))
+	packedQuery := domain.SellerLineQuery{SellerID: sellerID, FulfillmentStatuses: []domain.FulfillmentStatus{domain.FulfillmentPacked}, Limit: 10}
+	packed, err := repos.Orders.ListSellerLines(ctx, packedQuery)
+	require.NoError(t, err)
+	assert.Empty(t, packed)
+
+	require.NoError(t, repos.Orders.UpdateLineFulfillment(ctx, first.ID, []uuid.UUID{lines[1].ID}, domain.FulfillmentPacked, at))
+	packed, err = repos.Orders.ListSellerLines(ctx, packedQuery)
+	require.NoError(t, err)
+	require.Len(t, packed, 1)
+	assert.Equal(t, first.ID, packed[0].OrderID)
+
+	next, err := repos.Orders.ListSellerLines(ctx, domain.SellerLineQuery{
+		SellerID: sellerID, Limit: 10,
+		After: &domain.OrderCursor{CreatedAt: lines[0].OrderedAt, ID: lines[0].ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, next, 1)
+	assert.Equal(t, first.ID, next[0].OrderID)
+
+	sales, err := repos.Orders.SellerDailySales(ctx, domain.SellerSalesQuery{SellerID: sellerID, From: base, To: base.Add(72 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SellerDailySales{
+		{Day: "2026-05-01", Orders: 1, Units: 2, Revenue: 80},
+		{Day: "2026-05-02", Orders: 1, Units: 2, Revenue: 80},
+	}, sales)
+}
+
+func testConcurrentReturns(t *testing.T, repos Repositories) {
+	if repos.Returns == nil {
+		t.Skip("no return repository")
+	}
+	ctx := context.Background()
+	order := create(t, repos, uuid.New(), uuid.New(), 0)
+	got, err := repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	item := got.Items[0]
+	newReturn := func(status domain.ReturnStatus, quantity int) *domain.OrderReturn {
+		id := uuid.New()
+		return &domain.OrderReturn{
+			ID: id, OrderID: order.ID, UserID: order.UserID, Status: status, Reason: "broken",
+			Items:       []domain.OrderReturnItem{{ID: uuid.New(), ReturnID: id, OrderItemID: item.ID, Quantity: quantity}},
+			RequestedAt: base, UpdatedAt: base,
+		}
+	}
+
+	// Rejected returns leave their units to be returned.
+	require.NoError(t, repos.Returns.CreateReturn(ctx, newReturn(domain.ReturnRejected, item.Quantity), nil))
+
+	// Each request also moves the order to RETURN_REQUESTED.
+	const requests = 8
+	errs := make([]error, requests)
+	entries := make([]*domain.OrderStatusHistory, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		entries[i] = &domain.OrderStatusHistory{
+			ID: uuid.New(), OrderID: order.ID, Status: domain.StatusReturnRequested,
+			Actor: domain.Actor{Type: domain.ActorUser, ID: order.UserID.String()}, ChangedAt: base,
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = repos.Returns.CreateReturn(ctx, newReturn(domain.ReturnRequested, 1), entries[i])
+		}()
+	}
+	wg.Wait()
+
+	created, recorded := 0, 0
+	for i, err := range errs {
+		if entries[i].Sequence != 0 {
+			recorded++
+		}
+		if err == nil {
+			created++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrReturnExceedsOrder)
+	}
+	assert.Equal(t, item.Quantity, created, "one return per unit ordered")
+	assert.Equal(t, 1, recorded, "the order moves once")
+
+	got, err = repos.Orders.GetOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusReturnRequested, got.Status)
+	assert.Equal(t, order.Version+1, got.Version)
+	history, err := repos.Orders.ListStatusHistory(ctx, order.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+	last := history[len(history)-1]
+	assert.Equal(t, domain.StatusReturnRequested, last.Status)
+	assert.Equal(t, order.Status, last.FromStatus)
+
+	returns, err := repos.Returns.ListReturns(ctx, domain.ReturnListQuery{OrderID: order.ID, Statuses: []domain.ReturnStatus{domain.ReturnRequested}})
+	require.NoError(t, err)
+	assert.Len(t, returns, item.Quantity)
+}
@@ -0,0 +1,151 @@
+// Package schema applies the service's embedded SQL migrations with
+// golang-migrate. Mutating commands run under the Postgres driver's advisory
+// lock, so replicas or jobs migrating at once take turns instead of racing.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// ErrOutdated is returned by Verify when the database lacks migrations this
+// binary needs or a migration failed halfway.
+var ErrOutdated = errors.New("database schema is not up to date")
+
+// Migrator migrates one database.
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// Open connects to the database. lockTimeout bounds the wait for the
+// advisory lock while another instance migrates.
+func Open(databaseURL string, lockTimeout time.Duration) (*Migrator, error) {
+	latest, err := Latest(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded migrations: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to the database: %w", err)
+	}
+	m.LockTimeout = lockTimeout
+	m.Log = logger{}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// Latest is the version of the newest embedded migration.
+func (m *Migrator) Latest() uint {
+	return m.latest
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down reverts the last n migrations.
+func (m *Migrator) Down(n int) error {
+	return ignoreNoChange(m.m.Steps(-n))
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Force records version as applied and clean without running anything, to
+// recover from a failed migration once the database was repaired by hand.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Version returns the applied version, 0 for an empty database, and whether
+// the last migration failed halfway.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Verify checks the database is at least at the latest embedded version and
+// clean. A newer schema is accepted: it is what an older replica sees while
+// a release rolls out.
+func (m *Migrator) Verify() error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	return check(version, dirty, m.latest)
+}
+
+func check(version uint, dirty bool, latest uint) error {
+	switch {
+	case dirty:
+		return fmt.Errorf("%w: migration %d failed and must be repaired, then forced", ErrOutdated, version)
+	case version < latest:
+		return fmt.Errorf("%w: at version %d, expected %d", ErrOutdated, version, latest)
+	default:
+		return nil
+	}
+}
+
+// Latest returns the highest version among the migrations in fsys.
+func Latest(fsys fs.FS) (uint, error) {
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: bad version: %w", name, err)
+		}
+		latest = max(latest, uint(version))
+	}
+	if latest == 0 {
+		return 0, errors.New("no migrations embedded")
+	}
+	return latest, nil
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// logger reports the migrations golang-migrate applies.
+type logger struct{}
+
+func (logger) Printf(format string, v ...any) {
+	log.Printf("migrate: "+format, v...)
+}
+
+func (logger) Verbose() bool {
+	return false
+}
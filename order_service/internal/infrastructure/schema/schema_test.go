@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	latest, err := Latest(migrations.FS)
+	require.NoError(t, err)
+
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+	assert.Len(t, ups, int(latest), "versions are numbered without gaps")
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		_, err := fs.Stat(migrations.FS, down)
+		assert.NoError(t, err, "%s has no down migration", up)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	latest, err := Latest(fstest.MapFS{
+		"000001_init.up.sql":    {},
+		"000001_init.down.sql":  {},
+		"000010_later.up.sql":   {},
+		"000002_second.up.sql":  {},
+		"000010_later.down.sql": {},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint(10), latest)
+
+	_, err = Latest(fstest.MapFS{"init.up.sql": {}})
+	assert.Error(t, err)
+	_, err = Latest(fstest.MapFS{})
+	assert.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	assert.NoError(t, check(13, false, 13))
+	assert.NoError(t, check(14, false, 13), "a newer schema is accepted during a rollout")
+	assert.ErrorIs(t, check(12, false, 13), ErrOutdated)
+	assert.ErrorIs(t, check(0, false, 13), ErrOutdated)
+	assert.ErrorIs(t, check(13, true, 13), ErrOutdated)
+}
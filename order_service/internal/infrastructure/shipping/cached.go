@@ -0,0 +1,117 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+)
+
+// FallbackZone is the zone of quotes priced with the fallback fee.
+const FallbackZone = "fallback"
+
+// maxCachedQuotes bounds the quotes kept in memory.
+const maxCachedQuotes = 10000
+
+// CachedQuoter keeps the quotes of a quoter for reuse. Quotes younger than
+// the TTL are served without asking again. When the quoter is unavailable,
+// the last quote for the same shipment is served however old it is, and
+// without one the fallback fee is charged, so orders are not refused while
+// the delivery service is down. Other errors, such as a destination the
+// delivery service does not ship to, are returned.
+type CachedQuoter struct {
+	next        domain.ShippingQuoter
+	ttl         time.Duration
+	fallbackFee float64
+	now         func() time.Time
+
+	mu     sync.Mutex
+	quotes map[string]cachedQuote
+}
+
+type cachedQuote struct {
+	quote    domain.ShippingQuote
+	storedAt time.Time
+}
+
+func NewCachedQuoter(next domain.ShippingQuoter, ttl time.Duration, fallbackFee float64) *CachedQuoter {
+	return &CachedQuoter{
+		next:        next,
+		ttl:         ttl,
+		fallbackFee: fallbackFee,
+		now:         time.Now,
+		quotes:      map[string]cachedQuote{},
+	}
+}
+
+func (c *CachedQuoter) QuoteShipping(ctx context.Context, address domain.OrderAddress, items []domain.OrderItem, orderValue float64, currency string) (*domain.ShippingQuote, error) {
+	key := shipmentKey(address, items, orderValue, currency)
+	c.mu.Lock()
+	cached, ok := c.quotes[key]
+	c.mu.Unlock()
+	if ok && c.now().Sub(cached.storedAt) < c.ttl {
+		quote := cached.quote
+		return &quote, nil
+	}
+
+	quote, err := c.next.QuoteShipping(ctx, address, items, orderValue, currency)
+	if err == nil {
+		c.store(key, *quote)
+		return quote, nil
+	}
+	if !errors.Is(err, domain.ErrUnavailable) {
+		return nil, err
+	}
+	if ok {
+		log.Printf("Serving a cached shipping quote from %s: %v", cached.storedAt.Format(time.RFC3339), err)
+		quote := cached.quote
+		return &quote, nil
+	}
+	log.Printf("Charging the fallback shipping fee: %v", err)
+	return &domain.ShippingQuote{Amount: c.fallbackFee, Zone: FallbackZone}, nil
+}
+
+func (c *CachedQuoter) store(key string, quote domain.ShippingQuote) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.quotes[key]; !ok && len(c.quotes) >= maxCachedQuotes {
+		for k, q := range c.quotes {
+			if now.Sub(q.storedAt) >= c.ttl {
+				delete(c.quotes, k)
+			}
+		}
+		// Still full of fresh quotes: make room for this one.
+		for k := range c.quotes {
+			if len(c.quotes) < maxCachedQuotes {
+				break
+			}
+			delete(c.quotes, k)
+		}
+	}
+	c.quotes[key] = cachedQuote{quote: quote, storedAt: now}
+}
+
+// shipmentKey identifies what a quote depends on: the destination, the
+// quantities and weights shipped, and the value of the goods.
+func shipmentKey(address domain.OrderAddress, items []domain.OrderItem, orderValue float64, currency string) string {
+	parcels := make([]string, 0, len(items))
+	for _, item := range items {
+		parcels = append(parcels, fmt.Sprintf("%d@%g", item.Quantity, item.WeightKg))
+	}
+	sort.Strings(parcels)
+	return strings.Join([]string{
+		strings.ToLower(strings.TrimSpace(address.Country)),
+		strings.ToLower(strings.TrimSpace(address.Region)),
+		strings.ToLower(strings.TrimSpace(address.City)),
+		strings.Join(parcels, ","),
+		fmt.Sprintf("%.2f", orderValue),
+		currency,
+	}, "|")
+}
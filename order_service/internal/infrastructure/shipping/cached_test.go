@@ -0,0 +1,79 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubQuoter answers with its quote or error and counts the calls.
+type stubQuoter struct {
+	quote *domain.ShippingQuote
+	err   error
+	calls int
+}
+
+func (s *stubQuoter) QuoteShipping(context.Context, domain.OrderAddress, []domain.OrderItem, float64, string) (*domain.ShippingQuote, error) {
+	s.calls++
+	return s.quote, s.err
+}
+
+func TestCachedQuoter(t *testing.T) {
+	ctx := context.Background()
+	address := domain.OrderAddress{Country: "ET", City: "Addis Ababa"}
+	items := []domain.OrderItem{{Quantity: 2, WeightKg: 1.5}}
+	next := &stubQuoter{quote: &domain.ShippingQuote{Amount: 60, Zone: "addis-ababa"}}
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	c := NewCachedQuoter(next, time.Minute, 100)
+	c.now = func() time.Time { return now }
+
+	quote, err := c.QuoteShipping(ctx, address, items, 500, "ETB")
+	require.NoError(t, err)
+	assert.Equal(t, 60.0, quote.Amount)
+
+	// Fresh quotes are reused, whatever the case of the address.
+	_, err = c.QuoteShipping(ctx, domain.OrderAddress{Country: "et", City: "addis ababa"}, items, 500, "ETB")
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.calls)
+
+	// Stale quotes are served only when the delivery service fails.
+	now = now.Add(2 * time.Minute)
+	next.quote, next.err = nil, quoteError(status.Error(codes.Unavailable, "connection refused"))
+	quote, err = c.QuoteShipping(ctx, address, items, 500, "ETB")
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls)
+	assert.Equal(t, "addis-ababa", quote.Zone)
+
+	// Shipments never quoted get the fallback fee.
+	quote, err = c.QuoteShipping(ctx, address, items, 900, "ETB")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ShippingQuote{Amount: 100, Zone: FallbackZone}, quote)
+
+	// Destinations the delivery service refuses are not charged a fee.
+	next.err = quoteError(status.Error(codes.InvalidArgument, "no zone covers the destination"))
+	_, err = c.QuoteShipping(ctx, address, items, 500, "ETB")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	next.err = errors.New("unexpected response")
+	_, err = c.QuoteShipping(ctx, address, items, 500, "ETB")
+	assert.EqualError(t, err, "unexpected response")
+}
+
+func TestQuoteError(t *testing.T) {
+	for code, kind := range map[codes.Code]error{
+		codes.Unavailable:      domain.ErrUnavailable,
+		codes.DeadlineExceeded: domain.ErrUnavailable,
+		codes.InvalidArgument:  domain.ErrInvalidArgument,
+	} {
+		assert.ErrorIs(t, quoteError(status.Error(code, "x")), kind, code.String())
+	}
+	err := quoteError(status.Error(codes.Internal, "boom"))
+	assert.NotErrorIs(t, err, domain.ErrUnavailable)
+	assert.NotErrorIs(t, err, domain.ErrInvalidArgument)
+}
@@ -0,0 +1,78 @@
+// Package shipping quotes shipping fees from the delivery service.
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/deliverypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// Client calls the delivery service's QuoteShipping RPC.
+type Client struct {
+	conn    *grpc.ClientConn
+	client  deliverypb.DeliveryServiceClient
+	timeout time.Duration
+}
+
+// NewClient connects lazily to the delivery service at addr. Each quote
+// waits at most timeout.
+func NewClient(addr string, timeout time.Duration) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("shipping: %w", err)
+	}
+	return &Client{conn: conn, client: deliverypb.NewDeliveryServiceClient(conn), timeout: timeout}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) QuoteShipping(ctx context.Context, address domain.OrderAddress, items []domain.OrderItem, orderValue float64, currency string) (*domain.ShippingQuote, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &deliverypb.QuoteShippingRequest{
+		Destination: &deliverypb.Destination{
+			Country: address.Country,
+			Region:  address.Region,
+			City:    address.City,
+		},
+		OrderValue: orderValue,
+		Currency:   currency,
+	}
+	for _, item := range items {
+		req.Items = append(req.Items, &deliverypb.Parcel{
+			ProductId: item.ProductID.String(),
+			Quantity:  int32(item.Quantity),
+			WeightKg:  item.WeightKg,
+		})
+	}
+	resp, err := c.client.QuoteShipping(ctx, req)
+	if err != nil {
+		return nil, quoteError(err)
+	}
+	return &domain.ShippingQuote{Amount: resp.Amount, Zone: resp.Zone, FreeShipping: resp.FreeShipping}, nil
+}
+
+// quoteError classifies a failed quote: the delivery service being down or
+// slow is unavailable, a shipment it refuses to price an invalid address.
+func quoteError(err error) error {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return &domain.Error{Kind: domain.ErrUnavailable, Reason: "SHIPPING_UNAVAILABLE", Message: "shipping cannot be quoted right now", Err: err}
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.NotFound:
+		return &domain.Error{Kind: domain.ErrInvalidArgument, Reason: "SHIPPING_NOT_POSSIBLE", Message: "cannot ship to this address: " + st.Message(),
+			Field: "shipping_address", Err: err}
+	default:
+		return fmt.Errorf("shipping: quote: %w", err)
+	}
+}
@@ -0,0 +1,160 @@
+// Package tax implements the order domain's tax calculation from a rules
+// file of rates by country, region and product category.
+package tax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule sets the rate and pricing mode of a place. Unset fields fall back to
+// the enclosing rule: region to country to the top level.
+type Rule struct {
+	Rate             *float64           `yaml:"rate"`
+	PricesIncludeTax *bool              `yaml:"prices_include_tax"`
+	Categories       map[string]float64 `yaml:"categories"`
+}
+
+// Country is the rule of a country with those of its regions.
+type Country struct {
+	Rule    `yaml:",inline"`
+	Regions map[string]Rule `yaml:"regions"`
+}
+
+// Rules is the content of a rules file. The top-level rule applies to every
+// address; without any rule nothing is taxed.
+//
+//	prices_include_tax: false
+//	rate: 0
+//	countries:
+//	  ET:
+//	    rate: 0.15
+//	    prices_include_tax: true
+//	    categories: {books: 0}
+//	    regions:
+//	      Addis Ababa: {categories: {alcohol: 0.25}}
+//
+// Countries, regions and categories match case-insensitively.
+type Rules struct {
+	Rule      `yaml:",inline"`
+	Countries map[string]Country `yaml:"countries"`
+}
+
+// Load reads a rules file.
+func Load(path string) (*Rules, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tax: %w", err)
+	}
+	var rules Rules
+	if err := yaml.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("tax: %s: %w", path, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("tax: %s: %w", path, err)
+	}
+	return &rules, nil
+}
+
+// Validate checks every rate is between 0 and 1.
+func (r *Rules) Validate() error {
+	var errs []error
+	check := func(where string, rule Rule) {
+		if rule.Rate != nil && (*rule.Rate < 0 || *rule.Rate > 1) {
+			errs = append(errs, fmt.Errorf("%s: rate must be between 0 and 1", where))
+		}
+		for category, rate := range rule.Categories {
+			if rate < 0 || rate > 1 {
+				errs = append(errs, fmt.Errorf("%s: rate of category %s must be between 0 and 1", where, category))
+			}
+		}
+	}
+	check("top level", r.Rule)
+	for code, country := range r.Countries {
+		check(code, country.Rule)
+		for name, region := range country.Regions {
+			check(code+"/"+name, region)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Calculator computes tax from rules.
+type Calculator struct {
+	rules Rules
+}
+
+// NewCalculator indexes the rules for lookups.
+func NewCalculator(rules Rules) *Calculator {
+	normalized := Rules{Rule: normalizeRule(rules.Rule), Countries: map[string]Country{}}
+	for code, country := range rules.Countries {
+		c := Country{Rule: normalizeRule(country.Rule), Regions: map[string]Rule{}}
+		for name, region := range country.Regions {
+			c.Regions[normalize(name)] = normalizeRule(region)
+		}
+		normalized.Countries[normalize(code)] = c
+	}
+	return &Calculator{rules: normalized}
+}
+
+// CalculateTax implements domain.TaxCalculator. The pricing mode is the one
+// of the address; each line gets the rate of its category there.
+func (c *Calculator) CalculateTax(_ context.Context, address domain.OrderAddress, lines []domain.TaxableLine) (*domain.TaxQuote, error) {
+	// The most specific rule first.
+	chain := []Rule{}
+	if country, ok := c.rules.Countries[normalize(address.Country)]; ok {
+		if region, ok := country.Regions[normalize(address.Region)]; ok {
+			chain = append(chain, region)
+		}
+		chain = append(chain, country.Rule)
+	}
+	chain = append(chain, c.rules.Rule)
+
+	quote := &domain.TaxQuote{}
+	for _, rule := range chain {
+		if rule.PricesIncludeTax != nil {
+			quote.PricesIncludeTax = *rule.PricesIncludeTax
+			break
+		}
+	}
+	for _, line := range lines {
+		rate := rateOf(chain, normalize(line.Category))
+		quote.Lines = append(quote.Lines, domain.LineTax{
+			Rate:   rate,
+			Amount: domain.TaxAt(line.Amount, rate, quote.PricesIncludeTax),
+		})
+	}
+	return quote, nil
+}
+
+// rateOf returns the first category rate, else the first rate, found along
+// the chain of each place from the most specific.
+func rateOf(chain []Rule, category string) float64 {
+	for _, rule := range chain {
+		if rate, ok := rule.Categories[category]; ok && category != "" {
+			return rate
+		}
+		if rule.Rate != nil {
+			return *rule.Rate
+		}
+	}
+	return 0
+}
+
+func normalizeRule(rule Rule) Rule {
+	out := Rule{Rate: rule.Rate, PricesIncludeTax: rule.PricesIncludeTax, Categories: map[string]float64{}}
+	for category, rate := range rule.Categories {
+		out.Categories[normalize(category)] = rate
+	}
+	return out
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
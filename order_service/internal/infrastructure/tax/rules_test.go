@@ -0,0 +1,92 @@
+package tax
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/order_service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rulesFile = `
+rate: 0.05
+categories:
+  food: 0
+countries:
+  ET:
+    rate: 0.15
+    prices_include_tax: true
+    categories:
+      Books: 0
+    regions:
+      Addis Ababa:
+        categories:
+          alcohol: 0.25
+  US:
+    regions:
+      NY:
+        rate: 0.08875
+`
+
+func loadCalculator(t *testing.T) *Calculator {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tax.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(rulesFile), 0o600))
+	rules, err := Load(path)
+	require.NoError(t, err)
+	return NewCalculator(*rules)
+}
+
+func TestCalculator_CalculateTax(t *testing.T) {
+	calc := loadCalculator(t)
+	lines := []domain.TaxableLine{
+		{Category: "", Amount: 100},
+		{Category: "books", Amount: 100},
+		{Category: "alcohol", Amount: 100},
+		{Category: "food", Amount: 100},
+	}
+
+	tests := []struct {
+		name      string
+		address   domain.OrderAddress
+		inclusive bool
+		rates     []float64
+	}{
+		{"unknown country uses the top level", domain.OrderAddress{Country: "KE"}, false, []float64{0.05, 0.05, 0.05, 0}},
+		{"country", domain.OrderAddress{Country: "et"}, true, []float64{0.15, 0, 0.15, 0.15}},
+		{"region category", domain.OrderAddress{Country: "ET", Region: "addis ababa"}, true, []float64{0.15, 0, 0.25, 0.15}},
+		{"region rate", domain.OrderAddress{Country: "US", Region: "NY"}, false, []float64{0.08875, 0.08875, 0.08875, 0.08875}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := calc.CalculateTax(context.Background(), tt.address, lines)
+			require.NoError(t, err)
+			assert.Equal(t, tt.inclusive, quote.PricesIncludeTax)
+			var rates []float64
+			for i, l := range quote.Lines {
+				rates = append(rates, l.Rate)
+				assert.Equal(t, domain.TaxAt(lines[i].Amount, l.Rate, tt.inclusive), l.Amount)
+			}
+			assert.Equal(t, tt.rates, rates)
+		})
+	}
+}
+
+func TestCalculator_NoRules(t *testing.T) {
+	quote, err := NewCalculator(Rules{}).CalculateTax(context.Background(), domain.OrderAddress{Country: "ET"}, []domain.TaxableLine{{Amount: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.TaxQuote{Lines: []domain.LineTax{{}}}, quote)
+}
+
+func TestLoad_RejectsInvalidRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tax.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("countries:\n  ET:\n    categories: {books: 1.5}\n"), 0o600))
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "ET: rate of category books")
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
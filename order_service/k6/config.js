@@ -1,9 +1,35 @@
 // Shared configuration for k6 load tests
 import { randomIntBetween } from 'https://jslib.k6.io/k6-utils/1.2.0/index.js';
+import crypto from 'k6/crypto';
+import encoding from 'k6/encoding';
 
 // gRPC server configuration
 export const GRPC_SERVER = __ENV.GRPC_SERVER || 'localhost:50051';
 
+// Secret the order service verifies access tokens with (its JWT_SECRET)
+const JWT_SECRET = __ENV.JWT_SECRET || '';
+
+// Signs an HS256 access token like the user service issues, valid for an hour
+export function accessToken(subject, roles) {
+    if (!JWT_SECRET) {
+        throw new Error('JWT_SECRET must be set to the order service\'s secret');
+    }
+    const encode = (part) => encoding.b64encode(JSON.stringify(part), 'rawurl');
+    const now = Math.floor(Date.now() / 1000);
+    const unsigned = `${encode({ alg: 'HS256', typ: 'JWT' })}.${encode({ sub: subject, roles: roles, iat: now, exp: now + 3600 })}`;
+    return `${unsigned}.${crypto.hmac('sha256', JWT_SECRET, unsigned, 'base64rawurl')}`;
+}
+
+// Call params carrying a token of the subject as bearer metadata
+export function authParams(subject, roles = ['buyer']) {
+    return { metadata: { authorization: `Bearer ${accessToken(subject, roles)}` } };
+}
+
+// Reads of orders placed by random users need an admin token
+export function adminParams() {
+    return authParams(generateUUID(), ['admin']);
+}
+
 // Test data generators
 export function generateUUID() {
     return 'xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx'.replace(/[xy]/g, function (c) {
@@ -4,6 +4,7 @@ import { Counter, Trend } from 'k6/metrics';
 import {
     GRPC_SERVER,
     generateCreateOrderRequest,
+    authParams,
     commonThresholds,
     getScenario
 } from './config.js';
@@ -37,7 +38,7 @@ export default function () {
     const request = generateCreateOrderRequest();
 
     // Make gRPC call
-    const response = client.invoke('ecommerce.orders.OrderService/CreateOrder', request);
+    const response = client.invoke('ecommerce.orders.OrderService/CreateOrder', request, authParams(request.user_id));
 
     // Validate response
     const success = check(response, {
@@ -5,6 +5,7 @@ import { SharedArray } from 'k6/data';
 import {
     GRPC_SERVER,
     generateUUID,
+    adminParams,
     commonThresholds,
     getScenario
 } from './config.js';
@@ -50,7 +51,7 @@ export default function () {
     // Make gRPC call
     const response = client.invoke('ecommerce.orders.OrderService/GetOrderStatus', {
         order_id: orderId,
-    });
+    }, adminParams());
 
     // Validate response
     // Note: Since we're using random UUIDs, we expect NotFound errors
@@ -6,6 +6,8 @@ import {
     GRPC_SERVER,
     generateCreateOrderRequest,
     generateUUID,
+    authParams,
+    adminParams,
     commonThresholds
 } from './config.js';
 
@@ -67,7 +69,7 @@ export default function () {
     if (random < 0.7) {
         // CreateOrder
         const request = generateCreateOrderRequest();
-        const response = client.invoke('ecommerce.orders.OrderService/CreateOrder', request);
+        const response = client.invoke('ecommerce.orders.OrderService/CreateOrder', request, authParams(request.user_id));
 
         createOrderCount.add(1);
 
@@ -97,7 +99,7 @@ export default function () {
 
         const response = client.invoke('ecommerce.orders.OrderService/GetOrderStatus', {
             order_id: orderId,
-        });
+        }, adminParams());
 
         getOrderCount.add(1);
 
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_order_status_histories_order_id_sequence;
+DROP INDEX IF EXISTS idx_order_status_histories_sequence;
+
+ALTER TABLE order_status_histories DROP COLUMN IF EXISTS sequence;
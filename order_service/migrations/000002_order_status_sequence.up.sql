@@ -0,0 +1,4 @@
+ALTER TABLE order_status_histories ADD COLUMN sequence BIGSERIAL;
+
+CREATE UNIQUE INDEX idx_order_status_histories_sequence ON order_status_histories(sequence);
+CREATE INDEX idx_order_status_histories_order_id_sequence ON order_status_histories(order_id, sequence);
@@ -0,0 +1,2 @@
+-- Serves the newest-first, keyset-paginated listing of a user's orders.
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders(user_id, created_at DESC, id DESC);
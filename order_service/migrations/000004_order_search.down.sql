@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_orders_total_amount;
+DROP INDEX IF EXISTS idx_orders_updated_at;
+DROP INDEX IF EXISTS idx_orders_created_at;
+DROP INDEX IF EXISTS idx_orders_status_created_at;
+DROP INDEX IF EXISTS idx_order_addresses_phone_trgm;
+DROP INDEX IF EXISTS idx_order_addresses_city;
+DROP INDEX IF EXISTS idx_order_addresses_order_id;
+DROP INDEX IF EXISTS idx_order_items_product_id;
+DROP INDEX IF EXISTS idx_order_items_seller_id;
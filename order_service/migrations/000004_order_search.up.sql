@@ -0,0 +1,17 @@
+-- Indexes for the admin order search. Item and address filters are EXISTS
+-- subqueries keyed on order_id, so each filter column is indexed alongside it.
+CREATE INDEX IF NOT EXISTS idx_order_items_seller_id ON order_items(seller_id, order_id);
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items(product_id, order_id);
+CREATE INDEX IF NOT EXISTS idx_order_addresses_order_id ON order_addresses(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_addresses_city ON order_addresses(LOWER(city), order_id);
+
+-- Phone numbers are matched by substring, which only a trigram index serves.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_order_addresses_phone_trgm ON order_addresses USING GIN (phone gin_trgm_ops);
+
+-- Status filters combined with the default newest-first sort, and the
+-- other sortable columns.
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders(status, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at ON orders(updated_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_total_amount ON orders(total_amount, id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_order_items_seller_fulfillment;
+
+ALTER TABLE order_items DROP COLUMN IF EXISTS handed_over_at;
+ALTER TABLE order_items DROP COLUMN IF EXISTS packed_at;
+ALTER TABLE order_items DROP COLUMN IF EXISTS fulfillment_status;
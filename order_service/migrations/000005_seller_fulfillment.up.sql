@@ -0,0 +1,8 @@
+-- Sellers pack their lines and hand them over to the courier.
+ALTER TABLE order_items ADD COLUMN fulfillment_status VARCHAR(20) NOT NULL DEFAULT 'PENDING';
+ALTER TABLE order_items ADD COLUMN packed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE order_items ADD COLUMN handed_over_at TIMESTAMP WITH TIME ZONE;
+
+-- Serves the seller's line listing filtered by fulfillment status; the
+-- unfiltered listing and the daily sales use idx_order_items_seller_id.
+CREATE INDEX IF NOT EXISTS idx_order_items_seller_fulfillment ON order_items(seller_id, fulfillment_status);
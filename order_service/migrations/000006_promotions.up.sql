@@ -0,0 +1,51 @@
+-- Promotions are discount rules applied when orders are created. Automatic
+-- promotions have an empty code; codes are stored upper-case.
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY,
+    code VARCHAR(64) NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL,
+    value DECIMAL(19,4) NOT NULL DEFAULT 0,
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    get_quantity INTEGER NOT NULL DEFAULT 0,
+    product_id UUID,
+    min_subtotal DECIMAL(19,4) NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    max_uses_per_user INTEGER NOT NULL DEFAULT 0,
+    uses INTEGER NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_code ON promotions(code) WHERE code <> '';
+CREATE INDEX IF NOT EXISTS idx_promotions_active ON promotions(active, code);
+
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    id UUID PRIMARY KEY,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    user_id UUID NOT NULL,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    redeemed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_promotion_user ON promotion_redemptions(promotion_id, user_id);
+
+-- The discounts an order was created with.
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    code VARCHAR(64) NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    amount DECIMAL(19,4) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_discounts_order_id ON order_discounts(order_id);
+
+-- Orders created before promotions were charged their subtotal.
+ALTER TABLE orders ADD COLUMN subtotal DECIMAL(19,4) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN discount_amount DECIMAL(19,4) NOT NULL DEFAULT 0;
+UPDATE orders SET subtotal = total_amount;
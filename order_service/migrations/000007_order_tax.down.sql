@@ -0,0 +1,7 @@
+ALTER TABLE order_addresses DROP COLUMN IF EXISTS region;
+ALTER TABLE orders DROP COLUMN IF EXISTS prices_include_tax;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE order_items DROP COLUMN IF EXISTS taxable_amount;
+ALTER TABLE order_items DROP COLUMN IF EXISTS category;
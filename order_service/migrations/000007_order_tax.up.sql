@@ -0,0 +1,13 @@
+-- Tax is computed per line from the shipping address and the category of
+-- the product. Lines created before were not taxed: their taxable amount is
+-- their value.
+ALTER TABLE order_items ADD COLUMN category VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE order_items ADD COLUMN taxable_amount DECIMAL(19,4) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_rate DECIMAL(9,6) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_amount DECIMAL(19,4) NOT NULL DEFAULT 0;
+UPDATE order_items SET taxable_amount = unit_price * quantity;
+
+ALTER TABLE orders ADD COLUMN tax_amount DECIMAL(19,4) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN prices_include_tax BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_addresses ADD COLUMN region VARCHAR(100);
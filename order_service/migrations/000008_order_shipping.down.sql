@@ -0,0 +1,3 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS weight_kg;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_zone;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_amount;
@@ -0,0 +1,6 @@
+-- The shipping fee quoted by the delivery service is part of the order
+-- total. Orders created before shipped free.
+ALTER TABLE orders ADD COLUMN shipping_amount DECIMAL(19,4) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN shipping_zone VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE order_items ADD COLUMN weight_kg DECIMAL(10,3) NOT NULL DEFAULT 0;
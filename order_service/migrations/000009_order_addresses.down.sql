@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_order_addresses_order_kind;
+DELETE FROM order_addresses WHERE kind = 'BILLING';
+ALTER TABLE order_addresses DROP COLUMN IF EXISTS longitude;
+ALTER TABLE order_addresses DROP COLUMN IF EXISTS latitude;
+ALTER TABLE order_addresses DROP COLUMN IF EXISTS address_line2;
+ALTER TABLE order_addresses DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,12 @@
+-- Orders have a shipping and a billing address. Orders created before were
+-- billed to their shipping address, which is copied as their billing one.
+ALTER TABLE order_addresses ADD COLUMN kind VARCHAR(10) NOT NULL DEFAULT 'SHIPPING';
+ALTER TABLE order_addresses ADD COLUMN address_line2 TEXT NOT NULL DEFAULT '';
+ALTER TABLE order_addresses ADD COLUMN latitude DOUBLE PRECISION;
+ALTER TABLE order_addresses ADD COLUMN longitude DOUBLE PRECISION;
+
+INSERT INTO order_addresses (id, order_id, kind, full_name, phone, country, region, city, street, postal_code)
+SELECT gen_random_uuid(), order_id, 'BILLING', full_name, phone, country, region, city, street, postal_code
+FROM order_addresses;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_addresses_order_kind ON order_addresses(order_id, kind);
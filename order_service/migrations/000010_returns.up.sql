@@ -0,0 +1,34 @@
+-- Returns of delivered orders. Each return covers some units of some lines
+-- and moves from REQUESTED to APPROVED or REJECTED, then PICKED_UP and
+-- REFUNDED.
+CREATE TABLE IF NOT EXISTS order_returns (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    reject_reason VARCHAR(500) NOT NULL DEFAULT '',
+    refund_amount DECIMAL(19,4) NOT NULL,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    picked_up_at TIMESTAMP WITH TIME ZONE,
+    refunded_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_returns_order_id ON order_returns(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_returns_user_id ON order_returns(user_id, requested_at DESC);
+CREATE INDEX IF NOT EXISTS idx_order_returns_status ON order_returns(status, requested_at DESC);
+
+CREATE TABLE IF NOT EXISTS order_return_items (
+    id UUID PRIMARY KEY,
+    return_id UUID NOT NULL REFERENCES order_returns(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    seller_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    refund_amount DECIMAL(19,4) NOT NULL,
+    sale_amount DECIMAL(19,4) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_return_items_return_id ON order_return_items(return_id);
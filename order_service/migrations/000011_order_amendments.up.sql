@@ -0,0 +1,13 @@
+-- Changes made to orders before payment. changes lists each changed value:
+-- a line's quantity or an address, with its old and new value.
+CREATE TABLE IF NOT EXISTS order_amendments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amended_by VARCHAR(64) NOT NULL,
+    changes JSONB NOT NULL,
+    previous_total DECIMAL(19,4) NOT NULL,
+    new_total DECIMAL(19,4) NOT NULL,
+    amended_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_amendments_order_id ON order_amendments(order_id, amended_at);
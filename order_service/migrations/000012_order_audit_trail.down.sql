@@ -0,0 +1,7 @@
+ALTER TABLE order_status_histories
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS source_event_id,
+    DROP COLUMN IF EXISTS actor_id,
+    DROP COLUMN IF EXISTS actor_type,
+    DROP COLUMN IF EXISTS from_status;
@@ -0,0 +1,11 @@
+-- Turns the status history into an audit trail: the status an order left,
+-- who moved it (actor_type USER, SERVICE or SYSTEM, and their ID), the event
+-- that triggered the change, a reason and free-form metadata. Entries written
+-- before this migration have no actor.
+ALTER TABLE order_status_histories
+    ADD COLUMN from_status VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN actor_type VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN actor_id VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN source_event_id VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN metadata JSONB;
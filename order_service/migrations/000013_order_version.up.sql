@@ -0,0 +1,3 @@
+-- Optimistic concurrency for orders: every update increments version and
+-- names the version it was computed from.
+ALTER TABLE orders ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+-- Redemptions of archived orders no longer have their order; the constraint
+-- only applies to new rows.
+ALTER TABLE promotion_redemptions
+    ADD CONSTRAINT promotion_redemptions_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE NOT VALID;
+DROP INDEX IF EXISTS idx_orders_status_updated_at;
+DROP TABLE IF EXISTS archived_orders;
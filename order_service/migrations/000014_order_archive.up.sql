@@ -0,0 +1,22 @@
+-- Orders moved out of the live tables once finished for long enough. The
+-- document holds the order with its lines, addresses, discounts, status
+-- history, amendments and returns. exported_to names the file the order was
+-- exported to, empty until then.
+CREATE TABLE IF NOT EXISTS archived_orders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    document JSONB NOT NULL,
+    exported_to TEXT NOT NULL DEFAULT '',
+    exported_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_orders_user_id ON archived_orders(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_archived_orders_unexported ON archived_orders(archived_at, id) WHERE exported_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_status_updated_at ON orders(status, updated_at);
+
+-- Redemptions count towards per-user promotion limits after their order is
+-- archived, so they no longer go with it.
+ALTER TABLE promotion_redemptions DROP CONSTRAINT IF EXISTS promotion_redemptions_order_id_fkey;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_promotion_redemptions_user_id;
+DROP TABLE IF EXISTS user_erasures;
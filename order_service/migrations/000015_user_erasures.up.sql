@@ -0,0 +1,12 @@
+-- Records the erasure of the personal data in a user's orders: who asked
+-- for it (erased_by_type USER or SERVICE, and their ID), how many orders it
+-- covered and when. Exports look up the promotions a user redeemed.
+CREATE TABLE IF NOT EXISTS user_erasures (
+    user_id UUID PRIMARY KEY,
+    erased_by_type VARCHAR(16) NOT NULL DEFAULT '',
+    erased_by_id VARCHAR(64) NOT NULL DEFAULT '',
+    orders INTEGER NOT NULL DEFAULT 0,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_user_id ON promotion_redemptions(user_id, redeemed_at);
@@ -0,0 +1,380 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.1
+// source: proto/delivery.proto
+
+package deliverypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Destination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Destination) Reset() {
+	*x = Destination{}
+	mi := &file_proto_delivery_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Destination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Destination) ProtoMessage() {}
+
+func (x *Destination) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_delivery_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Destination.ProtoReflect.Descriptor instead.
+func (*Destination) Descriptor() ([]byte, []int) {
+	return file_proto_delivery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Destination) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Destination) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Destination) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type Parcel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	WeightKg      float64                `protobuf:"fixed64,3,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"` // per unit; 0 uses the rate table's default weight
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parcel) Reset() {
+	*x = Parcel{}
+	mi := &file_proto_delivery_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parcel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parcel) ProtoMessage() {}
+
+func (x *Parcel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_delivery_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parcel.ProtoReflect.Descriptor instead.
+func (*Parcel) Descriptor() ([]byte, []int) {
+	return file_proto_delivery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Parcel) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Parcel) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Parcel) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+type QuoteShippingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   *Destination           `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Items         []*Parcel              `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	OrderValue    float64                `protobuf:"fixed64,3,opt,name=order_value,json=orderValue,proto3" json:"order_value,omitempty"` // value of the goods after discounts, for free-shipping thresholds
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteShippingRequest) Reset() {
+	*x = QuoteShippingRequest{}
+	mi := &file_proto_delivery_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingRequest) ProtoMessage() {}
+
+func (x *QuoteShippingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_delivery_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingRequest.ProtoReflect.Descriptor instead.
+func (*QuoteShippingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_delivery_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuoteShippingRequest) GetDestination() *Destination {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *QuoteShippingRequest) GetItems() []*Parcel {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *QuoteShippingRequest) GetOrderValue() float64 {
+	if x != nil {
+		return x.OrderValue
+	}
+	return 0
+}
+
+func (x *QuoteShippingRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ShippingQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Zone          string                 `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone,omitempty"`                                      // rate table zone the destination falls in
+	FreeShipping  bool                   `protobuf:"varint,4,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"` // the order value reached the zone's threshold
+	ItemCount     int32                  `protobuf:"varint,5,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	WeightKg      float64                `protobuf:"fixed64,6,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"` // billed weight
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingQuote) Reset() {
+	*x = ShippingQuote{}
+	mi := &file_proto_delivery_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingQuote) ProtoMessage() {}
+
+func (x *ShippingQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_delivery_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingQuote.ProtoReflect.Descriptor instead.
+func (*ShippingQuote) Descriptor() ([]byte, []int) {
+	return file_proto_delivery_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ShippingQuote) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ShippingQuote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *ShippingQuote) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *ShippingQuote) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+var File_proto_delivery_proto protoreflect.FileDescriptor
+
+const file_proto_delivery_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/delivery.proto\x12\x12ecommerce.delivery\"S\n" +
+	"\vDestination\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\"`\n" +
+	"\x06Parcel\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1b\n" +
+	"\tweight_kg\x18\x03 \x01(\x01R\bweightKg\"\xc8\x01\n" +
+	"\x14QuoteShippingRequest\x12A\n" +
+	"\vdestination\x18\x01 \x01(\v2\x1f.ecommerce.delivery.DestinationR\vdestination\x120\n" +
+	"\x05items\x18\x02 \x03(\v2\x1a.ecommerce.delivery.ParcelR\x05items\x12\x1f\n" +
+	"\vorder_value\x18\x03 \x01(\x01R\n" +
+	"orderValue\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\xb8\x01\n" +
+	"\rShippingQuote\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04zone\x18\x03 \x01(\tR\x04zone\x12#\n" +
+	"\rfree_shipping\x18\x04 \x01(\bR\ffreeShipping\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x05 \x01(\x05R\titemCount\x12\x1b\n" +
+	"\tweight_kg\x18\x06 \x01(\x01R\bweightKg2o\n" +
+	"\x0fDeliveryService\x12\\\n" +
+	"\rQuoteShipping\x12(.ecommerce.delivery.QuoteShippingRequest\x1a!.ecommerce.delivery.ShippingQuoteBNZLgithub.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/deliverypbb\x06proto3"
+
+var (
+	file_proto_delivery_proto_rawDescOnce sync.Once
+	file_proto_delivery_proto_rawDescData []byte
+)
+
+func file_proto_delivery_proto_rawDescGZIP() []byte {
+	file_proto_delivery_proto_rawDescOnce.Do(func() {
+		file_proto_delivery_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_delivery_proto_rawDesc), len(file_proto_delivery_proto_rawDesc)))
+	})
+	return file_proto_delivery_proto_rawDescData
+}
+
+var file_proto_delivery_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_delivery_proto_goTypes = []any{
+	(*Destination)(nil),          // 0: ecommerce.delivery.Destination
+	(*Parcel)(nil),               // 1: ecommerce.delivery.Parcel
+	(*QuoteShippingRequest)(nil), // 2: ecommerce.delivery.QuoteShippingRequest
+	(*ShippingQuote)(nil),        // 3: ecommerce.delivery.ShippingQuote
+}
+var file_proto_delivery_proto_depIdxs = []int32{
+	0, // 0: ecommerce.delivery.QuoteShippingRequest.destination:type_name -> ecommerce.delivery.Destination
+	1, // 1: ecommerce.delivery.QuoteShippingRequest.items:type_name -> ecommerce.delivery.Parcel
+	2, // 2: ecommerce.delivery.DeliveryService.QuoteShipping:input_type -> ecommerce.delivery.QuoteShippingRequest
+	3, // 3: ecommerce.delivery.DeliveryService.QuoteShipping:output_type -> ecommerce.delivery.ShippingQuote
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_delivery_proto_init() }
+func file_proto_delivery_proto_init() {
+	if File_proto_delivery_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_delivery_proto_rawDesc), len(file_proto_delivery_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_delivery_proto_goTypes,
+		DependencyIndexes: file_proto_delivery_proto_depIdxs,
+		MessageInfos:      file_proto_delivery_proto_msgTypes,
+	}.Build()
+	File_proto_delivery_proto = out.File
+	file_proto_delivery_proto_goTypes = nil
+	file_proto_delivery_proto_depIdxs = nil
+}
@@ -0,0 +1,9 @@
+package pb
+
+import _ "embed"
+
+// OpenAPI is the Swagger 2.0 document of the REST routes, generated from
+// proto/order.proto by protoc-gen-openapiv2.
+//
+//go:embed order.swagger.json
+var OpenAPI []byte
@@ -7,8 +7,10 @@
 package pb
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,13 +23,125 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OrderSortField int32
+
+const (
+	OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED  OrderSortField = 0 // created_at
+	OrderSortField_ORDER_SORT_FIELD_CREATED_AT   OrderSortField = 1
+	OrderSortField_ORDER_SORT_FIELD_UPDATED_AT   OrderSortField = 2
+	OrderSortField_ORDER_SORT_FIELD_TOTAL_AMOUNT OrderSortField = 3
+)
+
+// Enum value maps for OrderSortField.
+var (
+	OrderSortField_name = map[int32]string{
+		0: "ORDER_SORT_FIELD_UNSPECIFIED",
+		1: "ORDER_SORT_FIELD_CREATED_AT",
+		2: "ORDER_SORT_FIELD_UPDATED_AT",
+		3: "ORDER_SORT_FIELD_TOTAL_AMOUNT",
+	}
+	OrderSortField_value = map[string]int32{
+		"ORDER_SORT_FIELD_UNSPECIFIED":  0,
+		"ORDER_SORT_FIELD_CREATED_AT":   1,
+		"ORDER_SORT_FIELD_UPDATED_AT":   2,
+		"ORDER_SORT_FIELD_TOTAL_AMOUNT": 3,
+	}
+)
+
+func (x OrderSortField) Enum() *OrderSortField {
+	p := new(OrderSortField)
+	*p = x
+	return p
+}
+
+func (x OrderSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderSortField) Type() protoreflect.EnumType {
+	return &file_proto_order_proto_enumTypes[0]
+}
+
+func (x OrderSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSortField.Descriptor instead.
+func (OrderSortField) EnumDescriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{0}
+}
+
+type PromotionKind int32
+
+const (
+	PromotionKind_PROMOTION_KIND_UNSPECIFIED PromotionKind = 0
+	PromotionKind_PROMOTION_KIND_PERCENTAGE  PromotionKind = 1 // value percent off
+	PromotionKind_PROMOTION_KIND_FIXED       PromotionKind = 2 // value off
+	PromotionKind_PROMOTION_KIND_BUY_X_GET_Y PromotionKind = 3 // get_quantity of every buy_quantity + get_quantity units free
+)
+
+// Enum value maps for PromotionKind.
+var (
+	PromotionKind_name = map[int32]string{
+		0: "PROMOTION_KIND_UNSPECIFIED",
+		1: "PROMOTION_KIND_PERCENTAGE",
+		2: "PROMOTION_KIND_FIXED",
+		3: "PROMOTION_KIND_BUY_X_GET_Y",
+	}
+	PromotionKind_value = map[string]int32{
+		"PROMOTION_KIND_UNSPECIFIED": 0,
+		"PROMOTION_KIND_PERCENTAGE":  1,
+		"PROMOTION_KIND_FIXED":       2,
+		"PROMOTION_KIND_BUY_X_GET_Y": 3,
+	}
+)
+
+func (x PromotionKind) Enum() *PromotionKind {
+	p := new(PromotionKind)
+	*p = x
+	return p
+}
+
+func (x PromotionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PromotionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_order_proto_enumTypes[1].Descriptor()
+}
+
+func (PromotionKind) Type() protoreflect.EnumType {
+	return &file_proto_order_proto_enumTypes[1]
+}
+
+func (x PromotionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PromotionKind.Descriptor instead.
+func (PromotionKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{1}
+}
+
 type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
-	ProductName   string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	UnitPrice     float64                `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
-	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SellerId    string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	ProductName string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	UnitPrice   float64                `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Quantity    int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Category    string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"` // selects the tax rate of the line
+	// Set by the service: the line value after discounts and without tax, the
+	// rate applied to it and the tax.
+	TaxableAmount float64 `protobuf:"fixed64,7,opt,name=taxable_amount,json=taxableAmount,proto3" json:"taxable_amount,omitempty"`
+	TaxRate       float64 `protobuf:"fixed64,8,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	TaxAmount     float64 `protobuf:"fixed64,9,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	WeightKg      float64 `protobuf:"fixed64,10,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"` // per unit, to price shipping; 0 uses the delivery service's default
+	ItemId        string  `protobuf:"bytes,11,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`         // output only; identifies the line in returns
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,12 +211,62 @@ func (x *OrderItem) GetQuantity() int32 {
 	return 0
 }
 
+func (x *OrderItem) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *OrderItem) GetTaxableAmount() float64 {
+	if x != nil {
+		return x.TaxableAmount
+	}
+	return 0
+}
+
+func (x *OrderItem) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *OrderItem) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+func (x *OrderItem) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *OrderItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+// Required: full_name, city, street, and phone on shipping addresses; some
+// countries also require region and postal_code.
 type Address struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FullName      string                 `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"` // returned in E.164 form; national numbers are read in the address's country
 	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
-	Street        string                 `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	Street        string                 `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`   // first address line
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2, defaults to the service's country; selects the tax rules
+	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`   // state or province
+	PostalCode    string                 `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	AddressLine2  string                 `protobuf:"bytes,8,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,9,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,10,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -165,14 +329,61 @@ func (x *Address) GetStreet() string {
 	return ""
 }
 
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetAddressLine2() string {
+	if x != nil {
+		return x.AddressLine2
+	}
+	return ""
+}
+
+func (x *Address) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *Address) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
 type CreateOrderRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Items           []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	ShippingAddress *Address               `protobuf:"bytes,3,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
-	TotalAmount     float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Ignored: the total is computed from the items and promotions.
+	//
+	// Deprecated: Marked as deprecated in proto/order.proto.
+	TotalAmount    float64  `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	CouponCodes    []string `protobuf:"bytes,5,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`          // case-insensitive, at most 5
+	BillingAddress *Address `protobuf:"bytes,6,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty"` // defaults to the shipping address
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
@@ -226,6 +437,7 @@ func (x *CreateOrderRequest) GetShippingAddress() *Address {
 	return nil
 }
 
+// Deprecated: Marked as deprecated in proto/order.proto.
 func (x *CreateOrderRequest) GetTotalAmount() float64 {
 	if x != nil {
 		return x.TotalAmount
@@ -233,13 +445,31 @@ func (x *CreateOrderRequest) GetTotalAmount() float64 {
 	return 0
 }
 
+func (x *CreateOrderRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetBillingAddress() *Address {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
 type OrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // e.g., "CREATED", "PENDING_PAYMENT"
-	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // e.g., "CREATED", "PENDING_PAYMENT"
+	CreatedAt      string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TotalAmount    float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"` // amount charged, after discounts, with tax and shipping
+	DiscountAmount float64                `protobuf:"fixed64,5,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	TaxAmount      float64                `protobuf:"fixed64,6,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	ShippingAmount float64                `protobuf:"fixed64,7,opt,name=shipping_amount,json=shippingAmount,proto3" json:"shipping_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *OrderResponse) Reset() {
@@ -293,6 +523,34 @@ func (x *OrderResponse) GetCreatedAt() string {
 	return ""
 }
 
+func (x *OrderResponse) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *OrderResponse) GetDiscountAmount() float64 {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return 0
+}
+
+func (x *OrderResponse) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+func (x *OrderResponse) GetShippingAmount() float64 {
+	if x != nil {
+		return x.ShippingAmount
+	}
+	return 0
+}
+
 type GetOrderStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -337,39 +595,3578 @@ func (x *GetOrderStatusRequest) GetOrderId() string {
 	return ""
 }
 
-var File_proto_order_proto protoreflect.FileDescriptor
+type Order struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OrderId     string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status      string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TotalAmount float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Currency    string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Items       []*OrderItem           `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Value of the items; total_amount is subtotal less discount_amount, plus
+	// tax_amount unless prices_include_tax, plus shipping_amount.
+	Subtotal         float64          `protobuf:"fixed64,9,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	DiscountAmount   float64          `protobuf:"fixed64,10,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	Discounts        []*OrderDiscount `protobuf:"bytes,11,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	TaxAmount        float64          `protobuf:"fixed64,12,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	PricesIncludeTax bool             `protobuf:"varint,13,opt,name=prices_include_tax,json=pricesIncludeTax,proto3" json:"prices_include_tax,omitempty"`
+	TaxBreakdown     []*TaxBreakdown  `protobuf:"bytes,14,rep,name=tax_breakdown,json=taxBreakdown,proto3" json:"tax_breakdown,omitempty"` // by ascending rate
+	ShippingAmount   float64          `protobuf:"fixed64,15,opt,name=shipping_amount,json=shippingAmount,proto3" json:"shipping_amount,omitempty"`
+	ShippingZone     string           `protobuf:"bytes,16,opt,name=shipping_zone,json=shippingZone,proto3" json:"shipping_zone,omitempty"` // delivery zone the shipping was priced for
+	ShippingAddress  *Address         `protobuf:"bytes,17,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	BillingAddress   *Address         `protobuf:"bytes,18,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_proto_order_proto_rawDesc = "" +
-	"\n" +
-	"\x11proto/order.proto\x12\x10ecommerce.orders\"\xa5\x01\n" +
-	"\tOrderItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
-	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12!\n" +
-	"\fproduct_name\x18\x03 \x01(\tR\vproductName\x12\x1d\n" +
-	"\n" +
-	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12\x1a\n" +
-	"\bquantity\x18\x05 \x01(\x05R\bquantity\"h\n" +
-	"\aAddress\x12\x1b\n" +
-	"\tfull_name\x18\x01 \x01(\tR\bfullName\x12\x14\n" +
-	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\x12\x16\n" +
-	"\x06street\x18\x04 \x01(\tR\x06street\"\xc9\x01\n" +
-	"\x12CreateOrderRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
-	"\x05items\x18\x02 \x03(\v2\x1b.ecommerce.orders.OrderItemR\x05items\x12D\n" +
-	"\x10shipping_address\x18\x03 \x01(\v2\x19.ecommerce.orders.AddressR\x0fshippingAddress\x12!\n" +
-	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\"a\n" +
-	"\rOrderResponse\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x03 \x01(\tR\tcreatedAt\"2\n" +
-	"\x15GetOrderStatusRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId2\xc0\x01\n" +
-	"\fOrderService\x12T\n" +
-	"\vCreateOrder\x12$.ecommerce.orders.CreateOrderRequest\x1a\x1f.ecommerce.orders.OrderResponse\x12Z\n" +
-	"\x0eGetOrderStatus\x12'.ecommerce.orders.GetOrderStatusRequest\x1a\x1f.ecommerce.orders.OrderResponseBFZDgithub.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pbb\x06proto3"
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_proto_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Order) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *Order) GetDiscountAmount() float64 {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return 0
+}
+
+func (x *Order) GetDiscounts() []*OrderDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *Order) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+func (x *Order) GetPricesIncludeTax() bool {
+	if x != nil {
+		return x.PricesIncludeTax
+	}
+	return false
+}
+
+func (x *Order) GetTaxBreakdown() []*TaxBreakdown {
+	if x != nil {
+		return x.TaxBreakdown
+	}
+	return nil
+}
+
+func (x *Order) GetShippingAmount() float64 {
+	if x != nil {
+		return x.ShippingAmount
+	}
+	return 0
+}
+
+func (x *Order) GetShippingZone() string {
+	if x != nil {
+		return x.ShippingZone
+	}
+	return ""
+}
+
+func (x *Order) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetBillingAddress() *Address {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
+// The lines of an order taxed at one rate.
+type TaxBreakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rate          float64                `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	TaxableAmount float64                `protobuf:"fixed64,2,opt,name=taxable_amount,json=taxableAmount,proto3" json:"taxable_amount,omitempty"`
+	TaxAmount     float64                `protobuf:"fixed64,3,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxBreakdown) Reset() {
+	*x = TaxBreakdown{}
+	mi := &file_proto_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxBreakdown) ProtoMessage() {}
+
+func (x *TaxBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxBreakdown.ProtoReflect.Descriptor instead.
+func (*TaxBreakdown) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TaxBreakdown) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TaxBreakdown) GetTaxableAmount() float64 {
+	if x != nil {
+		return x.TaxableAmount
+	}
+	return 0
+}
+
+func (x *TaxBreakdown) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+type OrderDiscount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   string                 `protobuf:"bytes,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // empty for automatic promotions
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderDiscount) Reset() {
+	*x = OrderDiscount{}
+	mi := &file_proto_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDiscount) ProtoMessage() {}
+
+func (x *OrderDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDiscount.ProtoReflect.Descriptor instead.
+func (*OrderDiscount) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OrderDiscount) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // defaults to 20, at most 100
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_proto_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_proto_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // optional, recorded in the order's history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_proto_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetOrderHistoryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*OrderHistoryEntry   `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_proto_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderHistoryResponse) GetEntries() []*OrderHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// A status transition of an order. The actor is USER (id is the token
+// subject), SERVICE (id is the service that published source_event_id) or
+// SYSTEM.
+type OrderHistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    string                 `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"` // empty for the first entry of older orders
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ActorType     string                 `protobuf:"bytes,3,opt,name=actor_type,json=actorType,proto3" json:"actor_type,omitempty"`
+	ActorId       string                 `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	SourceEventId string                 `protobuf:"bytes,5,opt,name=source_event_id,json=sourceEventId,proto3" json:"source_event_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Sequence      int64                  `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ChangedAt     string                 `protobuf:"bytes,9,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderHistoryEntry) Reset() {
+	*x = OrderHistoryEntry{}
+	mi := &file_proto_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderHistoryEntry) ProtoMessage() {}
+
+func (x *OrderHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderHistoryEntry.ProtoReflect.Descriptor instead.
+func (*OrderHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrderHistoryEntry) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetActorType() string {
+	if x != nil {
+		return x.ActorType
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetSourceEventId() string {
+	if x != nil {
+		return x.SourceEventId
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderHistoryEntry) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *OrderHistoryEntry) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderHistoryEntry) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+// Changes to an unpaid order. Items set the quantity of existing lines, 0
+// removing the line; at least one line must remain. The addresses, when
+// set, replace the current ones.
+type AmendOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // must own the order
+	Items           []*ItemChange          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	BillingAddress  *Address               `protobuf:"bytes,5,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AmendOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetItems() []*ItemChange {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *AmendOrderRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *AmendOrderRequest) GetBillingAddress() *Address {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
+type ItemChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemChange) Reset() {
+	*x = ItemChange{}
+	mi := &file_proto_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemChange) ProtoMessage() {}
+
+func (x *ItemChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemChange.ProtoReflect.Descriptor instead.
+func (*ItemChange) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ItemChange) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ItemChange) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ListOrderAmendmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderAmendmentsRequest) Reset() {
+	*x = ListOrderAmendmentsRequest{}
+	mi := &file_proto_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderAmendmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderAmendmentsRequest) ProtoMessage() {}
+
+func (x *ListOrderAmendmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderAmendmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderAmendmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListOrderAmendmentsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListOrderAmendmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amendments    []*OrderAmendment      `protobuf:"bytes,1,rep,name=amendments,proto3" json:"amendments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderAmendmentsResponse) Reset() {
+	*x = ListOrderAmendmentsResponse{}
+	mi := &file_proto_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderAmendmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderAmendmentsResponse) ProtoMessage() {}
+
+func (x *ListOrderAmendmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderAmendmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderAmendmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListOrderAmendmentsResponse) GetAmendments() []*OrderAmendment {
+	if x != nil {
+		return x.Amendments
+	}
+	return nil
+}
+
+type OrderAmendment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AmendmentId   string                 `protobuf:"bytes,1,opt,name=amendment_id,json=amendmentId,proto3" json:"amendment_id,omitempty"`
+	AmendedBy     string                 `protobuf:"bytes,2,opt,name=amended_by,json=amendedBy,proto3" json:"amended_by,omitempty"` // the caller's token subject, or the user
+	Changes       []*AmendmentChange     `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	PreviousTotal float64                `protobuf:"fixed64,4,opt,name=previous_total,json=previousTotal,proto3" json:"previous_total,omitempty"`
+	NewTotal      float64                `protobuf:"fixed64,5,opt,name=new_total,json=newTotal,proto3" json:"new_total,omitempty"`
+	AmendedAt     string                 `protobuf:"bytes,6,opt,name=amended_at,json=amendedAt,proto3" json:"amended_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderAmendment) Reset() {
+	*x = OrderAmendment{}
+	mi := &file_proto_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAmendment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAmendment) ProtoMessage() {}
+
+func (x *OrderAmendment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAmendment.ProtoReflect.Descriptor instead.
+func (*OrderAmendment) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrderAmendment) GetAmendmentId() string {
+	if x != nil {
+		return x.AmendmentId
+	}
+	return ""
+}
+
+func (x *OrderAmendment) GetAmendedBy() string {
+	if x != nil {
+		return x.AmendedBy
+	}
+	return ""
+}
+
+func (x *OrderAmendment) GetChanges() []*AmendmentChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *OrderAmendment) GetPreviousTotal() float64 {
+	if x != nil {
+		return x.PreviousTotal
+	}
+	return 0
+}
+
+func (x *OrderAmendment) GetNewTotal() float64 {
+	if x != nil {
+		return x.NewTotal
+	}
+	return 0
+}
+
+func (x *OrderAmendment) GetAmendedAt() string {
+	if x != nil {
+		return x.AmendedAt
+	}
+	return ""
+}
+
+// A changed value: the quantity of item_id, or an address on one line.
+type AmendmentChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`                 // quantity, shipping_address or billing_address
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // set for quantity changes
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendmentChange) Reset() {
+	*x = AmendmentChange{}
+	mi := &file_proto_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendmentChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendmentChange) ProtoMessage() {}
+
+func (x *AmendmentChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendmentChange.ProtoReflect.Descriptor instead.
+func (*AmendmentChange) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AmendmentChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AmendmentChange) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AmendmentChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AmendmentChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// Every criterion that is set must match; all are optional.
+type SearchOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`    // any item sold by this seller
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // any item of this product
+	Statuses      []string               `protobuf:"bytes,4,rep,name=statuses,proto3" json:"statuses,omitempty"`                    // any of these statuses
+	MinTotal      *float64               `protobuf:"fixed64,5,opt,name=min_total,json=minTotal,proto3,oneof" json:"min_total,omitempty"`
+	MaxTotal      *float64               `protobuf:"fixed64,6,opt,name=max_total,json=maxTotal,proto3,oneof" json:"max_total,omitempty"`
+	CreatedFrom   string                 `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // RFC 3339, inclusive
+	CreatedTo     string                 `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // RFC 3339, exclusive
+	City          string                 `protobuf:"bytes,9,opt,name=city,proto3" json:"city,omitempty"`                                  // shipping city, case-insensitive
+	Phone         string                 `protobuf:"bytes,10,opt,name=phone,proto3" json:"phone,omitempty"`                               // fragment of the shipping phone, at least 3 characters
+	SortBy        OrderSortField         `protobuf:"varint,11,opt,name=sort_by,json=sortBy,proto3,enum=ecommerce.orders.OrderSortField" json:"sort_by,omitempty"`
+	Ascending     bool                   `protobuf:"varint,12,opt,name=ascending,proto3" json:"ascending,omitempty"`               // newest or largest first by default
+	Page          int32                  `protobuf:"varint,13,opt,name=page,proto3" json:"page,omitempty"`                         // starts at 1
+	PageSize      int32                  `protobuf:"varint,14,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // defaults to 20, at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersRequest) Reset() {
+	*x = SearchOrdersRequest{}
+	mi := &file_proto_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersRequest) ProtoMessage() {}
+
+func (x *SearchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetMinTotal() float64 {
+	if x != nil && x.MinTotal != nil {
+		return *x.MinTotal
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetMaxTotal() float64 {
+	if x != nil && x.MaxTotal != nil {
+		return *x.MaxTotal
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetSortBy() OrderSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *SearchOrdersRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *SearchOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // matches on all pages
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersResponse) Reset() {
+	*x = SearchOrdersResponse{}
+	mi := &file_proto_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersResponse) ProtoMessage() {}
+
+func (x *SearchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *SearchOrdersResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchOrdersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchOrdersResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SellerOrderLine struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ItemId            string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ProductId         string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName       string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	UnitPrice         float64                `protobuf:"fixed64,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Quantity          int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderStatus       string                 `protobuf:"bytes,7,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	FulfillmentStatus string                 `protobuf:"bytes,8,opt,name=fulfillment_status,json=fulfillmentStatus,proto3" json:"fulfillment_status,omitempty"` // PENDING, PACKED or HANDED_OVER
+	OrderedAt         string                 `protobuf:"bytes,9,opt,name=ordered_at,json=orderedAt,proto3" json:"ordered_at,omitempty"`
+	PackedAt          string                 `protobuf:"bytes,10,opt,name=packed_at,json=packedAt,proto3" json:"packed_at,omitempty"`               // empty until packed
+	HandedOverAt      string                 `protobuf:"bytes,11,opt,name=handed_over_at,json=handedOverAt,proto3" json:"handed_over_at,omitempty"` // empty until handed over
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SellerOrderLine) Reset() {
+	*x = SellerOrderLine{}
+	mi := &file_proto_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SellerOrderLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SellerOrderLine) ProtoMessage() {}
+
+func (x *SellerOrderLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SellerOrderLine.ProtoReflect.Descriptor instead.
+func (*SellerOrderLine) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SellerOrderLine) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *SellerOrderLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SellerOrderLine) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetFulfillmentStatus() string {
+	if x != nil {
+		return x.FulfillmentStatus
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetOrderedAt() string {
+	if x != nil {
+		return x.OrderedAt
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetPackedAt() string {
+	if x != nil {
+		return x.PackedAt
+	}
+	return ""
+}
+
+func (x *SellerOrderLine) GetHandedOverAt() string {
+	if x != nil {
+		return x.HandedOverAt
+	}
+	return ""
+}
+
+type ListSellerOrderLinesRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SellerId            string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	OrderStatuses       []string               `protobuf:"bytes,2,rep,name=order_statuses,json=orderStatuses,proto3" json:"order_statuses,omitempty"`                   // any of these order statuses
+	FulfillmentStatuses []string               `protobuf:"bytes,3,rep,name=fulfillment_statuses,json=fulfillmentStatuses,proto3" json:"fulfillment_statuses,omitempty"` // any of these fulfillment statuses
+	PageSize            int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                                 // defaults to 20, at most 100
+	PageToken           string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                               // next_page_token of the previous page
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListSellerOrderLinesRequest) Reset() {
+	*x = ListSellerOrderLinesRequest{}
+	mi := &file_proto_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSellerOrderLinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSellerOrderLinesRequest) ProtoMessage() {}
+
+func (x *ListSellerOrderLinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSellerOrderLinesRequest.ProtoReflect.Descriptor instead.
+func (*ListSellerOrderLinesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSellerOrderLinesRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *ListSellerOrderLinesRequest) GetOrderStatuses() []string {
+	if x != nil {
+		return x.OrderStatuses
+	}
+	return nil
+}
+
+func (x *ListSellerOrderLinesRequest) GetFulfillmentStatuses() []string {
+	if x != nil {
+		return x.FulfillmentStatuses
+	}
+	return nil
+}
+
+func (x *ListSellerOrderLinesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSellerOrderLinesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSellerOrderLinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*SellerOrderLine     `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSellerOrderLinesResponse) Reset() {
+	*x = ListSellerOrderLinesResponse{}
+	mi := &file_proto_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSellerOrderLinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSellerOrderLinesResponse) ProtoMessage() {}
+
+func (x *ListSellerOrderLinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSellerOrderLinesResponse.ProtoReflect.Descriptor instead.
+func (*ListSellerOrderLinesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSellerOrderLinesResponse) GetLines() []*SellerOrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ListSellerOrderLinesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateLineFulfillmentRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SellerId          string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	ItemIds           []string               `protobuf:"bytes,3,rep,name=item_ids,json=itemIds,proto3" json:"item_ids,omitempty"`                               // empty selects all of the seller's lines in the order
+	FulfillmentStatus string                 `protobuf:"bytes,4,opt,name=fulfillment_status,json=fulfillmentStatus,proto3" json:"fulfillment_status,omitempty"` // PACKED or HANDED_OVER
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateLineFulfillmentRequest) Reset() {
+	*x = UpdateLineFulfillmentRequest{}
+	mi := &file_proto_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLineFulfillmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLineFulfillmentRequest) ProtoMessage() {}
+
+func (x *UpdateLineFulfillmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLineFulfillmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLineFulfillmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateLineFulfillmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateLineFulfillmentRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *UpdateLineFulfillmentRequest) GetItemIds() []string {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+func (x *UpdateLineFulfillmentRequest) GetFulfillmentStatus() string {
+	if x != nil {
+		return x.FulfillmentStatus
+	}
+	return ""
+}
+
+type UpdateLineFulfillmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*SellerOrderLine     `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"` // all of the seller's lines in the order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLineFulfillmentResponse) Reset() {
+	*x = UpdateLineFulfillmentResponse{}
+	mi := &file_proto_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLineFulfillmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLineFulfillmentResponse) ProtoMessage() {}
+
+func (x *UpdateLineFulfillmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLineFulfillmentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLineFulfillmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateLineFulfillmentResponse) GetLines() []*SellerOrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type GetSellerDailySalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"` // YYYY-MM-DD, UTC
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`     // YYYY-MM-DD, inclusive; at most 92 days after from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerDailySalesRequest) Reset() {
+	*x = GetSellerDailySalesRequest{}
+	mi := &file_proto_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerDailySalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerDailySalesRequest) ProtoMessage() {}
+
+func (x *GetSellerDailySalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerDailySalesRequest.ProtoReflect.Descriptor instead.
+func (*GetSellerDailySalesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetSellerDailySalesRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *GetSellerDailySalesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetSellerDailySalesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type DailySales struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"` // YYYY-MM-DD
+	Orders        int64                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	Units         int64                  `protobuf:"varint,3,opt,name=units,proto3" json:"units,omitempty"`
+	Revenue       float64                `protobuf:"fixed64,4,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailySales) Reset() {
+	*x = DailySales{}
+	mi := &file_proto_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailySales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailySales) ProtoMessage() {}
+
+func (x *DailySales) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailySales.ProtoReflect.Descriptor instead.
+func (*DailySales) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DailySales) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *DailySales) GetOrders() int64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *DailySales) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *DailySales) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+type GetSellerDailySalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*DailySales          `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"` // days without sales are omitted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerDailySalesResponse) Reset() {
+	*x = GetSellerDailySalesResponse{}
+	mi := &file_proto_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerDailySalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerDailySalesResponse) ProtoMessage() {}
+
+func (x *GetSellerDailySalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerDailySalesResponse.ProtoReflect.Descriptor instead.
+func (*GetSellerDailySalesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetSellerDailySalesResponse) GetDays() []*DailySales {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// Promotions without a code apply to every qualifying order. Stackable
+// promotions combine; others apply alone, and an order gets whichever is
+// worth more.
+type Promotion struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId    string                 `protobuf:"bytes,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"` // output only
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                  // empty for automatic promotions
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Kind           PromotionKind          `protobuf:"varint,4,opt,name=kind,proto3,enum=ecommerce.orders.PromotionKind" json:"kind,omitempty"`
+	Value          float64                `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	BuyQuantity    int32                  `protobuf:"varint,6,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity    int32                  `protobuf:"varint,7,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	ProductId      string                 `protobuf:"bytes,8,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // empty for every product
+	MinSubtotal    float64                `protobuf:"fixed64,9,opt,name=min_subtotal,json=minSubtotal,proto3" json:"min_subtotal,omitempty"`
+	StartsAt       string                 `protobuf:"bytes,10,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`                        // RFC 3339; empty for no start
+	EndsAt         string                 `protobuf:"bytes,11,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`                              // RFC 3339, exclusive; empty for no end
+	MaxUses        int32                  `protobuf:"varint,12,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`                          // 0 is unlimited
+	MaxUsesPerUser int32                  `protobuf:"varint,13,opt,name=max_uses_per_user,json=maxUsesPerUser,proto3" json:"max_uses_per_user,omitempty"` // 0 is unlimited
+	Uses           int32                  `protobuf:"varint,14,opt,name=uses,proto3" json:"uses,omitempty"`                                               // output only
+	Stackable      bool                   `protobuf:"varint,15,opt,name=stackable,proto3" json:"stackable,omitempty"`
+	Active         bool                   `protobuf:"varint,16,opt,name=active,proto3" json:"active,omitempty"`                       // output only
+	CreatedAt      string                 `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // output only
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_proto_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Promotion) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Promotion) GetKind() PromotionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PromotionKind_PROMOTION_KIND_UNSPECIFIED
+}
+
+func (x *Promotion) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Promotion) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Promotion) GetMinSubtotal() float64 {
+	if x != nil {
+		return x.MinSubtotal
+	}
+	return 0
+}
+
+func (x *Promotion) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetEndsAt() string {
+	if x != nil {
+		return x.EndsAt
+	}
+	return ""
+}
+
+func (x *Promotion) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Promotion) GetMaxUsesPerUser() int32 {
+	if x != nil {
+		return x.MaxUsesPerUser
+	}
+	return 0
+}
+
+func (x *Promotion) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+func (x *Promotion) GetStackable() bool {
+	if x != nil {
+		return x.Stackable
+	}
+	return false
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_proto_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type ListPromotionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeInactive bool                   `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_proto_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListPromotionsRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_proto_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type SetPromotionActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   string                 `protobuf:"bytes,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPromotionActiveRequest) Reset() {
+	*x = SetPromotionActiveRequest{}
+	mi := &file_proto_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPromotionActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPromotionActiveRequest) ProtoMessage() {}
+
+func (x *SetPromotionActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPromotionActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetPromotionActiveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetPromotionActiveRequest) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *SetPromotionActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// A return moves from REQUESTED to APPROVED or REJECTED, then PICKED_UP once
+// the courier collected the items and REFUNDED.
+type Return struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      string                 `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	RejectReason  string                 `protobuf:"bytes,6,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`   // set when rejected
+	RefundAmount  float64                `protobuf:"fixed64,7,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"` // the paid price of the units, tax included; shipping is not refunded
+	Items         []*ReturnItem          `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	RequestedAt   string                 `protobuf:"bytes,9,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	DecidedAt     string                 `protobuf:"bytes,10,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`      // empty until approved or rejected
+	PickedUpAt    string                 `protobuf:"bytes,11,opt,name=picked_up_at,json=pickedUpAt,proto3" json:"picked_up_at,omitempty"` // empty until picked up
+	RefundedAt    string                 `protobuf:"bytes,12,opt,name=refunded_at,json=refundedAt,proto3" json:"refunded_at,omitempty"`   // empty until refunded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_proto_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Return) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *Return) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Return) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Return) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Return) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Return) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
+}
+
+func (x *Return) GetRefundAmount() float64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+func (x *Return) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Return) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
+}
+
+func (x *Return) GetDecidedAt() string {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return ""
+}
+
+func (x *Return) GetPickedUpAt() string {
+	if x != nil {
+		return x.PickedUpAt
+	}
+	return ""
+}
+
+func (x *Return) GetRefundedAt() string {
+	if x != nil {
+		return x.RefundedAt
+	}
+	return ""
+}
+
+type ReturnItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SellerId      string                 `protobuf:"bytes,3,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	RefundAmount  float64                `protobuf:"fixed64,5,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_proto_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReturnItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetRefundAmount() float64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+type RequestReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // must own the order
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*ReturnItemRequest   `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnRequest) Reset() {
+	*x = RequestReturnRequest{}
+	mi := &file_proto_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnRequest) ProtoMessage() {}
+
+func (x *RequestReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnRequest.ProtoReflect.Descriptor instead.
+func (*RequestReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RequestReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetItems() []*ReturnItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReturnItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnItemRequest) Reset() {
+	*x = ReturnItemRequest{}
+	mi := &file_proto_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItemRequest) ProtoMessage() {}
+
+func (x *ReturnItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItemRequest.ProtoReflect.Descriptor instead.
+func (*ReturnItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReturnItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReturnItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      string                 `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	mi := &file_proto_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnRequest) ProtoMessage() {}
+
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+// Either order_id or user_id is required.
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_proto_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListReturnsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListReturnsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReturnsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*Return              `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_proto_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*Return {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+type SearchReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []string               `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`                  // any of; empty for all
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchReturnsRequest) Reset() {
+	*x = SearchReturnsRequest{}
+	mi := &file_proto_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReturnsRequest) ProtoMessage() {}
+
+func (x *SearchReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReturnsRequest.ProtoReflect.Descriptor instead.
+func (*SearchReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SearchReturnsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchReturnsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ApproveReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      string                 `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReturnRequest) Reset() {
+	*x = ApproveReturnRequest{}
+	mi := &file_proto_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReturnRequest) ProtoMessage() {}
+
+func (x *ApproveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ApproveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ApproveReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+type RejectReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      string                 `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReturnRequest) Reset() {
+	*x = RejectReturnRequest{}
+	mi := &file_proto_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReturnRequest) ProtoMessage() {}
+
+func (x *RejectReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReturnRequest.ProtoReflect.Descriptor instead.
+func (*RejectReturnRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RejectReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *RejectReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type WatchOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	AfterSequence int64                  `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"` // resume after the last sequence seen; 0 replays the full history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_proto_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WatchOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WatchOrderRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+type WatchUserOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AfterSequence int64                  `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"` // resume after the last sequence seen; 0 replays the full history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchUserOrdersRequest) Reset() {
+	*x = WatchUserOrdersRequest{}
+	mi := &file_proto_order_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchUserOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchUserOrdersRequest) ProtoMessage() {}
+
+func (x *WatchUserOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchUserOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchUserOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WatchUserOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WatchUserOrdersRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+type OrderStatusEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Sequence      int64                  `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"` // increases across all orders
+	ChangedAt     string                 `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusEvent) Reset() {
+	*x = OrderStatusEvent{}
+	mi := &file_proto_order_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusEvent) ProtoMessage() {}
+
+func (x *OrderStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusEvent.ProtoReflect.Descriptor instead.
+func (*OrderStatusEvent) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *OrderStatusEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderStatusEvent) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // defaults to the caller
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_order_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// The user's orders, oldest first, each with its items, addresses,
+// discounts, status history, amendments and returns, and archived_at once
+// archived; the promotions the user redeemed; and when it was exported.
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *structpb.Struct       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_proto_order_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ExportUserDataResponse) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_proto_order_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *EraseUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EraseUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Orders        int32                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"` // orders whose addresses were erased
+	ErasedAt      string                 `protobuf:"bytes,3,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_proto_order_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *EraseUserDataResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EraseUserDataResponse) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetErasedAt() string {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return ""
+}
+
+var File_proto_order_proto protoreflect.FileDescriptor
+
+const file_proto_order_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/order.proto\x12\x10ecommerce.orders\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xd8\x02\n" +
+	"\tOrderItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12!\n" +
+	"\fproduct_name\x18\x03 \x01(\tR\vproductName\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12%\n" +
+	"\x0etaxable_amount\x18\a \x01(\x01R\rtaxableAmount\x12\x19\n" +
+	"\btax_rate\x18\b \x01(\x01R\ataxRate\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\t \x01(\x01R\ttaxAmount\x12\x1b\n" +
+	"\tweight_kg\x18\n" +
+	" \x01(\x01R\bweightKg\x12\x17\n" +
+	"\aitem_id\x18\v \x01(\tR\x06itemId\"\xbf\x02\n" +
+	"\aAddress\x12\x1b\n" +
+	"\tfull_name\x18\x01 \x01(\tR\bfullName\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x16\n" +
+	"\x06street\x18\x04 \x01(\tR\x06street\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\x12#\n" +
+	"\raddress_line2\x18\b \x01(\tR\faddressLine2\x12\x1f\n" +
+	"\blatitude\x18\t \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\n" +
+	" \x01(\x01H\x01R\tlongitude\x88\x01\x01B\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\xb4\x02\n" +
+	"\x12CreateOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\x05items\x18\x02 \x03(\v2\x1b.ecommerce.orders.OrderItemR\x05items\x12D\n" +
+	"\x10shipping_address\x18\x03 \x01(\v2\x19.ecommerce.orders.AddressR\x0fshippingAddress\x12%\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01B\x02\x18\x01R\vtotalAmount\x12!\n" +
+	"\fcoupon_codes\x18\x05 \x03(\tR\vcouponCodes\x12B\n" +
+	"\x0fbilling_address\x18\x06 \x01(\v2\x19.ecommerce.orders.AddressR\x0ebillingAddress\"\xf5\x01\n" +
+	"\rOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\x12'\n" +
+	"\x0fdiscount_amount\x18\x05 \x01(\x01R\x0ediscountAmount\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\x06 \x01(\x01R\ttaxAmount\x12'\n" +
+	"\x0fshipping_amount\x18\a \x01(\x01R\x0eshippingAmount\"2\n" +
+	"\x15GetOrderStatusRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xf1\x05\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x121\n" +
+	"\x05items\x18\x06 \x03(\v2\x1b.ecommerce.orders.OrderItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x1a\n" +
+	"\bsubtotal\x18\t \x01(\x01R\bsubtotal\x12'\n" +
+	"\x0fdiscount_amount\x18\n" +
+	" \x01(\x01R\x0ediscountAmount\x12=\n" +
+	"\tdiscounts\x18\v \x03(\v2\x1f.ecommerce.orders.OrderDiscountR\tdiscounts\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\f \x01(\x01R\ttaxAmount\x12,\n" +
+	"\x12prices_include_tax\x18\r \x01(\bR\x10pricesIncludeTax\x12C\n" +
+	"\rtax_breakdown\x18\x0e \x03(\v2\x1e.ecommerce.orders.TaxBreakdownR\ftaxBreakdown\x12'\n" +
+	"\x0fshipping_amount\x18\x0f \x01(\x01R\x0eshippingAmount\x12#\n" +
+	"\rshipping_zone\x18\x10 \x01(\tR\fshippingZone\x12D\n" +
+	"\x10shipping_address\x18\x11 \x01(\v2\x19.ecommerce.orders.AddressR\x0fshippingAddress\x12B\n" +
+	"\x0fbilling_address\x18\x12 \x01(\v2\x19.ecommerce.orders.AddressR\x0ebillingAddress\"h\n" +
+	"\fTaxBreakdown\x12\x12\n" +
+	"\x04rate\x18\x01 \x01(\x01R\x04rate\x12%\n" +
+	"\x0etaxable_amount\x18\x02 \x01(\x01R\rtaxableAmount\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\x03 \x01(\x01R\ttaxAmount\"\x80\x01\n" +
+	"\rOrderDiscount\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"h\n" +
+	"\x11ListOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"m\n" +
+	"\x12ListOrdersResponse\x12/\n" +
+	"\x06orders\x18\x01 \x03(\v2\x17.ecommerce.orders.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"G\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"3\n" +
+	"\x16GetOrderHistoryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"X\n" +
+	"\x17GetOrderHistoryResponse\x12=\n" +
+	"\aentries\x18\x01 \x03(\v2#.ecommerce.orders.OrderHistoryEntryR\aentries\"\x8d\x03\n" +
+	"\x11OrderHistoryEntry\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"actor_type\x18\x03 \x01(\tR\tactorType\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12&\n" +
+	"\x0fsource_event_id\x18\x05 \x01(\tR\rsourceEventId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12M\n" +
+	"\bmetadata\x18\a \x03(\v21.ecommerce.orders.OrderHistoryEntry.MetadataEntryR\bmetadata\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x03R\bsequence\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\t \x01(\tR\tchangedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x02\n" +
+	"\x11AmendOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x122\n" +
+	"\x05items\x18\x03 \x03(\v2\x1c.ecommerce.orders.ItemChangeR\x05items\x12D\n" +
+	"\x10shipping_address\x18\x04 \x01(\v2\x19.ecommerce.orders.AddressR\x0fshippingAddress\x12B\n" +
+	"\x0fbilling_address\x18\x05 \x01(\v2\x19.ecommerce.orders.AddressR\x0ebillingAddress\"A\n" +
+	"\n" +
+	"ItemChange\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"7\n" +
+	"\x1aListOrderAmendmentsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"_\n" +
+	"\x1bListOrderAmendmentsResponse\x12@\n" +
+	"\n" +
+	"amendments\x18\x01 \x03(\v2 .ecommerce.orders.OrderAmendmentR\n" +
+	"amendments\"\xf2\x01\n" +
+	"\x0eOrderAmendment\x12!\n" +
+	"\famendment_id\x18\x01 \x01(\tR\vamendmentId\x12\x1d\n" +
+	"\n" +
+	"amended_by\x18\x02 \x01(\tR\tamendedBy\x12;\n" +
+	"\achanges\x18\x03 \x03(\v2!.ecommerce.orders.AmendmentChangeR\achanges\x12%\n" +
+	"\x0eprevious_total\x18\x04 \x01(\x01R\rpreviousTotal\x12\x1b\n" +
+	"\tnew_total\x18\x05 \x01(\x01R\bnewTotal\x12\x1d\n" +
+	"\n" +
+	"amended_at\x18\x06 \x01(\tR\tamendedAt\"d\n" +
+	"\x0fAmendmentChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\xdc\x03\n" +
+	"\x13SearchOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bstatuses\x18\x04 \x03(\tR\bstatuses\x12 \n" +
+	"\tmin_total\x18\x05 \x01(\x01H\x00R\bminTotal\x88\x01\x01\x12 \n" +
+	"\tmax_total\x18\x06 \x01(\x01H\x01R\bmaxTotal\x88\x01\x01\x12!\n" +
+	"\fcreated_from\x18\a \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\b \x01(\tR\tcreatedTo\x12\x12\n" +
+	"\x04city\x18\t \x01(\tR\x04city\x12\x14\n" +
+	"\x05phone\x18\n" +
+	" \x01(\tR\x05phone\x129\n" +
+	"\asort_by\x18\v \x01(\x0e2 .ecommerce.orders.OrderSortFieldR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\f \x01(\bR\tascending\x12\x12\n" +
+	"\x04page\x18\r \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x0e \x01(\x05R\bpageSizeB\f\n" +
+	"\n" +
+	"_min_totalB\f\n" +
+	"\n" +
+	"_max_total\"\x99\x01\n" +
+	"\x14SearchOrdersResponse\x12/\n" +
+	"\x06orders\x18\x01 \x03(\v2\x17.ecommerce.orders.OrderR\x06orders\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xf6\x02\n" +
+	"\x0fSellerOrderLine\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x05 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12!\n" +
+	"\forder_status\x18\a \x01(\tR\vorderStatus\x12-\n" +
+	"\x12fulfillment_status\x18\b \x01(\tR\x11fulfillmentStatus\x12\x1d\n" +
+	"\n" +
+	"ordered_at\x18\t \x01(\tR\torderedAt\x12\x1b\n" +
+	"\tpacked_at\x18\n" +
+	" \x01(\tR\bpackedAt\x12$\n" +
+	"\x0ehanded_over_at\x18\v \x01(\tR\fhandedOverAt\"\xd0\x01\n" +
+	"\x1bListSellerOrderLinesRequest\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12%\n" +
+	"\x0eorder_statuses\x18\x02 \x03(\tR\rorderStatuses\x121\n" +
+	"\x14fulfillment_statuses\x18\x03 \x03(\tR\x13fulfillmentStatuses\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\x7f\n" +
+	"\x1cListSellerOrderLinesResponse\x127\n" +
+	"\x05lines\x18\x01 \x03(\v2!.ecommerce.orders.SellerOrderLineR\x05lines\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa0\x01\n" +
+	"\x1cUpdateLineFulfillmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x19\n" +
+	"\bitem_ids\x18\x03 \x03(\tR\aitemIds\x12-\n" +
+	"\x12fulfillment_status\x18\x04 \x01(\tR\x11fulfillmentStatus\"X\n" +
+	"\x1dUpdateLineFulfillmentResponse\x127\n" +
+	"\x05lines\x18\x01 \x03(\v2!.ecommerce.orders.SellerOrderLineR\x05lines\"]\n" +
+	"\x1aGetSellerDailySalesRequest\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"f\n" +
+	"\n" +
+	"DailySales\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x03R\x06orders\x12\x14\n" +
+	"\x05units\x18\x03 \x01(\x03R\x05units\x12\x18\n" +
+	"\arevenue\x18\x04 \x01(\x01R\arevenue\"O\n" +
+	"\x1bGetSellerDailySalesResponse\x120\n" +
+	"\x04days\x18\x01 \x03(\v2\x1c.ecommerce.orders.DailySalesR\x04days\"\x9c\x04\n" +
+	"\tPromotion\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x123\n" +
+	"\x04kind\x18\x04 \x01(\x0e2\x1f.ecommerce.orders.PromotionKindR\x04kind\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x12!\n" +
+	"\fbuy_quantity\x18\x06 \x01(\x05R\vbuyQuantity\x12!\n" +
+	"\fget_quantity\x18\a \x01(\x05R\vgetQuantity\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\b \x01(\tR\tproductId\x12!\n" +
+	"\fmin_subtotal\x18\t \x01(\x01R\vminSubtotal\x12\x1b\n" +
+	"\tstarts_at\x18\n" +
+	" \x01(\tR\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\v \x01(\tR\x06endsAt\x12\x19\n" +
+	"\bmax_uses\x18\f \x01(\x05R\amaxUses\x12)\n" +
+	"\x11max_uses_per_user\x18\r \x01(\x05R\x0emaxUsesPerUser\x12\x12\n" +
+	"\x04uses\x18\x0e \x01(\x05R\x04uses\x12\x1c\n" +
+	"\tstackable\x18\x0f \x01(\bR\tstackable\x12\x16\n" +
+	"\x06active\x18\x10 \x01(\bR\x06active\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\tR\tcreatedAt\"S\n" +
+	"\x16CreatePromotionRequest\x129\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x1b.ecommerce.orders.PromotionR\tpromotion\"B\n" +
+	"\x15ListPromotionsRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\"U\n" +
+	"\x16ListPromotionsResponse\x12;\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x1b.ecommerce.orders.PromotionR\n" +
+	"promotions\"V\n" +
+	"\x19SetPromotionActiveRequest\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\tR\vpromotionId\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"\x8c\x03\n" +
+	"\x06Return\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\tR\breturnId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12#\n" +
+	"\rreject_reason\x18\x06 \x01(\tR\frejectReason\x12#\n" +
+	"\rrefund_amount\x18\a \x01(\x01R\frefundAmount\x122\n" +
+	"\x05items\x18\b \x03(\v2\x1c.ecommerce.orders.ReturnItemR\x05items\x12!\n" +
+	"\frequested_at\x18\t \x01(\tR\vrequestedAt\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\n" +
+	" \x01(\tR\tdecidedAt\x12 \n" +
+	"\fpicked_up_at\x18\v \x01(\tR\n" +
+	"pickedUpAt\x12\x1f\n" +
+	"\vrefunded_at\x18\f \x01(\tR\n" +
+	"refundedAt\"\xa2\x01\n" +
+	"\n" +
+	"ReturnItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tseller_id\x18\x03 \x01(\tR\bsellerId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12#\n" +
+	"\rrefund_amount\x18\x05 \x01(\x01R\frefundAmount\"\x9d\x01\n" +
+	"\x14RequestReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x129\n" +
+	"\x05items\x18\x04 \x03(\v2#.ecommerce.orders.ReturnItemRequestR\x05items\"H\n" +
+	"\x11ReturnItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"/\n" +
+	"\x10GetReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\tR\breturnId\"e\n" +
+	"\x12ListReturnsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"I\n" +
+	"\x13ListReturnsResponse\x122\n" +
+	"\areturns\x18\x01 \x03(\v2\x18.ecommerce.orders.ReturnR\areturns\"O\n" +
+	"\x14SearchReturnsRequest\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"3\n" +
+	"\x14ApproveReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\tR\breturnId\"J\n" +
+	"\x13RejectReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\tR\breturnId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"U\n" +
+	"\x11WatchOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\"X\n" +
+	"\x16WatchUserOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\"\x99\x01\n" +
+	"\x10OrderStatusEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x03R\bsequence\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\tR\tchangedAt\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"E\n" +
+	"\x16ExportUserDataResponse\x12+\n" +
+	"\x04data\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x04data\"/\n" +
+	"\x14EraseUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"e\n" +
+	"\x15EraseUserDataResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x05R\x06orders\x12\x1b\n" +
+	"\terased_at\x18\x03 \x01(\tR\berasedAt*\x97\x01\n" +
+	"\x0eOrderSortField\x12 \n" +
+	"\x1cORDER_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bORDER_SORT_FIELD_CREATED_AT\x10\x01\x12\x1f\n" +
+	"\x1bORDER_SORT_FIELD_UPDATED_AT\x10\x02\x12!\n" +
+	"\x1dORDER_SORT_FIELD_TOTAL_AMOUNT\x10\x03*\x88\x01\n" +
+	"\rPromotionKind\x12\x1e\n" +
+	"\x1aPROMOTION_KIND_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PROMOTION_KIND_PERCENTAGE\x10\x01\x12\x18\n" +
+	"\x14PROMOTION_KIND_FIXED\x10\x02\x12\x1e\n" +
+	"\x1aPROMOTION_KIND_BUY_X_GET_Y\x10\x032\xc7\x19\n" +
+	"\fOrderService\x12o\n" +
+	"\vCreateOrder\x12$.ecommerce.orders.CreateOrderRequest\x1a\x1f.ecommerce.orders.OrderResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/api/v1/orders\x12Z\n" +
+	"\x0eGetOrderStatus\x12'.ecommerce.orders.GetOrderStatusRequest\x1a\x1f.ecommerce.orders.OrderResponse\x12i\n" +
+	"\bGetOrder\x12!.ecommerce.orders.GetOrderRequest\x1a\x17.ecommerce.orders.Order\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/orders/{order_id}\x12o\n" +
+	"\n" +
+	"ListOrders\x12#.ecommerce.orders.ListOrdersRequest\x1a$.ecommerce.orders.ListOrdersResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/orders\x12y\n" +
+	"\vCancelOrder\x12$.ecommerce.orders.CancelOrderRequest\x1a\x17.ecommerce.orders.Order\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/orders/{order_id}:cancel\x12\x91\x01\n" +
+	"\x0fGetOrderHistory\x12(.ecommerce.orders.GetOrderHistoryRequest\x1a).ecommerce.orders.GetOrderHistoryResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/orders/{order_id}/history\x12v\n" +
+	"\n" +
+	"AmendOrder\x12#.ecommerce.orders.AmendOrderRequest\x1a\x17.ecommerce.orders.Order\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/orders/{order_id}:amend\x12\xa0\x01\n" +
+	"\x13ListOrderAmendments\x12,.ecommerce.orders.ListOrderAmendmentsRequest\x1a-.ecommerce.orders.ListOrderAmendmentsResponse\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/orders/{order_id}/amendments\x12{\n" +
+	"\fSearchOrders\x12%.ecommerce.orders.SearchOrdersRequest\x1a&.ecommerce.orders.SearchOrdersResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/admin/orders\x12\x99\x01\n" +
+	"\x14ListSellerOrderLines\x12-.ecommerce.orders.ListSellerOrderLinesRequest\x1a..ecommerce.orders.ListSellerOrderLinesResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/seller/order-lines\x12\xb1\x01\n" +
+	"\x15UpdateLineFulfillment\x12..ecommerce.orders.UpdateLineFulfillmentRequest\x1a/.ecommerce.orders.UpdateLineFulfillmentResponse\"7\x82\xd3\xe4\x93\x021:\x01*\",/api/v1/seller/orders/{order_id}/fulfillment\x12\x96\x01\n" +
+	"\x13GetSellerDailySales\x12,.ecommerce.orders.GetSellerDailySalesRequest\x1a-.ecommerce.orders.GetSellerDailySalesResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/seller/sales/daily\x12\x85\x01\n" +
+	"\x0fCreatePromotion\x12(.ecommerce.orders.CreatePromotionRequest\x1a\x1b.ecommerce.orders.Promotion\"+\x82\xd3\xe4\x93\x02%:\tpromotion\"\x18/api/v1/admin/promotions\x12\x85\x01\n" +
+	"\x0eListPromotions\x12'.ecommerce.orders.ListPromotionsRequest\x1a(.ecommerce.orders.ListPromotionsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/admin/promotions\x12\x9c\x01\n" +
+	"\x12SetPromotionActive\x12+.ecommerce.orders.SetPromotionActiveRequest\x1a\x1b.ecommerce.orders.Promotion\"<\x82\xd3\xe4\x93\x026:\x01*\"1/api/v1/admin/promotions/{promotion_id}:setActive\x12\x7f\n" +
+	"\rRequestReturn\x12&.ecommerce.orders.RequestReturnRequest\x1a\x18.ecommerce.orders.Return\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/orders/{order_id}/returns\x12n\n" +
+	"\tGetReturn\x12\".ecommerce.orders.GetReturnRequest\x1a\x18.ecommerce.orders.Return\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/returns/{return_id}\x12s\n" +
+	"\vListReturns\x12$.ecommerce.orders.ListReturnsRequest\x1a%.ecommerce.orders.ListReturnsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/returns\x12}\n" +
+	"\rSearchReturns\x12&.ecommerce.orders.SearchReturnsRequest\x1a%.ecommerce.orders.ListReturnsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/admin/returns\x12\x87\x01\n" +
+	"\rApproveReturn\x12&.ecommerce.orders.ApproveReturnRequest\x1a\x18.ecommerce.orders.Return\"4\x82\xd3\xe4\x93\x02.:\x01*\")/api/v1/admin/returns/{return_id}:approve\x12\x84\x01\n" +
+	"\fRejectReturn\x12%.ecommerce.orders.RejectReturnRequest\x1a\x18.ecommerce.orders.Return\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1/admin/returns/{return_id}:reject\x12\x8a\x01\n" +
+	"\x0eExportUserData\x12'.ecommerce.orders.ExportUserDataRequest\x1a(.ecommerce.orders.ExportUserDataResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/orders:exportUserData\x12\x8f\x01\n" +
+	"\rEraseUserData\x12&.ecommerce.orders.EraseUserDataRequest\x1a'.ecommerce.orders.EraseUserDataResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/admin/orders:eraseUserData\x12W\n" +
+	"\n" +
+	"WatchOrder\x12#.ecommerce.orders.WatchOrderRequest\x1a\".ecommerce.orders.OrderStatusEvent0\x01\x12a\n" +
+	"\x0fWatchUserOrders\x12(.ecommerce.orders.WatchUserOrdersRequest\x1a\".ecommerce.orders.OrderStatusEvent0\x01BFZDgithub.com/Asfm445/Distributed_EcommerceProject/order_service/pkg/pbb\x06proto3"
 
 var (
 	file_proto_order_proto_rawDescOnce sync.Once
@@ -383,26 +4180,151 @@ func file_proto_order_proto_rawDescGZIP() []byte {
 	return file_proto_order_proto_rawDescData
 }
 
-var file_proto_order_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_order_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_order_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_proto_order_proto_goTypes = []any{
-	(*OrderItem)(nil),             // 0: ecommerce.orders.OrderItem
-	(*Address)(nil),               // 1: ecommerce.orders.Address
-	(*CreateOrderRequest)(nil),    // 2: ecommerce.orders.CreateOrderRequest
-	(*OrderResponse)(nil),         // 3: ecommerce.orders.OrderResponse
-	(*GetOrderStatusRequest)(nil), // 4: ecommerce.orders.GetOrderStatusRequest
+	(OrderSortField)(0),                   // 0: ecommerce.orders.OrderSortField
+	(PromotionKind)(0),                    // 1: ecommerce.orders.PromotionKind
+	(*OrderItem)(nil),                     // 2: ecommerce.orders.OrderItem
+	(*Address)(nil),                       // 3: ecommerce.orders.Address
+	(*CreateOrderRequest)(nil),            // 4: ecommerce.orders.CreateOrderRequest
+	(*OrderResponse)(nil),                 // 5: ecommerce.orders.OrderResponse
+	(*GetOrderStatusRequest)(nil),         // 6: ecommerce.orders.GetOrderStatusRequest
+	(*Order)(nil),                         // 7: ecommerce.orders.Order
+	(*TaxBreakdown)(nil),                  // 8: ecommerce.orders.TaxBreakdown
+	(*OrderDiscount)(nil),                 // 9: ecommerce.orders.OrderDiscount
+	(*GetOrderRequest)(nil),               // 10: ecommerce.orders.GetOrderRequest
+	(*ListOrdersRequest)(nil),             // 11: ecommerce.orders.ListOrdersRequest
+	(*ListOrdersResponse)(nil),            // 12: ecommerce.orders.ListOrdersResponse
+	(*CancelOrderRequest)(nil),            // 13: ecommerce.orders.CancelOrderRequest
+	(*GetOrderHistoryRequest)(nil),        // 14: ecommerce.orders.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),       // 15: ecommerce.orders.GetOrderHistoryResponse
+	(*OrderHistoryEntry)(nil),             // 16: ecommerce.orders.OrderHistoryEntry
+	(*AmendOrderRequest)(nil),             // 17: ecommerce.orders.AmendOrderRequest
+	(*ItemChange)(nil),                    // 18: ecommerce.orders.ItemChange
+	(*ListOrderAmendmentsRequest)(nil),    // 19: ecommerce.orders.ListOrderAmendmentsRequest
+	(*ListOrderAmendmentsResponse)(nil),   // 20: ecommerce.orders.ListOrderAmendmentsResponse
+	(*OrderAmendment)(nil),                // 21: ecommerce.orders.OrderAmendment
+	(*AmendmentChange)(nil),               // 22: ecommerce.orders.AmendmentChange
+	(*SearchOrdersRequest)(nil),           // 23: ecommerce.orders.SearchOrdersRequest
+	(*SearchOrdersResponse)(nil),          // 24: ecommerce.orders.SearchOrdersResponse
+	(*SellerOrderLine)(nil),               // 25: ecommerce.orders.SellerOrderLine
+	(*ListSellerOrderLinesRequest)(nil),   // 26: ecommerce.orders.ListSellerOrderLinesRequest
+	(*ListSellerOrderLinesResponse)(nil),  // 27: ecommerce.orders.ListSellerOrderLinesResponse
+	(*UpdateLineFulfillmentRequest)(nil),  // 28: ecommerce.orders.UpdateLineFulfillmentRequest
+	(*UpdateLineFulfillmentResponse)(nil), // 29: ecommerce.orders.UpdateLineFulfillmentResponse
+	(*GetSellerDailySalesRequest)(nil),    // 30: ecommerce.orders.GetSellerDailySalesRequest
+	(*DailySales)(nil),                    // 31: ecommerce.orders.DailySales
+	(*GetSellerDailySalesResponse)(nil),   // 32: ecommerce.orders.GetSellerDailySalesResponse
+	(*Promotion)(nil),                     // 33: ecommerce.orders.Promotion
+	(*CreatePromotionRequest)(nil),        // 34: ecommerce.orders.CreatePromotionRequest
+	(*ListPromotionsRequest)(nil),         // 35: ecommerce.orders.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),        // 36: ecommerce.orders.ListPromotionsResponse
+	(*SetPromotionActiveRequest)(nil),     // 37: ecommerce.orders.SetPromotionActiveRequest
+	(*Return)(nil),                        // 38: ecommerce.orders.Return
+	(*ReturnItem)(nil),                    // 39: ecommerce.orders.ReturnItem
+	(*RequestReturnRequest)(nil),          // 40: ecommerce.orders.RequestReturnRequest
+	(*ReturnItemRequest)(nil),             // 41: ecommerce.orders.ReturnItemRequest
+	(*GetReturnRequest)(nil),              // 42: ecommerce.orders.GetReturnRequest
+	(*ListReturnsRequest)(nil),            // 43: ecommerce.orders.ListReturnsRequest
+	(*ListReturnsResponse)(nil),           // 44: ecommerce.orders.ListReturnsResponse
+	(*SearchReturnsRequest)(nil),          // 45: ecommerce.orders.SearchReturnsRequest
+	(*ApproveReturnRequest)(nil),          // 46: ecommerce.orders.ApproveReturnRequest
+	(*RejectReturnRequest)(nil),           // 47: ecommerce.orders.RejectReturnRequest
+	(*WatchOrderRequest)(nil),             // 48: ecommerce.orders.WatchOrderRequest
+	(*WatchUserOrdersRequest)(nil),        // 49: ecommerce.orders.WatchUserOrdersRequest
+	(*OrderStatusEvent)(nil),              // 50: ecommerce.orders.OrderStatusEvent
+	(*ExportUserDataRequest)(nil),         // 51: ecommerce.orders.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),        // 52: ecommerce.orders.ExportUserDataResponse
+	(*EraseUserDataRequest)(nil),          // 53: ecommerce.orders.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),         // 54: ecommerce.orders.EraseUserDataResponse
+	nil,                                   // 55: ecommerce.orders.OrderHistoryEntry.MetadataEntry
+	(*structpb.Struct)(nil),               // 56: google.protobuf.Struct
 }
 var file_proto_order_proto_depIdxs = []int32{
-	0, // 0: ecommerce.orders.CreateOrderRequest.items:type_name -> ecommerce.orders.OrderItem
-	1, // 1: ecommerce.orders.CreateOrderRequest.shipping_address:type_name -> ecommerce.orders.Address
-	2, // 2: ecommerce.orders.OrderService.CreateOrder:input_type -> ecommerce.orders.CreateOrderRequest
-	4, // 3: ecommerce.orders.OrderService.GetOrderStatus:input_type -> ecommerce.orders.GetOrderStatusRequest
-	3, // 4: ecommerce.orders.OrderService.CreateOrder:output_type -> ecommerce.orders.OrderResponse
-	3, // 5: ecommerce.orders.OrderService.GetOrderStatus:output_type -> ecommerce.orders.OrderResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: ecommerce.orders.CreateOrderRequest.items:type_name -> ecommerce.orders.OrderItem
+	3,  // 1: ecommerce.orders.CreateOrderRequest.shipping_address:type_name -> ecommerce.orders.Address
+	3,  // 2: ecommerce.orders.CreateOrderRequest.billing_address:type_name -> ecommerce.orders.Address
+	2,  // 3: ecommerce.orders.Order.items:type_name -> ecommerce.orders.OrderItem
+	9,  // 4: ecommerce.orders.Order.discounts:type_name -> ecommerce.orders.OrderDiscount
+	8,  // 5: ecommerce.orders.Order.tax_breakdown:type_name -> ecommerce.orders.TaxBreakdown
+	3,  // 6: ecommerce.orders.Order.shipping_address:type_name -> ecommerce.orders.Address
+	3,  // 7: ecommerce.orders.Order.billing_address:type_name -> ecommerce.orders.Address
+	7,  // 8: ecommerce.orders.ListOrdersResponse.orders:type_name -> ecommerce.orders.Order
+	16, // 9: ecommerce.orders.GetOrderHistoryResponse.entries:type_name -> ecommerce.orders.OrderHistoryEntry
+	55, // 10: ecommerce.orders.OrderHistoryEntry.metadata:type_name -> ecommerce.orders.OrderHistoryEntry.MetadataEntry
+	18, // 11: ecommerce.orders.AmendOrderRequest.items:type_name -> ecommerce.orders.ItemChange
+	3,  // 12: ecommerce.orders.AmendOrderRequest.shipping_address:type_name -> ecommerce.orders.Address
+	3,  // 13: ecommerce.orders.AmendOrderRequest.billing_address:type_name -> ecommerce.orders.Address
+	21, // 14: ecommerce.orders.ListOrderAmendmentsResponse.amendments:type_name -> ecommerce.orders.OrderAmendment
+	22, // 15: ecommerce.orders.OrderAmendment.changes:type_name -> ecommerce.orders.AmendmentChange
+	0,  // 16: ecommerce.orders.SearchOrdersRequest.sort_by:type_name -> ecommerce.orders.OrderSortField
+	7,  // 17: ecommerce.orders.SearchOrdersResponse.orders:type_name -> ecommerce.orders.Order
+	25, // 18: ecommerce.orders.ListSellerOrderLinesResponse.lines:type_name -> ecommerce.orders.SellerOrderLine
+	25, // 19: ecommerce.orders.UpdateLineFulfillmentResponse.lines:type_name -> ecommerce.orders.SellerOrderLine
+	31, // 20: ecommerce.orders.GetSellerDailySalesResponse.days:type_name -> ecommerce.orders.DailySales
+	1,  // 21: ecommerce.orders.Promotion.kind:type_name -> ecommerce.orders.PromotionKind
+	33, // 22: ecommerce.orders.CreatePromotionRequest.promotion:type_name -> ecommerce.orders.Promotion
+	33, // 23: ecommerce.orders.ListPromotionsResponse.promotions:type_name -> ecommerce.orders.Promotion
+	39, // 24: ecommerce.orders.Return.items:type_name -> ecommerce.orders.ReturnItem
+	41, // 25: ecommerce.orders.RequestReturnRequest.items:type_name -> ecommerce.orders.ReturnItemRequest
+	38, // 26: ecommerce.orders.ListReturnsResponse.returns:type_name -> ecommerce.orders.Return
+	56, // 27: ecommerce.orders.ExportUserDataResponse.data:type_name -> google.protobuf.Struct
+	4,  // 28: ecommerce.orders.OrderService.CreateOrder:input_type -> ecommerce.orders.CreateOrderRequest
+	6,  // 29: ecommerce.orders.OrderService.GetOrderStatus:input_type -> ecommerce.orders.GetOrderStatusRequest
+	10, // 30: ecommerce.orders.OrderService.GetOrder:input_type -> ecommerce.orders.GetOrderRequest
+	11, // 31: ecommerce.orders.OrderService.ListOrders:input_type -> ecommerce.orders.ListOrdersRequest
+	13, // 32: ecommerce.orders.OrderService.CancelOrder:input_type -> ecommerce.orders.CancelOrderRequest
+	14, // 33: ecommerce.orders.OrderService.GetOrderHistory:input_type -> ecommerce.orders.GetOrderHistoryRequest
+	17, // 34: ecommerce.orders.OrderService.AmendOrder:input_type -> ecommerce.orders.AmendOrderRequest
+	19, // 35: ecommerce.orders.OrderService.ListOrderAmendments:input_type -> ecommerce.orders.ListOrderAmendmentsRequest
+	23, // 36: ecommerce.orders.OrderService.SearchOrders:input_type -> ecommerce.orders.SearchOrdersRequest
+	26, // 37: ecommerce.orders.OrderService.ListSellerOrderLines:input_type -> ecommerce.orders.ListSellerOrderLinesRequest
+	28, // 38: ecommerce.orders.OrderService.UpdateLineFulfillment:input_type -> ecommerce.orders.UpdateLineFulfillmentRequest
+	30, // 39: ecommerce.orders.OrderService.GetSellerDailySales:input_type -> ecommerce.orders.GetSellerDailySalesRequest
+	34, // 40: ecommerce.orders.OrderService.CreatePromotion:input_type -> ecommerce.orders.CreatePromotionRequest
+	35, // 41: ecommerce.orders.OrderService.ListPromotions:input_type -> ecommerce.orders.ListPromotionsRequest
+	37, // 42: ecommerce.orders.OrderService.SetPromotionActive:input_type -> ecommerce.orders.SetPromotionActiveRequest
+	40, // 43: ecommerce.orders.OrderService.RequestReturn:input_type -> ecommerce.orders.RequestReturnRequest
+	42, // 44: ecommerce.orders.OrderService.GetReturn:input_type -> ecommerce.orders.GetReturnRequest
+	43, // 45: ecommerce.orders.OrderService.ListReturns:input_type -> ecommerce.orders.ListReturnsRequest
+	45, // 46: ecommerce.orders.OrderService.SearchReturns:input_type -> ecommerce.orders.SearchReturnsRequest
+	46, // 47: ecommerce.orders.OrderService.ApproveReturn:input_type -> ecommerce.orders.ApproveReturnRequest
+	47, // 48: ecommerce.orders.OrderService.RejectReturn:input_type -> ecommerce.orders.RejectReturnRequest
+	51, // 49: ecommerce.orders.OrderService.ExportUserData:input_type -> ecommerce.orders.ExportUserDataRequest
+	53, // 50: ecommerce.orders.OrderService.EraseUserData:input_type -> ecommerce.orders.EraseUserDataRequest
+	48, // 51: ecommerce.orders.OrderService.WatchOrder:input_type -> ecommerce.orders.WatchOrderRequest
+	49, // 52: ecommerce.orders.OrderService.WatchUserOrders:input_type -> ecommerce.orders.WatchUserOrdersRequest
+	5,  // 53: ecommerce.orders.OrderService.CreateOrder:output_type -> ecommerce.orders.OrderResponse
+	5,  // 54: ecommerce.orders.OrderService.GetOrderStatus:output_type -> ecommerce.orders.OrderResponse
+	7,  // 55: ecommerce.orders.OrderService.GetOrder:output_type -> ecommerce.orders.Order
+	12, // 56: ecommerce.orders.OrderService.ListOrders:output_type -> ecommerce.orders.ListOrdersResponse
+	7,  // 57: ecommerce.orders.OrderService.CancelOrder:output_type -> ecommerce.orders.Order
+	15, // 58: ecommerce.orders.OrderService.GetOrderHistory:output_type -> ecommerce.orders.GetOrderHistoryResponse
+	7,  // 59: ecommerce.orders.OrderService.AmendOrder:output_type -> ecommerce.orders.Order
+	20, // 60: ecommerce.orders.OrderService.ListOrderAmendments:output_type -> ecommerce.orders.ListOrderAmendmentsResponse
+	24, // 61: ecommerce.orders.OrderService.SearchOrders:output_type -> ecommerce.orders.SearchOrdersResponse
+	27, // 62: ecommerce.orders.OrderService.ListSellerOrderLines:output_type -> ecommerce.orders.ListSellerOrderLinesResponse
+	29, // 63: ecommerce.orders.OrderService.UpdateLineFulfillment:output_type -> ecommerce.orders.UpdateLineFulfillmentResponse
+	32, // 64: ecommerce.orders.OrderService.GetSellerDailySales:output_type -> ecommerce.orders.GetSellerDailySalesResponse
+	33, // 65: ecommerce.orders.OrderService.CreatePromotion:output_type -> ecommerce.orders.Promotion
+	36, // 66: ecommerce.orders.OrderService.ListPromotions:output_type -> ecommerce.orders.ListPromotionsResponse
+	33, // 67: ecommerce.orders.OrderService.SetPromotionActive:output_type -> ecommerce.orders.Promotion
+	38, // 68: ecommerce.orders.OrderService.RequestReturn:output_type -> ecommerce.orders.Return
+	38, // 69: ecommerce.orders.OrderService.GetReturn:output_type -> ecommerce.orders.Return
+	44, // 70: ecommerce.orders.OrderService.ListReturns:output_type -> ecommerce.orders.ListReturnsResponse
+	44, // 71: ecommerce.orders.OrderService.SearchReturns:output_type -> ecommerce.orders.ListReturnsResponse
+	38, // 72: ecommerce.orders.OrderService.ApproveReturn:output_type -> ecommerce.orders.Return
+	38, // 73: ecommerce.orders.OrderService.RejectReturn:output_type -> ecommerce.orders.Return
+	52, // 74: ecommerce.orders.OrderService.ExportUserData:output_type -> ecommerce.orders.ExportUserDataResponse
+	54, // 75: ecommerce.orders.OrderService.EraseUserData:output_type -> ecommerce.orders.EraseUserDataResponse
+	50, // 76: ecommerce.orders.OrderService.WatchOrder:output_type -> ecommerce.orders.OrderStatusEvent
+	50, // 77: ecommerce.orders.OrderService.WatchUserOrders:output_type -> ecommerce.orders.OrderStatusEvent
+	53, // [53:78] is the sub-list for method output_type
+	28, // [28:53] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_order_proto_init() }
@@ -410,18 +4332,21 @@ func file_proto_order_proto_init() {
 	if File_proto_order_proto != nil {
 		return
 	}
+	file_proto_order_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_order_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_order_proto_rawDesc), len(file_proto_order_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_order_proto_goTypes,
 		DependencyIndexes: file_proto_order_proto_depIdxs,
+		EnumInfos:         file_proto_order_proto_enumTypes,
 		MessageInfos:      file_proto_order_proto_msgTypes,
 	}.Build()
 	File_proto_order_proto = out.File
@@ -0,0 +1,398 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Order is an order as charged, with the seller lines its payment is split
+// between. Amount is what the customer is charged, zero when unknown.
+type Order struct {
+	ID       string
+	Currency string
+	Amount   int64
+	Lines    []OrderLine
+}
+
+// OrderLine is the gross amount of one order line and who sold it.
+type OrderLine struct {
+	ProductID string
+	SellerID  string
+	Amount    int64
+}
+
+// Payment is a successful charge of an order.
+type Payment struct {
+	ID      string
+	OrderID string
+	Amount  int64
+	At      time.Time
+}
+
+// paymentNamespace is the UUID namespace payment IDs are derived in.
+var paymentNamespace = uuid.MustParse("0b6c3f4e-9a7d-5e21-8c4b-2f1d6a9e7c30")
+
+// PaymentID returns the ID of the payment of an order. It is derived from the
+// order ID, so that charging an order again, e.g. on a redelivered
+// order.created, yields the same payment.
+func PaymentID(orderID string) string {
+	return uuid.NewSHA1(paymentNamespace, []byte(orderID)).String()
+}
+
+// Refund returns money of an order to the customer. Lines allocates it to
+// order lines; without lines every line is refunded in full.
+type Refund struct {
+	ID      string
+	OrderID string
+	Amount  int64
+	Lines   []OrderLine
+	At      time.Time
+}
+
+// Adjustment is what an amendment changes of an order whose charge was
+// fixed: Amount is the amended amount less the charged one, and Lines the
+// amount each charged line decreased by.
+type Adjustment struct {
+	Amount int64
+	Lines  []OrderLine
+}
+
+// orderRecord is an order as recorded when it was charged. Commission rates
+// are fixed at that time so later rate changes do not alter past sales.
+// ChargedAmount is set once the charge is fixed.
+type orderRecord struct {
+	OrderID       string `gorm:"primaryKey"`
+	Currency      string
+	Amount        int64
+	ChargedAmount int64
+	RecordedAt    time.Time
+
+	Lines []orderLineRecord `gorm:"foreignKey:OrderID;references:OrderID"`
+}
+
+func (orderRecord) TableName() string { return "ledger_orders" }
+
+type orderLineRecord struct {
+	ID             uint `gorm:"primaryKey"`
+	OrderID        string
+	ProductID      string
+	SellerID       string
+	Amount         int64
+	CommissionRate float64
+	Refunded       int64
+}
+
+func (orderLineRecord) TableName() string { return "ledger_order_lines" }
+
+// Ledger books sales, refunds and payouts.
+type Ledger struct {
+	db            *gorm.DB
+	rates         Rates
+	payoutMinimum int64
+}
+
+func New(db *gorm.DB, rates Rates, payoutMinimum int64) *Ledger {
+	return &Ledger{db: db, rates: rates, payoutMinimum: payoutMinimum}
+}
+
+// RecordOrder remembers how an order is split between sellers before it is
+// charged. Recording an order again leaves the first record in place.
+func (l *Ledger) RecordOrder(ctx context.Context, order Order) error {
+	record := l.record(order)
+	return l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Omit("Lines").Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+		if res.Error != nil || res.RowsAffected == 0 || len(record.Lines) == 0 {
+			return res.Error
+		}
+		return tx.Create(&record.Lines).Error
+	})
+}
+
+// AmendOrder replaces the amount and lines of an order whose charge is not
+// fixed yet, recording it when it was not, and returns nil. Once ChargeOrder
+// fixed the charge, the sale is split on the lines charged, so the order is
+// left as is and the adjustment from the charged order is returned instead.
+// It fails with ErrAlreadyPaid for an order paid before charges were fixed.
+func (l *Ledger) AmendOrder(ctx context.Context, order Order) (*Adjustment, error) {
+	record := l.record(order)
+	var adjustment *Adjustment
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The update skips, and ChargeOrder waits for, an order whose
+		// charge is being fixed at the same time.
+		res := tx.Omit("Lines").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "order_id"}},
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Table: record.TableName(), Name: "charged_amount"}, Value: 0}}},
+			DoUpdates: clause.AssignmentColumns([]string{"currency", "amount"}),
+		}).Create(&record)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			charged, err := loadOrder(tx, order.ID)
+			if err != nil {
+				return err
+			}
+			adjustment = adjust(charged, order)
+			return nil
+		}
+		var sales int64
+		if err := tx.Model(&JournalEntry{}).Where("order_id = ? AND kind = ?", order.ID, KindSale).Count(&sales).Error; err != nil {
+			return err
+		}
+		if sales > 0 {
+			return fmt.Errorf("%w: %s", ErrAlreadyPaid, order.ID)
+		}
+		if err := tx.Where("order_id = ?", order.ID).Delete(&orderLineRecord{}).Error; err != nil {
+			return err
+		}
+		if len(record.Lines) == 0 {
+			return nil
+		}
+		return tx.Create(&record.Lines).Error
+	})
+	return adjustment, err
+}
+
+// adjust compares an amended order with the charged one, matching lines by
+// product and seller.
+func adjust(charged *orderRecord, amended Order) *Adjustment {
+	adjustment := &Adjustment{Amount: amended.Amount - charged.ChargedAmount}
+	remaining := map[[2]string]int64{}
+	for _, line := range amended.Lines {
+		remaining[[2]string{line.ProductID, line.SellerID}] += line.Amount
+	}
+	for _, line := range charged.Lines {
+		key := [2]string{line.ProductID, line.SellerID}
+		kept := min(remaining[key], line.Amount)
+		remaining[key] -= kept
+		if line.Amount > kept {
+			adjustment.Lines = append(adjustment.Lines, OrderLine{ProductID: line.ProductID, SellerID: line.SellerID, Amount: line.Amount - kept})
+		}
+	}
+	return adjustment
+}
+
+// ChargeOrder fixes the amount a recorded order is charged, as last amended,
+// and returns it. Later amendments no longer change what is charged; see
+// AmendOrder. Charging again returns the amount fixed first.
+func (l *Ledger) ChargeOrder(ctx context.Context, orderID string) (int64, error) {
+	var record orderRecord
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&orderRecord{}).Where("order_id = ? AND charged_amount = 0", orderID).
+			Update("charged_amount", gorm.Expr("amount")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Select("charged_amount").First(&record, "order_id = ?", orderID).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownOrder, orderID)
+	}
+	return record.ChargedAmount, err
+}
+
+func (l *Ledger) record(order Order) orderRecord {
+	record := orderRecord{OrderID: order.ID, Currency: order.Currency, Amount: order.Amount, RecordedAt: time.Now()}
+	for _, line := range order.Lines {
+		record.Lines = append(record.Lines, orderLineRecord{
+			OrderID:        order.ID,
+			ProductID:      line.ProductID,
+			SellerID:       line.SellerID,
+			Amount:         line.Amount,
+			CommissionRate: l.rates.For(line.SellerID),
+		})
+	}
+	return record
+}
+
+// BookPayment books a sale: the payment is debited to platform cash and
+// credited to each seller's payable, less commission. Booking the same
+// payment twice has no effect; booking another payment of an order already
+// paid fails with ErrAlreadyPaid.
+func (l *Ledger) BookPayment(ctx context.Context, p Payment) error {
+	return l.book(ctx, "sale:"+p.ID, func(tx *gorm.DB) (*JournalEntry, error) {
+		order, err := loadOrder(tx, p.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		var sales int64
+		if err := tx.Model(&JournalEntry{}).Where("order_id = ? AND kind = ?", p.OrderID, KindSale).Count(&sales).Error; err != nil {
+			return nil, err
+		}
+		if sales > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyPaid, p.OrderID)
+		}
+		entry := &JournalEntry{Kind: KindSale, OrderID: p.OrderID, Currency: order.Currency, BookedAt: p.At}
+		entry.debit(AccountPlatformCash, "", p.Amount)
+		amounts := make([]int64, len(order.Lines))
+		for i, line := range order.Lines {
+			amounts[i] = line.Amount
+		}
+		shares := splitBySeller(order.Lines, amounts)
+		entry.credit(AccountOrderAdjustments, "", p.Amount-bookShares(entry, shares))
+		return entry, nil
+	})
+}
+
+// BookRefund reverses the refunded part of a sale, commission included.
+// Booking the same refund twice has no effect.
+func (l *Ledger) BookRefund(ctx context.Context, r Refund) error {
+	return l.book(ctx, "refund:"+r.ID, func(tx *gorm.DB) (*JournalEntry, error) {
+		order, err := loadOrder(tx, r.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		var sales int64
+		if err := tx.Model(&JournalEntry{}).Where("order_id = ? AND kind = ?", r.OrderID, KindSale).Count(&sales).Error; err != nil {
+			return nil, err
+		}
+		if sales == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotPaid, r.OrderID)
+		}
+
+		refunds, err := allocateRefund(order.Lines, r.Lines)
+		if err != nil {
+			return nil, err
+		}
+		for i := range order.Lines {
+			if refunds[i] == 0 {
+				continue
+			}
+			err := tx.Model(&order.Lines[i]).Update("refunded", gorm.Expr("refunded + ?", refunds[i])).Error
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// The reverse of a sale: credit cash, debit the shares back.
+		entry := &JournalEntry{Kind: KindRefund, OrderID: r.OrderID, Currency: order.Currency, BookedAt: r.At}
+		entry.credit(AccountPlatformCash, "", r.Amount)
+		amounts := make([]int64, len(refunds))
+		for i, refund := range refunds {
+			amounts[i] = -refund
+		}
+		shares := splitBySeller(order.Lines, amounts)
+		entry.credit(AccountOrderAdjustments, "", -r.Amount-bookShares(entry, shares))
+		return entry, nil
+	})
+}
+
+// book runs build and stores the entry it returns under reference, unless an
+// entry with that reference exists.
+func (l *Ledger) book(ctx context.Context, reference string, build func(tx *gorm.DB) (*JournalEntry, error)) error {
+	return l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&JournalEntry{}).Where("reference = ?", reference).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+		entry, err := build(tx)
+		if err != nil {
+			return err
+		}
+		entry.ID = uuid.NewString()
+		entry.Reference = reference
+		if entry.BookedAt.IsZero() {
+			entry.BookedAt = time.Now()
+		}
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func loadOrder(tx *gorm.DB, orderID string) (*orderRecord, error) {
+	var order orderRecord
+	err := tx.Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&order, "order_id = ?", orderID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOrder, orderID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// sellerShare is the gross amount of a seller's lines and the commission on
+// it. Both are negative for refunds.
+type sellerShare struct {
+	sellerID   string
+	gross      int64
+	commission int64
+}
+
+// splitBySeller sums the amounts booked on each line per seller, in the
+// order the sellers first appear, computing commission per line at the
+// line's rate.
+func splitBySeller(lines []orderLineRecord, amounts []int64) []sellerShare {
+	var shares []sellerShare
+	index := map[string]int{}
+	for j, line := range lines {
+		gross := amounts[j]
+		if gross == 0 {
+			continue
+		}
+		i, ok := index[line.SellerID]
+		if !ok {
+			i = len(shares)
+			index[line.SellerID] = i
+			shares = append(shares, sellerShare{sellerID: line.SellerID})
+		}
+		shares[i].gross += gross
+		shares[i].commission += commission(gross, line.CommissionRate)
+	}
+	return shares
+}
+
+// bookShares credits each seller's payable with their net share and the
+// platform's commission, and returns the gross total.
+func bookShares(entry *JournalEntry, shares []sellerShare) int64 {
+	var total int64
+	for _, s := range shares {
+		entry.credit(AccountSellerPayable, s.sellerID, s.gross-s.commission)
+		entry.credit(AccountCommissionRevenue, s.sellerID, s.commission)
+		total += s.gross
+	}
+	return total
+}
+
+// allocateRefund returns the amount refunded on each order line. Requested
+// lines are matched by seller and product; without any, every line is
+// refunded what remains of it.
+func allocateRefund(lines []orderLineRecord, requested []OrderLine) ([]int64, error) {
+	refunds := make([]int64, len(lines))
+	if len(requested) == 0 {
+		for i, line := range lines {
+			refunds[i] = line.Amount - line.Refunded
+		}
+		return refunds, nil
+	}
+	for _, req := range requested {
+		if req.Amount <= 0 {
+			return nil, fmt.Errorf("%w: non-positive amount for product %s", ErrInvalidRefund, req.ProductID)
+		}
+		remaining := req.Amount
+		for i, line := range lines {
+			if line.SellerID != req.SellerID || line.ProductID != req.ProductID {
+				continue
+			}
+			take := min(remaining, line.Amount-line.Refunded-refunds[i])
+			refunds[i] += take
+			remaining -= take
+		}
+		if remaining > 0 {
+			return nil, fmt.Errorf("%w: product %s of seller %s is refunded beyond its amount", ErrInvalidRefund, req.ProductID, req.SellerID)
+		}
+	}
+	return refunds, nil
+}
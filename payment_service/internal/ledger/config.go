@@ -0,0 +1,76 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config configures commission and payouts.
+type Config struct {
+	CommissionRate float64       `yaml:"commission_rate" env:"LEDGER_COMMISSION_RATE" flag:"commission-rate" default:"0.1" usage:"share of each sale kept as commission, between 0 and 1"`
+	SellerRates    []string      `yaml:"seller_commission_rates" env:"LEDGER_SELLER_COMMISSION_RATES" usage:"per-seller commission overrides as seller_id=rate, comma separated"`
+	PayoutInterval time.Duration `yaml:"payout_interval" env:"LEDGER_PAYOUT_INTERVAL" default:"24h" usage:"how often payout batches are produced; 0 disables the schedule"`
+	PayoutMinimum  float64       `yaml:"payout_minimum" env:"LEDGER_PAYOUT_MINIMUM" default:"0" usage:"smallest seller balance paid out; smaller ones carry over"`
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if _, err := c.Rates(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.PayoutInterval < 0 {
+		errs = append(errs, errors.New("payout_interval must not be negative"))
+	}
+	if c.PayoutMinimum < 0 {
+		errs = append(errs, errors.New("payout_minimum must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Rates resolves the commission rate of each seller.
+func (c *Config) Rates() (Rates, error) {
+	if err := checkRate(c.CommissionRate); err != nil {
+		return Rates{}, fmt.Errorf("commission_rate: %w", err)
+	}
+	rates := Rates{Default: c.CommissionRate, Sellers: map[string]float64{}}
+	for _, item := range c.SellerRates {
+		seller, value, ok := strings.Cut(item, "=")
+		seller = strings.TrimSpace(seller)
+		if !ok || seller == "" {
+			return Rates{}, fmt.Errorf("seller_commission_rates: %q is not seller_id=rate", item)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err == nil {
+			err = checkRate(rate)
+		}
+		if err != nil {
+			return Rates{}, fmt.Errorf("seller_commission_rates: %s: %w", seller, err)
+		}
+		rates.Sellers[seller] = rate
+	}
+	return rates, nil
+}
+
+func checkRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("rate %v is not between 0 and 1", rate)
+	}
+	return nil
+}
+
+// Rates holds the commission rate of each seller.
+type Rates struct {
+	Default float64
+	Sellers map[string]float64
+}
+
+// For returns the commission rate of a seller.
+func (r Rates) For(sellerID string) float64 {
+	if rate, ok := r.Sellers[sellerID]; ok {
+		return rate
+	}
+	return r.Default
+}
@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultBatchListLimit = 20
+
+// Register adds the ledger endpoints to mux. They do not authenticate
+// callers, so mux must be served on a management listener only.
+//
+//	GET  /ledger/sellers/{seller_id}/balance  payable balances of a seller
+//	GET  /ledger/payouts?limit=               recent payout batches
+//	POST /ledger/payouts                      run a payout batch now
+//	GET  /ledger/payouts/{id}/csv             a batch as CSV
+func Register(mux *http.ServeMux, l *Ledger) {
+	mux.HandleFunc("GET /ledger/sellers/{seller_id}/balance", func(w http.ResponseWriter, r *http.Request) {
+		balances, err := l.SellerBalances(r.Context(), r.PathValue("seller_id"))
+		if err != nil {
+			serverError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, balances)
+	})
+
+	mux.HandleFunc("GET /ledger/payouts", func(w http.ResponseWriter, r *http.Request) {
+		limit := defaultBatchListLimit
+		if s := r.URL.Query().Get("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		batches, err := l.ListPayoutBatches(r.Context(), limit)
+		if err != nil {
+			serverError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, batches)
+	})
+
+	mux.HandleFunc("POST /ledger/payouts", func(w http.ResponseWriter, r *http.Request) {
+		batch, err := l.CreatePayoutBatch(r.Context(), time.Now())
+		if err != nil {
+			serverError(w, err)
+			return
+		}
+		if batch == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusCreated, batch)
+	})
+
+	mux.HandleFunc("GET /ledger/payouts/{id}/csv", func(w http.ResponseWriter, r *http.Request) {
+		batch, err := l.GetPayoutBatch(r.Context(), r.PathValue("id"))
+		if errors.Is(err, ErrBatchNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			serverError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="payout-`+batch.ID+`.csv"`)
+		if err := WriteCSV(w, batch); err != nil {
+			log.Printf("Failed to write payout batch %s: %v", batch.ID, err)
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+func serverError(w http.ResponseWriter, err error) {
+	log.Printf("Ledger request failed: %v", err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
@@ -0,0 +1,134 @@
+// Package ledger keeps the marketplace's double-entry books: what customers
+// paid, what the platform earns in commission and what it owes each seller,
+// and the payout batches that settle those debts.
+//
+// Amounts are integers in minor units (cents); every currency is assumed to
+// have two decimal places.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Account is a ledger account. Seller payables are kept per seller through
+// the SellerID of the journal line.
+type Account string
+
+const (
+	// AccountPlatformCash holds what customers paid until sellers are paid out.
+	AccountPlatformCash Account = "platform_cash"
+	// AccountSellerPayable is what the platform owes a seller.
+	AccountSellerPayable Account = "seller_payable"
+	// AccountCommissionRevenue is the platform's commission on sales.
+	AccountCommissionRevenue Account = "commission_revenue"
+	// AccountOrderAdjustments absorbs the difference between what was charged
+	// and the value of the order lines, such as shipping or discounts.
+	AccountOrderAdjustments Account = "order_adjustments"
+)
+
+// EntryKind is the business event a journal entry records.
+type EntryKind string
+
+const (
+	KindSale   EntryKind = "sale"
+	KindRefund EntryKind = "refund"
+	KindPayout EntryKind = "payout"
+)
+
+var (
+	// ErrUnknownOrder is returned when booking a payment or refund for an
+	// order the ledger has not recorded.
+	ErrUnknownOrder = errors.New("ledger: unknown order")
+	// ErrNotPaid is returned when refunding an order whose payment has not
+	// been booked.
+	ErrNotPaid = errors.New("ledger: order has no booked payment")
+	// ErrInvalidRefund is returned, wrapped with the reason, for a refund
+	// that does not fit the order.
+	ErrInvalidRefund = errors.New("ledger: invalid refund")
+	// ErrAlreadyPaid is returned when amending an order paid before its
+	// charge was fixed, or booking a second payment of an order.
+	ErrAlreadyPaid = errors.New("ledger: order is already paid")
+	// ErrBatchNotFound is returned for an unknown payout batch.
+	ErrBatchNotFound = errors.New("ledger: payout batch not found")
+)
+
+// JournalEntry is a balanced set of journal lines booked together.
+// Reference identifies the business event and makes booking idempotent.
+type JournalEntry struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	Reference string    `json:"reference" gorm:"uniqueIndex"`
+	Kind      EntryKind `json:"kind"`
+	OrderID   string    `json:"order_id,omitempty" gorm:"default:null"`
+	BatchID   string    `json:"batch_id,omitempty" gorm:"default:null"`
+	Currency  string    `json:"currency"`
+	BookedAt  time.Time `json:"booked_at"`
+
+	Lines []JournalLine `json:"lines" gorm:"foreignKey:EntryID"`
+}
+
+// JournalLine debits or credits one account by a non-negative amount.
+// SellerID is empty on accounts that are not kept per seller.
+type JournalLine struct {
+	ID       uint    `json:"-" gorm:"primaryKey"`
+	EntryID  string  `json:"-"`
+	Account  Account `json:"account"`
+	SellerID string  `json:"seller_id,omitempty"`
+	Debit    int64   `json:"debit"`
+	Credit   int64   `json:"credit"`
+}
+
+// Validate checks that the entry is balanced and its lines well formed.
+func (e *JournalEntry) Validate() error {
+	var debits, credits int64
+	for _, l := range e.Lines {
+		if l.Debit < 0 || l.Credit < 0 || (l.Debit == 0) == (l.Credit == 0) {
+			return fmt.Errorf("ledger: line on %s must either debit or credit a positive amount", l.Account)
+		}
+		debits += l.Debit
+		credits += l.Credit
+	}
+	if debits != credits {
+		return fmt.Errorf("ledger: unbalanced entry %s: debits %d, credits %d", e.Reference, debits, credits)
+	}
+	return nil
+}
+
+// debit and credit append a line for a positive amount; zero amounts are
+// skipped and negative ones flip the side.
+func (e *JournalEntry) debit(account Account, sellerID string, amount int64) {
+	switch {
+	case amount > 0:
+		e.Lines = append(e.Lines, JournalLine{Account: account, SellerID: sellerID, Debit: amount})
+	case amount < 0:
+		e.Lines = append(e.Lines, JournalLine{Account: account, SellerID: sellerID, Credit: -amount})
+	}
+}
+
+func (e *JournalEntry) credit(account Account, sellerID string, amount int64) {
+	e.debit(account, sellerID, -amount)
+}
+
+// ToMinor converts an amount in major units, as carried by events, to minor
+// units.
+func ToMinor(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// FormatMinor formats minor units as a decimal amount, e.g. 1234 as "12.34".
+func FormatMinor(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return sign + strconv.FormatInt(amount/100, 10) + "." + fmt.Sprintf("%02d", amount%100)
+}
+
+// commission is the platform's share of a gross amount at rate, rounded
+// half away from zero to the minor unit.
+func commission(gross int64, rate float64) int64 {
+	return int64(math.Round(float64(gross) * rate))
+}
@@ -0,0 +1,249 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupLedger(t *testing.T, rates Rates, payoutMinimum int64) (*Ledger, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&orderRecord{}, &orderLineRecord{}, &JournalEntry{}, &JournalLine{}, &PayoutBatch{}, &PayoutItem{}))
+	return New(db, rates, payoutMinimum), db
+}
+
+// accountTotals sums credits minus debits per account and seller.
+func accountTotals(t *testing.T, db *gorm.DB) map[string]int64 {
+	t.Helper()
+	var lines []JournalLine
+	require.NoError(t, db.Find(&lines).Error)
+	totals := map[string]int64{}
+	var debits, credits int64
+	for _, l := range lines {
+		key := string(l.Account)
+		if l.SellerID != "" {
+			key += ":" + l.SellerID
+		}
+		totals[key] += l.Credit - l.Debit
+		debits += l.Debit
+		credits += l.Credit
+	}
+	assert.Equal(t, debits, credits, "the books balance")
+	return totals
+}
+
+var testOrder = Order{
+	ID:       "order-1",
+	Currency: "ETB",
+	Lines: []OrderLine{
+		{ProductID: "lamp", SellerID: "s1", Amount: 10000},
+		{ProductID: "mug", SellerID: "s2", Amount: 5000},
+		{ProductID: "bulb", SellerID: "s1", Amount: 2000},
+	},
+}
+
+func TestLedger_BookPayment(t *testing.T) {
+	l, db := setupLedger(t, Rates{Default: 0.1, Sellers: map[string]float64{"s2": 0.05}}, 0)
+	ctx := context.Background()
+
+	require.NoError(t, l.RecordOrder(ctx, testOrder))
+	require.NoError(t, l.RecordOrder(ctx, testOrder), "recording again is a no-op")
+	// Charged 17500 for 17000 of lines: 500 shipping.
+	payment := Payment{ID: "pay-1", OrderID: "order-1", Amount: 17500, At: time.Now()}
+	require.NoError(t, l.BookPayment(ctx, payment))
+	require.NoError(t, l.BookPayment(ctx, payment), "booking again is a no-op")
+
+	assert.Equal(t, map[string]int64{
+		"platform_cash":         -17500,
+		"seller_payable:s1":     10800,
+		"seller_payable:s2":     4750,
+		"commission_revenue:s1": 1200,
+		"commission_revenue:s2": 250,
+		"order_adjustments":     500,
+	}, accountTotals(t, db))
+
+	err := l.BookPayment(ctx, Payment{ID: "pay-2", OrderID: "unknown", Amount: 100})
+	assert.ErrorIs(t, err, ErrUnknownOrder)
+}
+
+func TestLedger_BookPayment_Redelivered(t *testing.T) {
+	l, db := setupLedger(t, Rates{Default: 0.1}, 0)
+	ctx := context.Background()
+	require.NoError(t, l.RecordOrder(ctx, testOrder))
+
+	// order.created delivered twice charges the same payment.
+	first, second := PaymentID("order-1"), PaymentID("order-1")
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, PaymentID("order-2"))
+	require.NoError(t, l.BookPayment(ctx, Payment{ID: first, OrderID: "order-1", Amount: 17000, At: time.Now()}))
+	require.NoError(t, l.BookPayment(ctx, Payment{ID: second, OrderID: "order-1", Amount: 17000, At: time.Now()}))
+
+	var sales int64
+	require.NoError(t, db.Model(&JournalEntry{}).Where("order_id = ? AND kind = ?", "order-1", KindSale).Count(&sales).Error)
+	assert.Equal(t, int64(1), sales)
+	assert.Equal(t, int64(-17000), accountTotals(t, db)["platform_cash"])
+
+	// A payment under another ID is not booked on top.
+	err := l.BookPayment(ctx, Payment{ID: "pay-legacy", OrderID: "order-1", Amount: 17000, At: time.Now()})
+	assert.ErrorIs(t, err, ErrAlreadyPaid)
+	assert.Equal(t, int64(-17000), accountTotals(t, db)["platform_cash"])
+}
+
+func TestLedger_BookRefund(t *testing.T) {
+	l, db := setupLedger(t, Rates{Default: 0.1}, 0)
+	ctx := context.Background()
+	require.NoError(t, l.RecordOrder(ctx, testOrder))
+
+	err := l.BookRefund(ctx, Refund{ID: "ref-0", OrderID: "order-1", Amount: 100})
+	assert.ErrorIs(t, err, ErrNotPaid)
+
+	require.NoError(t, l.BookPayment(ctx, Payment{ID: "pay-1", OrderID: "order-1", Amount: 17000}))
+
+	partial := Refund{ID: "ref-1", OrderID: "order-1", Amount: 2000, Lines: []OrderLine{{ProductID: "bulb", SellerID: "s1", Amount: 2000}}}
+	require.NoError(t, l.BookRefund(ctx, partial))
+	require.NoError(t, l.BookRefund(ctx, partial), "booking again is a no-op")
+	totals := accountTotals(t, db)
+	assert.Equal(t, int64(9000), totals["seller_payable:s1"])
+	assert.Equal(t, int64(1000), totals["commission_revenue:s1"])
+
+	err = l.BookRefund(ctx, Refund{ID: "ref-2", OrderID: "order-1", Amount: 1, Lines: []OrderLine{{ProductID: "bulb", SellerID: "s1", Amount: 1}}})
+	assert.ErrorIs(t, err, ErrInvalidRefund, "the bulb is fully refunded")
+
+	// The rest of the order, without lines.
+	require.NoError(t, l.BookRefund(ctx, Refund{ID: "ref-3", OrderID: "order-1", Amount: 15000}))
+	totals = accountTotals(t, db)
+	for key, amount := range totals {
+		assert.Zero(t, amount, key)
+	}
+}
+
+func TestLedger_AmendOrder(t *testing.T) {
+	l, db := setupLedger(t, Rates{Default: 0.1}, 0)
+	ctx := context.Background()
+	order := testOrder
+	order.Amount = 17500
+	require.NoError(t, l.RecordOrder(ctx, order))
+
+	// The mug is removed and a second lamp added.
+	amended := Order{ID: "order-1", Currency: "ETB", Amount: 22500, Lines: []OrderLine{
+		{ProductID: "lamp", SellerID: "s1", Amount: 20000},
+		{ProductID: "bulb", SellerID: "s1", Amount: 2000},
+	}}
+	adjustment, err := l.AmendOrder(ctx, amended)
+	require.NoError(t, err)
+	assert.Nil(t, adjustment, "the order is replaced until its charge is fixed")
+	amount, err := l.ChargeOrder(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(22500), amount)
+
+	// Once fixed, the charge stands and amendments become adjustments.
+	adjustment, err = l.AmendOrder(ctx, Order{ID: "order-1", Currency: "ETB", Amount: 12500, Lines: []OrderLine{
+		{ProductID: "lamp", SellerID: "s1", Amount: 10000},
+		{ProductID: "bulb", SellerID: "s1", Amount: 2000},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, &Adjustment{Amount: -10000, Lines: []OrderLine{{ProductID: "lamp", SellerID: "s1", Amount: 10000}}}, adjustment)
+	amount, err = l.ChargeOrder(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(22500), amount, "charging again keeps the fixed amount")
+
+	require.NoError(t, l.BookPayment(ctx, Payment{ID: "pay-1", OrderID: "order-1", Amount: amount}))
+	totals := accountTotals(t, db)
+	assert.Equal(t, int64(19800), totals["seller_payable:s1"])
+	assert.Zero(t, totals["seller_payable:s2"], "the removed line is not sold")
+
+	// The adjustment is refunded against the lines it reduced.
+	require.NoError(t, l.BookRefund(ctx, Refund{ID: "amendment-2", OrderID: "order-1", Amount: -adjustment.Amount, Lines: adjustment.Lines}))
+	assert.Equal(t, int64(10800), accountTotals(t, db)["seller_payable:s1"])
+
+	// An order paid before charges were fixed cannot be amended.
+	require.NoError(t, l.RecordOrder(ctx, Order{ID: "order-3", Currency: "ETB", Amount: 5000}))
+	require.NoError(t, l.BookPayment(ctx, Payment{ID: "pay-3", OrderID: "order-3", Amount: 5000}))
+	_, err = l.AmendOrder(ctx, Order{ID: "order-3", Currency: "ETB", Amount: 4000})
+	assert.ErrorIs(t, err, ErrAlreadyPaid)
+
+	// An amendment seen before the order records it.
+	_, err = l.AmendOrder(ctx, Order{ID: "order-2", Currency: "ETB", Amount: 100, Lines: []OrderLine{{ProductID: "mug", SellerID: "s2", Amount: 100}}})
+	require.NoError(t, err)
+	require.NoError(t, l.RecordOrder(ctx, Order{ID: "order-2", Currency: "ETB", Amount: 5000}))
+	amount, err = l.ChargeOrder(ctx, "order-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), amount)
+
+	_, err = l.ChargeOrder(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrUnknownOrder)
+}
+
+func TestLedger_CreatePayoutBatch(t *testing.T) {
+	l, db := setupLedger(t, Rates{Default: 0.1}, 5000)
+	ctx := context.Background()
+	require.NoError(t, l.RecordOrder(ctx, testOrder))
+	require.NoError(t, l.BookPayment(ctx, Payment{ID: "pay-1", OrderID: "order-1", Amount: 17000}))
+
+	at := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	batch, err := l.CreatePayoutBatch(ctx, at)
+	require.NoError(t, err)
+	require.NotNil(t, batch)
+	// s2 is owed 4500, below the minimum, and carries over.
+	assert.Equal(t, []PayoutItem{{ID: batch.Items[0].ID, BatchID: batch.ID, SellerID: "s1", Currency: "ETB", Amount: 10800}}, batch.Items)
+
+	totals := accountTotals(t, db)
+	assert.Zero(t, totals["seller_payable:s1"])
+	assert.Equal(t, int64(4500), totals["seller_payable:s2"])
+	assert.Equal(t, int64(-17000+10800), totals["platform_cash"])
+
+	again, err := l.CreatePayoutBatch(ctx, at.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, again, "nothing left to pay")
+
+	balances, err := l.SellerBalances(ctx, "s2")
+	require.NoError(t, err)
+	assert.Equal(t, []Balance{{SellerID: "s2", Currency: "ETB", Amount: 4500}}, balances)
+
+	stored, err := l.GetPayoutBatch(ctx, batch.ID)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, WriteCSV(&out, stored))
+	assert.Equal(t, strings.Join([]string{
+		"batch_id,created_at,seller_id,currency,amount",
+		batch.ID + ",2026-06-01T00:00:00Z,s1,ETB,108.00",
+		"",
+	}, "\n"), out.String())
+
+	batches, err := l.ListPayoutBatches(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, batches, 1)
+
+	_, err = l.GetPayoutBatch(ctx, "missing")
+	assert.ErrorIs(t, err, ErrBatchNotFound)
+}
+
+func TestConfig_Rates(t *testing.T) {
+	cfg := Config{CommissionRate: 0.1, SellerRates: []string{"s1=0.05", " s2 = 0 "}}
+	rates, err := cfg.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, 0.05, rates.For("s1"))
+	assert.Equal(t, 0.0, rates.For("s2"))
+	assert.Equal(t, 0.1, rates.For("s3"))
+
+	for _, bad := range [][]string{{"s1"}, {"=0.1"}, {"s1=abc"}, {"s1=1.5"}} {
+		cfg := Config{CommissionRate: 0.1, SellerRates: bad}
+		assert.Error(t, cfg.Validate(), "%v", bad)
+	}
+}
+
+func TestFormatMinor(t *testing.T) {
+	assert.Equal(t, "12.34", FormatMinor(1234))
+	assert.Equal(t, "0.05", FormatMinor(5))
+	assert.Equal(t, "-1.50", FormatMinor(-150))
+	assert.Equal(t, int64(1999), ToMinor(19.99))
+}
@@ -0,0 +1,156 @@
+package ledger
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// payoutLockKey serializes payout runs across replicas through a PostgreSQL
+// advisory lock.
+const payoutLockKey = 0x7061796f7574 // "payout"
+
+// PayoutBatch settles the payables of the sellers it lists.
+type PayoutBatch struct {
+	ID        string       `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time    `json:"created_at"`
+	Items     []PayoutItem `json:"items" gorm:"foreignKey:BatchID"`
+}
+
+// PayoutItem is what one seller is paid in one currency.
+type PayoutItem struct {
+	ID       uint   `json:"-" gorm:"primaryKey"`
+	BatchID  string `json:"-"`
+	SellerID string `json:"seller_id"`
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+// Balance is what the platform owes a seller in one currency. It is
+// negative when refunds exceed the sales not yet paid out.
+type Balance struct {
+	SellerID string `json:"seller_id"`
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+// SellerBalances returns the seller's payable balance per currency.
+func (l *Ledger) SellerBalances(ctx context.Context, sellerID string) ([]Balance, error) {
+	var balances []Balance
+	err := payables(l.db.WithContext(ctx)).
+		Where("l.seller_id = ?", sellerID).
+		Scan(&balances).Error
+	return balances, err
+}
+
+// CreatePayoutBatch pays out every seller balance of at least the configured
+// minimum: each is debited to the seller's payable and credited to platform
+// cash. It returns nil when there is nothing to pay.
+func (l *Ledger) CreatePayoutBatch(ctx context.Context, at time.Time) (*PayoutBatch, error) {
+	var batch *PayoutBatch
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", payoutLockKey).Error; err != nil {
+				return err
+			}
+		}
+
+		var balances []Balance
+		err := payables(tx).
+			Having("SUM(l.credit - l.debit) >= ?", max(l.payoutMinimum, 1)).
+			Order("l.seller_id, e.currency").
+			Scan(&balances).Error
+		if err != nil || len(balances) == 0 {
+			return err
+		}
+
+		batch = &PayoutBatch{ID: uuid.NewString(), CreatedAt: at}
+		for _, b := range balances {
+			batch.Items = append(batch.Items, PayoutItem{SellerID: b.SellerID, Currency: b.Currency, Amount: b.Amount})
+		}
+		if err := tx.Create(batch).Error; err != nil {
+			return err
+		}
+		for _, item := range batch.Items {
+			entry := &JournalEntry{
+				ID:        uuid.NewString(),
+				Reference: fmt.Sprintf("payout:%s:%s:%s", batch.ID, item.SellerID, item.Currency),
+				Kind:      KindPayout,
+				BatchID:   batch.ID,
+				Currency:  item.Currency,
+				BookedAt:  at,
+			}
+			entry.debit(AccountSellerPayable, item.SellerID, item.Amount)
+			entry.credit(AccountPlatformCash, "", item.Amount)
+			if err := entry.Validate(); err != nil {
+				return err
+			}
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// ListPayoutBatches returns up to limit batches, newest first.
+func (l *Ledger) ListPayoutBatches(ctx context.Context, limit int) ([]PayoutBatch, error) {
+	var batches []PayoutBatch
+	err := l.db.WithContext(ctx).Preload("Items", orderItems).
+		Order("created_at DESC, id").Limit(limit).
+		Find(&batches).Error
+	return batches, err
+}
+
+// GetPayoutBatch returns a batch with its items.
+func (l *Ledger) GetPayoutBatch(ctx context.Context, id string) (*PayoutBatch, error) {
+	var batch PayoutBatch
+	err := l.db.WithContext(ctx).Preload("Items", orderItems).First(&batch, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// WriteCSV writes a batch as the CSV handed to the bank, one row per seller
+// and currency.
+func WriteCSV(w io.Writer, batch *PayoutBatch) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"batch_id", "created_at", "seller_id", "currency", "amount"}); err != nil {
+		return err
+	}
+	created := batch.CreatedAt.UTC().Format(time.RFC3339)
+	for _, item := range batch.Items {
+		if err := out.Write([]string{batch.ID, created, item.SellerID, item.Currency, FormatMinor(item.Amount)}); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// payables selects seller payable balances grouped by seller and currency.
+func payables(tx *gorm.DB) *gorm.DB {
+	return tx.Table("journal_lines AS l").
+		Select("l.seller_id AS seller_id, e.currency AS currency, SUM(l.credit - l.debit) AS amount").
+		Joins("JOIN journal_entries e ON e.id = l.entry_id").
+		Where("l.account = ?", AccountSellerPayable).
+		Group("l.seller_id, e.currency")
+}
+
+func orderItems(db *gorm.DB) *gorm.DB {
+	return db.Order("seller_id, currency")
+}
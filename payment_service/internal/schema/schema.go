@@ -0,0 +1,152 @@
+// Package schema applies the ledger's embedded SQL migrations with
+// golang-migrate, under the Postgres driver's advisory lock so that
+// instances migrating at once take turns.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/payment_service/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// The schema handling of the service at boot; see Config.
+const (
+	MigrateUp     = "up"
+	MigrateVerify = "verify"
+	MigrateSkip   = "skip"
+)
+
+// Config sets what the service does with the ledger schema when it starts.
+// The migrate subcommand migrates explicitly, e.g. as a release step when
+// boots only verify.
+type Config struct {
+	OnStart     string        `yaml:"on_start" env:"MIGRATE_ON_START" flag:"migrate-on-start" default:"verify" usage:"schema handling at boot: verify refuses to start on an outdated schema, up applies pending migrations, skip does neither"`
+	LockTimeout time.Duration `yaml:"lock_timeout" env:"MIGRATE_LOCK_TIMEOUT" default:"1m" usage:"how long to wait for another instance to finish migrating"`
+}
+
+func (c *Config) Validate() error {
+	switch c.OnStart {
+	case MigrateUp, MigrateVerify, MigrateSkip:
+	default:
+		return errors.New("on_start must be up, verify or skip")
+	}
+	if c.LockTimeout <= 0 {
+		return errors.New("lock_timeout must be positive")
+	}
+	return nil
+}
+
+// ErrOutdated is returned by Verify when the database lacks migrations this
+// binary needs or a migration failed halfway.
+var ErrOutdated = errors.New("ledger schema is not up to date")
+
+// Migrator migrates one database.
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// Open connects to the database. lockTimeout bounds the wait for the
+// advisory lock while another instance migrates.
+func Open(databaseURL string, lockTimeout time.Duration) (*Migrator, error) {
+	latest, err := Latest(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded migrations: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to the database: %w", err)
+	}
+	m.LockTimeout = lockTimeout
+	m.Log = logger{}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Version returns the applied version, 0 for an empty database, and whether
+// the last migration failed halfway.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Verify checks the database is at least at the latest embedded version and
+// clean. A newer schema is accepted, as during a rolling deploy.
+func (m *Migrator) Verify() error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	return check(version, dirty, m.latest)
+}
+
+func check(version uint, dirty bool, latest uint) error {
+	switch {
+	case dirty:
+		return fmt.Errorf("%w: migration %d failed and must be repaired", ErrOutdated, version)
+	case version < latest:
+		return fmt.Errorf("%w: at version %d, expected %d", ErrOutdated, version, latest)
+	default:
+		return nil
+	}
+}
+
+// Latest returns the highest version among the migrations in fsys.
+func Latest(fsys fs.FS) (uint, error) {
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: bad version: %w", name, err)
+		}
+		latest = max(latest, uint(version))
+	}
+	if latest == 0 {
+		return 0, errors.New("no migrations embedded")
+	}
+	return latest, nil
+}
+
+// logger reports the migrations golang-migrate applies.
+type logger struct{}
+
+func (logger) Printf(format string, v ...any) {
+	log.Printf("migrate: "+format, v...)
+}
+
+func (logger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/payment_service/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	latest, err := Latest(migrations.FS)
+	require.NoError(t, err)
+
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+	assert.Len(t, ups, int(latest), "versions are numbered without gaps")
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		_, err := fs.Stat(migrations.FS, down)
+		assert.NoError(t, err, "%s has no down migration", up)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	assert.NoError(t, check(2, false, 2))
+	assert.NoError(t, check(3, false, 2), "a newer schema is accepted during a rollout")
+	assert.ErrorIs(t, check(1, false, 2), ErrOutdated)
+	assert.ErrorIs(t, check(0, false, 2), ErrOutdated)
+	assert.ErrorIs(t, check(2, true, 2), ErrOutdated)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := Config{OnStart: MigrateVerify, LockTimeout: 1}
+	assert.NoError(t, cfg.Validate())
+	cfg.OnStart = "always"
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS payout_items;
+DROP TABLE IF EXISTS payout_batches;
+DROP TABLE IF EXISTS ledger_order_lines;
+DROP TABLE IF EXISTS ledger_orders;
@@ -0,0 +1,60 @@
+-- Orders as charged, split into seller lines with the commission rate in
+-- force at the time. Amounts are in minor units.
+CREATE TABLE IF NOT EXISTS ledger_orders (
+    order_id VARCHAR(64) PRIMARY KEY,
+    currency VARCHAR(10) NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS ledger_order_lines (
+    id BIGSERIAL PRIMARY KEY,
+    order_id VARCHAR(64) NOT NULL REFERENCES ledger_orders(order_id) ON DELETE CASCADE,
+    product_id VARCHAR(64) NOT NULL,
+    seller_id VARCHAR(64) NOT NULL,
+    amount BIGINT NOT NULL,
+    commission_rate NUMERIC(6,5) NOT NULL,
+    refunded BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_ledger_order_lines_order_id ON ledger_order_lines(order_id);
+
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS payout_items (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES payout_batches(id) ON DELETE CASCADE,
+    seller_id VARCHAR(64) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount BIGINT NOT NULL
+);
+
+CREATE INDEX idx_payout_items_batch_id ON payout_items(batch_id);
+CREATE INDEX idx_payout_batches_created_at ON payout_batches(created_at DESC);
+
+-- The double-entry journal. Every entry's lines balance; the reference
+-- names the event booked and makes booking idempotent.
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL,
+    order_id VARCHAR(64),
+    batch_id UUID REFERENCES payout_batches(id),
+    currency VARCHAR(10) NOT NULL,
+    booked_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS journal_lines (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    account VARCHAR(50) NOT NULL,
+    seller_id VARCHAR(64) NOT NULL DEFAULT '',
+    debit BIGINT NOT NULL DEFAULT 0 CHECK (debit >= 0),
+    credit BIGINT NOT NULL DEFAULT 0 CHECK (credit >= 0)
+);
+
+CREATE INDEX idx_journal_entries_order_id ON journal_entries(order_id, kind);
+CREATE INDEX idx_journal_lines_entry_id ON journal_lines(entry_id);
+CREATE INDEX idx_journal_lines_account_seller ON journal_lines(account, seller_id);
@@ -0,0 +1 @@
+ALTER TABLE ledger_orders DROP COLUMN IF EXISTS amount;
@@ -0,0 +1,3 @@
+-- What the customer of an order is charged, in minor units, as last amended
+-- before payment. Zero for orders recorded before it was kept.
+ALTER TABLE ledger_orders ADD COLUMN IF NOT EXISTS amount BIGINT NOT NULL DEFAULT 0;
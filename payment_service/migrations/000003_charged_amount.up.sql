@@ -0,0 +1,4 @@
+-- The amount an order's charge was fixed at, in minor units. Zero until the
+-- payment service starts charging it; amendments arriving after that are
+-- settled as adjustments instead of replacing the order.
+ALTER TABLE ledger_orders ADD COLUMN IF NOT EXISTS charged_amount BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+// Package migrations embeds the ledger's SQL migrations, numbered
+// NNNNNN_name.up.sql and NNNNNN_name.down.sql as golang-migrate expects, so
+// the binary migrates without the files next to it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -21,11 +21,14 @@ aws ecr get-login-password --region $REGION | docker login --username AWS --pass
 # Build and push each service
 for SERVICE in "${SERVICES[@]}"; do
   echo "🚀 Processing $SERVICE..."
-  cd $SERVICE
-  docker build -t $SERVICE .
+  # Go services import the shared module, so they build from the repo root
+  if [ -f "$SERVICE/go.mod" ]; then
+    docker build -t $SERVICE -f $SERVICE/Dockerfile .
+  else
+    (cd $SERVICE && docker build -t $SERVICE .)
+  fi
   docker tag $SERVICE:latest ${ECR_URI}/ecommerce/${SERVICE}:latest
   docker push ${ECR_URI}/ecommerce/${SERVICE}:latest
-  cd ..
   echo "✅ $SERVICE pushed successfully!"
 done
 
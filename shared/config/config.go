@@ -0,0 +1,326 @@
+// Package config loads typed service configuration shared by the Go services.
+//
+// Values are resolved from, in increasing order of precedence: struct tag
+// defaults (or values already set on the destination), an optional YAML file,
+// environment variables and command-line flags. Fields are described with
+// struct tags:
+//
+//	URL string `yaml:"url" env:"DATABASE_URL" flag:"database-url" required:"true" secret:"true" usage:"..."`
+//
+// Nested structs become nested YAML mappings and dotted names in error
+// messages (e.g. "database.url").
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv is the environment variable naming the optional YAML config file.
+// The same file can be given with the -config flag.
+const FileEnv = "CONFIG_FILE"
+
+// Validator is implemented by config sections that need checks beyond the
+// `required` tag. It is called after all sources have been applied.
+type Validator interface {
+	Validate() error
+}
+
+// Options controls where Load reads values from.
+type Options struct {
+	// Name is used as the flag set name in usage output.
+	Name string
+	// Args are the command-line arguments to parse, usually os.Args[1:].
+	Args []string
+	// LookupEnv reads environment variables. Defaults to os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+	// Output receives flag usage and parse errors. Defaults to os.Stderr.
+	Output io.Writer
+}
+
+// ValidationError lists every problem found while loading a configuration,
+// so that a misconfigured service reports all of them at once.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type field struct {
+	path  string
+	value reflect.Value
+	tag   reflect.StructTag
+}
+
+// Load populates dst, which must be a pointer to a struct, and validates it.
+// Flag parsing stops at the first non-flag argument; the remaining arguments
+// are returned so callers can implement subcommands. flag.ErrHelp is returned
+// unchanged when -h or -help is given.
+func Load(dst any, opts Options) ([]string, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: destination must be a pointer to a struct, got %T", dst)
+	}
+	if opts.LookupEnv == nil {
+		opts.LookupEnv = os.LookupEnv
+	}
+	if opts.Output == nil {
+		opts.Output = os.Stderr
+	}
+
+	fields := collect(rv.Elem(), "")
+	var problems []string
+
+	for _, f := range fields {
+		def, ok := f.tag.Lookup("default")
+		if !ok || !f.value.IsZero() {
+			continue
+		}
+		if err := setString(f.value, def); err != nil {
+			return nil, fmt.Errorf("config: bad default for %s: %w", f.path, err)
+		}
+	}
+
+	fs := flag.NewFlagSet(opts.Name, flag.ContinueOnError)
+	fs.SetOutput(opts.Output)
+	configFile := fs.String("config", "", "path to a YAML configuration file (env "+FileEnv+")")
+	flagValues := map[string]string{}
+	for _, f := range fields {
+		name := f.tag.Get("flag")
+		if name == "" {
+			continue
+		}
+		usage := describe(f)
+		record := func(s string) error {
+			flagValues[name] = s
+			return nil
+		}
+		if f.value.Kind() == reflect.Bool {
+			fs.BoolFunc(name, usage, record)
+		} else {
+			fs.Func(name, usage, record)
+		}
+	}
+	if err := fs.Parse(opts.Args); err != nil {
+		return nil, err
+	}
+
+	path := *configFile
+	if path == "" {
+		path, _ = opts.LookupEnv(FileEnv)
+	}
+	if path != "" {
+		if err := loadFile(path, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		name := f.tag.Get("env")
+		if name == "" {
+			continue
+		}
+		if s, ok := opts.LookupEnv(name); ok && s != "" {
+			if err := setString(f.value, s); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid value %q from $%s: %v", f.path, s, name, err))
+			}
+		}
+	}
+
+	for _, f := range fields {
+		name := f.tag.Get("flag")
+		s, ok := flagValues[name]
+		if name == "" || !ok {
+			continue
+		}
+		if err := setString(f.value, s); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid value %q from -%s: %v", f.path, s, name, err))
+		}
+	}
+
+	for _, f := range fields {
+		if f.tag.Get("required") == "true" && f.value.IsZero() {
+			problems = append(problems, fmt.Sprintf("%s is required (%s)", f.path, sources(f)))
+		}
+	}
+	problems = append(problems, validate(rv.Elem(), "")...)
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return fs.Args(), nil
+}
+
+func loadFile(path string, dst any) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// collect flattens the leaf fields of a struct. Anonymous struct fields are
+// inlined; other struct fields (except time.Duration) are descended into.
+func collect(v reflect.Value, prefix string) []field {
+	var out []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if fv.Kind() == reflect.Struct {
+			if sf.Anonymous {
+				out = append(out, collect(fv, prefix)...)
+			} else {
+				out = append(out, collect(fv, path)...)
+			}
+			continue
+		}
+		out = append(out, field{path: path, value: fv, tag: sf.Tag})
+	}
+	return out
+}
+
+// validate calls Validate on every struct in the tree that implements
+// Validator, prefixing the error with the section's path.
+func validate(v reflect.Value, prefix string) []string {
+	var problems []string
+	if val, ok := v.Addr().Interface().(Validator); ok {
+		if err := val.Validate(); err != nil {
+			// errors.Join separates its errors with newlines; report each one.
+			for _, msg := range strings.Split(err.Error(), "\n") {
+				if prefix != "" {
+					msg = prefix + ": " + msg
+				}
+				problems = append(problems, msg)
+			}
+		}
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		if !sf.IsExported() || fv.Kind() != reflect.Struct {
+			continue
+		}
+		path := prefix
+		if !sf.Anonymous {
+			name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+			if name == "" {
+				name = strings.ToLower(sf.Name)
+			}
+			if path != "" {
+				path += "."
+			}
+			path += name
+		}
+		problems = append(problems, validate(fv, path)...)
+	}
+	return problems
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setString(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func describe(f field) string {
+	usage := f.tag.Get("usage")
+	if usage == "" {
+		usage = f.path
+	}
+	if env := f.tag.Get("env"); env != "" {
+		usage += " (env " + env + ")"
+	}
+	return usage
+}
+
+func sources(f field) string {
+	var s []string
+	if env := f.tag.Get("env"); env != "" {
+		s = append(s, "set $"+env)
+	}
+	if name := f.tag.Get("flag"); name != "" {
+		s = append(s, "pass -"+name)
+	}
+	s = append(s, "or set "+f.path+" in the config file")
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Port     string   `yaml:"port" env:"PORT" flag:"port" default:"50051"`
+	Debug    bool     `yaml:"debug" env:"DEBUG" flag:"debug"`
+	Token    string   `yaml:"token" env:"TOKEN" secret:"true"`
+	Database Database `yaml:"database"`
+	RabbitMQ RabbitMQ `yaml:"rabbitmq"`
+}
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+func TestLoad_DefaultsAndEnv(t *testing.T) {
+	var cfg testConfig
+	_, err := Load(&cfg, Options{LookupEnv: env(map[string]string{
+		"DATABASE_URL":            "postgres://u:p@db:5432/orders",
+		"RABBITMQ_URL":            "amqp://guest:guest@mq:5672/",
+		"DATABASE_MAX_OPEN_CONNS": "80",
+	})})
+	require.NoError(t, err)
+
+	assert.Equal(t, "50051", cfg.Port)
+	assert.Equal(t, "postgres://u:p@db:5432/orders", cfg.Database.URL)
+	assert.Equal(t, 80, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 50, cfg.Database.MaxIdleConns)
+	assert.Equal(t, time.Hour, cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, 10, cfg.RabbitMQ.ConnectRetries)
+}
+
+func TestLoad_PresetValuesActAsDefaults(t *testing.T) {
+	cfg := testConfig{RabbitMQ: RabbitMQ{ConnectRetries: 20}}
+	_, err := Load(&cfg, Options{LookupEnv: env(map[string]string{
+		"DATABASE_URL": "postgres://db/orders",
+		"RABBITMQ_URL": "amqp://mq/",
+	})})
+	require.NoError(t, err)
+	assert.Equal(t, 20, cfg.RabbitMQ.ConnectRetries)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+port: "7000"
+database:
+  url: postgres://file/orders
+  max_open_conns: 60
+rabbitmq:
+  url: amqp://file/
+  max_backoff: 2s
+`), 0o600))
+
+	var cfg testConfig
+	rest, err := Load(&cfg, Options{
+		Args: []string{"-config", path, "-port", "9000", "-debug", "migrate", "up"},
+		LookupEnv: env(map[string]string{
+			"PORT":         "8000",
+			"DATABASE_URL": "postgres://env/orders",
+		}),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "9000", cfg.Port, "flags override env and file")
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, "postgres://env/orders", cfg.Database.URL, "env overrides file")
+	assert.Equal(t, 60, cfg.Database.MaxOpenConns)
+	assert.Equal(t, "amqp://file/", cfg.RabbitMQ.URL)
+	assert.Equal(t, 2*time.Second, cfg.RabbitMQ.MaxBackoff)
+	assert.Equal(t, []string{"migrate", "up"}, rest)
+}
+
+func TestLoad_ReportsAllProblems(t *testing.T) {
+	var cfg testConfig
+	_, err := Load(&cfg, Options{LookupEnv: env(map[string]string{
+		"DATABASE_MAX_OPEN_CONNS":  "lots",
+		"DATABASE_MAX_IDLE_CONNS":  "500",
+		"RABBITMQ_CONNECT_RETRIES": "0",
+	})})
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	msg := err.Error()
+	assert.Contains(t, msg, `database.max_open_conns: invalid value "lots" from $DATABASE_MAX_OPEN_CONNS`)
+	assert.Contains(t, msg, "database.url is required (set $DATABASE_URL, pass -database-url")
+	assert.Contains(t, msg, "rabbitmq.url is required")
+	assert.Contains(t, msg, "database: max_idle_conns must be between 0 and max_open_conns")
+	assert.Contains(t, msg, "rabbitmq: connect_retries must be at least 1")
+}
+
+func TestLoad_UnknownFileKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("databse:\n  url: x\n"), 0o600))
+
+	var cfg testConfig
+	_, err := Load(&cfg, Options{LookupEnv: env(map[string]string{FileEnv: path})})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "databse")
+}
+
+func TestRedact(t *testing.T) {
+	cfg := testConfig{
+		Port:     "50051",
+		Token:    "s3cr3t",
+		Database: Database{URL: "postgres://postgres:hunter2@db:5432/orders?sslmode=disable"},
+		RabbitMQ: RabbitMQ{URL: "amqp://guest:guest@mq:5672/"},
+	}
+
+	out := Redact(&cfg)
+
+	assert.NotContains(t, out, "s3cr3t")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "guest:guest")
+	assert.Contains(t, out, "token: ******\n")
+	assert.Contains(t, out, "database.url: postgres://postgres:******@db:5432/orders?sslmode=disable\n")
+	assert.Contains(t, out, "rabbitmq.url: amqp://guest:******@mq:5672/\n")
+	assert.True(t, strings.HasPrefix(out, "port: 50051\n"))
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const mask = "******"
+
+// Redact renders cfg as one "path: value" line per field, suitable for
+// logging at startup. Fields tagged `secret:"true"` are masked entirely and
+// passwords embedded in URL values (DSNs, AMQP URLs) are masked in place.
+func Redact(cfg any) string {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Sprintf("%v", cfg)
+	}
+
+	var b strings.Builder
+	for _, f := range collect(v, "") {
+		b.WriteString(f.path)
+		b.WriteString(": ")
+		b.WriteString(redactValue(f))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func redactValue(f field) string {
+	if f.tag.Get("secret") == "true" && !f.value.IsZero() {
+		return mask
+	}
+	switch f.value.Kind() {
+	case reflect.String:
+		if u, ok := redactURL(f.value.String()); ok {
+			return u
+		}
+		return f.value.String()
+	case reflect.Slice:
+		items := make([]string, f.value.Len())
+		for i := range items {
+			s := fmt.Sprint(f.value.Index(i).Interface())
+			if u, ok := redactURL(s); ok {
+				s = u
+			}
+			items[i] = s
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprint(f.value.Interface())
+}
+
+// redactURL masks the password of a URL with user info. It reports false for
+// values that are not URLs with credentials.
+func redactURL(s string) (string, bool) {
+	if !strings.Contains(s, "://") {
+		return "", false
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return "", false
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s, true
+	}
+	u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	return strings.Replace(u.String(), "xxxxx", mask, 1), true
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/events"
+)
+
+// Database configures a PostgreSQL connection pool.
+type Database struct {
+	URL             string        `yaml:"url" env:"DATABASE_URL" flag:"database-url" required:"true" usage:"PostgreSQL connection string"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DATABASE_MAX_IDLE_CONNS" default:"50" usage:"maximum idle connections in the pool"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DATABASE_MAX_OPEN_CONNS" default:"300" usage:"maximum open connections to the database"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DATABASE_CONN_MAX_LIFETIME" default:"1h" usage:"maximum time a connection may be reused"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DATABASE_CONN_MAX_IDLE_TIME" default:"5m" usage:"maximum time a connection may be idle"`
+}
+
+func (d *Database) Validate() error {
+	var errs []error
+	if d.MaxOpenConns < 1 {
+		errs = append(errs, errors.New("max_open_conns must be at least 1"))
+	}
+	if d.MaxIdleConns < 0 || d.MaxIdleConns > d.MaxOpenConns {
+		errs = append(errs, errors.New("max_idle_conns must be between 0 and max_open_conns"))
+	}
+	if d.ConnMaxLifetime < 0 || d.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("connection lifetimes must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// RabbitMQ configures the broker connection. Startup gives up after
+// ConnectRetries attempts; once connected, reconnection is retried forever
+// with exponential backoff between InitialBackoff and MaxBackoff. Deliveries
+// failing with a transient error are retried MaxRetries times, RetryDelay
+// apart, and then dead-lettered.
+type RabbitMQ struct {
+	URL            string        `yaml:"url" env:"RABBITMQ_URL" flag:"rabbitmq-url" required:"true" usage:"AMQP connection URL"`
+	ConnectRetries int           `yaml:"connect_retries" env:"RABBITMQ_CONNECT_RETRIES" default:"10" usage:"connection attempts at startup"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"RABBITMQ_INITIAL_BACKOFF" default:"500ms" usage:"first delay between connection attempts"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"RABBITMQ_MAX_BACKOFF" default:"30s" usage:"upper bound for the delay between connection attempts"`
+	Prefetch       int           `yaml:"prefetch" env:"RABBITMQ_PREFETCH" default:"10" usage:"unacknowledged deliveries per consumer"`
+	ConfirmTimeout time.Duration `yaml:"confirm_timeout" env:"RABBITMQ_CONFIRM_TIMEOUT" default:"5s" usage:"how long to wait for a publisher confirm"`
+	EventFormat    string        `yaml:"event_format" env:"RABBITMQ_EVENT_FORMAT" default:"binary" usage:"wire format of published events: binary or structured CloudEvents, or envelope"`
+	MaxRetries     int           `yaml:"max_retries" env:"RABBITMQ_MAX_RETRIES" default:"5" usage:"times a delivery failing with a transient error is retried before it is dead-lettered"`
+	RetryDelay     time.Duration `yaml:"retry_delay" env:"RABBITMQ_RETRY_DELAY" default:"10s" usage:"delay before a failed delivery is retried"`
+}
+
+func (r *RabbitMQ) Validate() error {
+	var errs []error
+	if r.ConnectRetries < 1 {
+		errs = append(errs, errors.New("connect_retries must be at least 1"))
+	}
+	if r.InitialBackoff <= 0 || r.MaxBackoff < r.InitialBackoff {
+		errs = append(errs, errors.New("backoff must be positive with max_backoff >= initial_backoff"))
+	}
+	if r.Prefetch < 0 {
+		errs = append(errs, errors.New("prefetch must not be negative"))
+	}
+	if r.ConfirmTimeout <= 0 {
+		errs = append(errs, errors.New("confirm_timeout must be positive"))
+	}
+	if r.MaxRetries < 0 || r.RetryDelay <= 0 {
+		errs = append(errs, errors.New("max_retries must not be negative and retry_delay must be positive"))
+	}
+	if _, err := events.ParseFormat(r.EventFormat); err != nil {
+		errs = append(errs, errors.New("event_format must be binary, structured or envelope"))
+	}
+	return errors.Join(errs...)
+}
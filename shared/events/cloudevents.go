@@ -0,0 +1,235 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format selects how an envelope is laid out on the wire.
+type Format string
+
+const (
+	// FormatEnvelope is the JSON envelope used before CloudEvents support.
+	FormatEnvelope Format = "envelope"
+	// FormatBinary is CloudEvents 1.0 binary mode: attributes in ce-*
+	// headers, the payload as the message body.
+	FormatBinary Format = "binary"
+	// FormatStructured is CloudEvents 1.0 structured mode: the whole event
+	// as an application/cloudevents+json body.
+	FormatStructured Format = "structured"
+)
+
+// ParseFormat validates a configured format name.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatEnvelope, FormatBinary, FormatStructured:
+		return f, nil
+	}
+	return "", fmt.Errorf("events: unknown format %q (want envelope, binary or structured)", s)
+}
+
+const (
+	specVersion = "1.0"
+
+	// ContentTypeJSON is the content type of envelopes, legacy payloads and
+	// binary-mode CloudEvents data.
+	ContentTypeJSON = "application/json"
+	// ContentTypeCloudEvents is the content type of structured-mode events.
+	ContentTypeCloudEvents = "application/cloudevents+json"
+
+	headerPrefix = "ce-"
+
+	// CloudEvents extension attributes carrying the envelope fields that
+	// have no core attribute.
+	extCorrelationID = "correlationid"
+	extEventVersion  = "eventversion"
+)
+
+// Message is a transport-neutral encoded event: headers map onto AMQP
+// message headers, ContentType onto the content-type property.
+type Message struct {
+	ContentType string
+	Headers     map[string]any
+	Body        []byte
+}
+
+// cloudEvent is the structured-mode representation of an envelope.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	CorrelationID   string          `json:"correlationid,omitempty"`
+	EventVersion    json.Number     `json:"eventversion,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// Encode lays env out in the given format. The event ID maps to the
+// CloudEvents id, the producer to source, the version and correlation ID to
+// the eventversion and correlationid extensions.
+func Encode(env Envelope, f Format) (Message, error) {
+	switch f {
+	case FormatEnvelope:
+		body, err := json.Marshal(env)
+		if err != nil {
+			return Message{}, fmt.Errorf("events: encoding %s: %w", env.Type, err)
+		}
+		return Message{ContentType: ContentTypeJSON, Body: body}, nil
+
+	case FormatBinary:
+		headers := map[string]any{
+			headerPrefix + "specversion":     specVersion,
+			headerPrefix + "id":              env.EventID,
+			headerPrefix + "source":          env.Producer,
+			headerPrefix + "type":            env.Type,
+			headerPrefix + "time":            env.OccurredAt.UTC().Format(time.RFC3339Nano),
+			headerPrefix + extEventVersion:   strconv.Itoa(env.Version),
+			headerPrefix + "datacontenttype": ContentTypeJSON,
+		}
+		if env.CorrelationID != "" {
+			headers[headerPrefix+extCorrelationID] = env.CorrelationID
+		}
+		return Message{ContentType: ContentTypeJSON, Headers: headers, Body: bytes.Clone(env.Data)}, nil
+
+	case FormatStructured:
+		at := env.OccurredAt.UTC()
+		body, err := json.Marshal(cloudEvent{
+			SpecVersion:     specVersion,
+			ID:              env.EventID,
+			Source:          env.Producer,
+			Type:            env.Type,
+			Time:            &at,
+			DataContentType: ContentTypeJSON,
+			CorrelationID:   env.CorrelationID,
+			EventVersion:    json.Number(strconv.Itoa(env.Version)),
+			Data:            env.Data,
+		})
+		if err != nil {
+			return Message{}, fmt.Errorf("events: encoding %s: %w", env.Type, err)
+		}
+		return Message{ContentType: ContentTypeCloudEvents, Body: body}, nil
+	}
+	return Message{}, fmt.Errorf("events: unknown format %q", f)
+}
+
+// DecodeMessage decodes a message in any supported format into dst: binary
+// or structured CloudEvents, the JSON envelope, or a legacy bare payload.
+func DecodeMessage(m Message, dst Payload) (Envelope, error) {
+	var (
+		env Envelope
+		err error
+	)
+	switch {
+	case m.Headers[headerPrefix+"specversion"] != nil:
+		env, err = fromBinary(m)
+	case strings.HasPrefix(m.ContentType, ContentTypeCloudEvents) || isStructured(m.Body):
+		env, err = fromStructured(m.Body)
+	default:
+		return Decode(m.Body, dst)
+	}
+	if err != nil {
+		return Envelope{}, err
+	}
+	return env, env.DecodeData(dst)
+}
+
+func fromBinary(m Message) (Envelope, error) {
+	attr := func(name string) string {
+		switch v := m.Headers[headerPrefix+name].(type) {
+		case string:
+			return v
+		case []byte:
+			return string(v)
+		case nil:
+			return ""
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	if err := checkSpecVersion(attr("specversion")); err != nil {
+		return Envelope{}, err
+	}
+	env := Envelope{
+		EventID:       attr("id"),
+		Type:          attr("type"),
+		Producer:      attr("source"),
+		CorrelationID: attr(extCorrelationID),
+		Data:          bytes.Clone(m.Body),
+	}
+	if err := fillCommon(&env, attr("time"), attr(extEventVersion)); err != nil {
+		return Envelope{}, err
+	}
+	return env, nil
+}
+
+func fromStructured(body []byte) (Envelope, error) {
+	var ce cloudEvent
+	if err := json.Unmarshal(body, &ce); err != nil {
+		return Envelope{}, fmt.Errorf("events: malformed cloudevent: %w", err)
+	}
+	if err := checkSpecVersion(ce.SpecVersion); err != nil {
+		return Envelope{}, err
+	}
+	env := Envelope{
+		EventID:       ce.ID,
+		Type:          ce.Type,
+		Producer:      ce.Source,
+		CorrelationID: ce.CorrelationID,
+		Data:          ce.Data,
+	}
+	if ce.Time != nil {
+		env.OccurredAt = *ce.Time
+	}
+	if err := fillCommon(&env, "", ce.EventVersion.String()); err != nil {
+		return Envelope{}, err
+	}
+	return env, nil
+}
+
+// fillCommon applies the attributes shared by both CloudEvents modes and
+// checks the required ones. Events without an eventversion extension were
+// not produced by us and are treated as version 1.
+func fillCommon(env *Envelope, at, version string) error {
+	if env.EventID == "" || env.Type == "" || env.Producer == "" {
+		return errors.New("events: cloudevent without id, source or type")
+	}
+	if at != "" {
+		t, err := time.Parse(time.RFC3339Nano, at)
+		if err != nil {
+			return fmt.Errorf("events: malformed cloudevent time: %w", err)
+		}
+		env.OccurredAt = t
+	}
+	env.Version = 1
+	if version != "" {
+		v, err := strconv.Atoi(version)
+		if err != nil || v < 1 {
+			return fmt.Errorf("events: malformed cloudevent eventversion %q", version)
+		}
+		env.Version = v
+	}
+	return nil
+}
+
+func checkSpecVersion(v string) error {
+	if v != specVersion {
+		return fmt.Errorf("events: unsupported cloudevents specversion %q", v)
+	}
+	return nil
+}
+
+// isStructured recognizes structured-mode events delivered without the
+// CloudEvents content type.
+func isStructured(body []byte) bool {
+	var probe struct {
+		SpecVersion string `json:"specversion"`
+	}
+	return json.Unmarshal(body, &probe) == nil && probe.SpecVersion != ""
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleEnvelope(t *testing.T) Envelope {
+	t.Helper()
+	at := time.Date(2026, 1, 15, 10, 30, 5, 0, time.UTC)
+	env, err := New("order-service", OrderPaid{OrderID: fixtureOrderID, Amount: 100, Currency: "ETB"},
+		WithCorrelationID(fixtureOrderID), WithOccurredAt(at))
+	require.NoError(t, err)
+	return env
+}
+
+func TestEncode_RoundTripsEveryFormat(t *testing.T) {
+	env := sampleEnvelope(t)
+	for _, f := range []Format{FormatEnvelope, FormatBinary, FormatStructured} {
+		t.Run(string(f), func(t *testing.T) {
+			msg, err := Encode(env, f)
+			require.NoError(t, err)
+
+			var got OrderPaid
+			decoded, err := DecodeMessage(msg, &got)
+			require.NoError(t, err)
+			assert.Equal(t, OrderPaid{OrderID: fixtureOrderID, Amount: 100, Currency: "ETB"}, got)
+			assert.Equal(t, env.EventID, decoded.EventID)
+			assert.Equal(t, env.Type, decoded.Type)
+			assert.Equal(t, env.Version, decoded.Version)
+			assert.Equal(t, env.CorrelationID, decoded.CorrelationID)
+			assert.Equal(t, env.Producer, decoded.Producer)
+			assert.True(t, env.OccurredAt.Equal(decoded.OccurredAt))
+		})
+	}
+}
+
+func TestEncode_Binary(t *testing.T) {
+	env := sampleEnvelope(t)
+	msg, err := Encode(env, FormatBinary)
+	require.NoError(t, err)
+
+	assert.Equal(t, ContentTypeJSON, msg.ContentType)
+	assert.Equal(t, "1.0", msg.Headers["ce-specversion"])
+	assert.Equal(t, env.EventID, msg.Headers["ce-id"])
+	assert.Equal(t, "order-service", msg.Headers["ce-source"])
+	assert.Equal(t, "order.paid", msg.Headers["ce-type"])
+	assert.Equal(t, "2026-01-15T10:30:05Z", msg.Headers["ce-time"])
+	assert.Equal(t, fixtureOrderID, msg.Headers["ce-correlationid"])
+	assert.Equal(t, "1", msg.Headers["ce-eventversion"])
+	assert.JSONEq(t, string(env.Data), string(msg.Body))
+}
+
+func TestEncode_Structured(t *testing.T) {
+	msg, err := Encode(sampleEnvelope(t), FormatStructured)
+	require.NoError(t, err)
+	assert.Equal(t, ContentTypeCloudEvents, msg.ContentType)
+	assert.Empty(t, msg.Headers)
+
+	var ce map[string]any
+	require.NoError(t, json.Unmarshal(msg.Body, &ce))
+	for _, attr := range []string{"specversion", "id", "source", "type", "time", "data"} {
+		assert.Contains(t, ce, attr)
+	}
+}
+
+func TestDecodeMessage_AcceptsLegacyAndStructuredFixtures(t *testing.T) {
+	for _, name := range []string{"order.paid.v0.json", "order.paid.v1.json", "order.paid.v1.cloudevent.json"} {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile("testdata/" + name)
+			require.NoError(t, err)
+
+			// Content type is not always set by older producers.
+			var got OrderPaid
+			_, err = DecodeMessage(Message{Body: body}, &got)
+			require.NoError(t, err)
+			assert.Equal(t, fixtureOrderID, got.OrderID)
+			assert.Equal(t, 100.0, got.Amount)
+		})
+	}
+}
+
+func TestDecodeMessage_Rejects(t *testing.T) {
+	var got OrderPaid
+
+	_, err := DecodeMessage(Message{Headers: map[string]any{"ce-specversion": "0.3", "ce-id": "e-1", "ce-source": "x", "ce-type": "order.paid"}, Body: []byte(`{}`)}, &got)
+	assert.ErrorContains(t, err, "specversion")
+
+	_, err = DecodeMessage(Message{Headers: map[string]any{"ce-specversion": "1.0", "ce-type": "order.paid"}, Body: []byte(`{}`)}, &got)
+	assert.Error(t, err)
+
+	_, err = DecodeMessage(Message{Headers: map[string]any{"ce-specversion": "1.0", "ce-id": "e-1", "ce-source": "x", "ce-type": "order.delivered"}, Body: []byte(`{}`)}, &got)
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("structured")
+	require.NoError(t, err)
+	assert.Equal(t, FormatStructured, f)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
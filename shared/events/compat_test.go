@@ -0,0 +1,245 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samples holds a fully populated value of the current version of every
+// event contract.
+var samples = []Payload{
+	OrderCreated{
+		OrderID: fixtureOrderID, UserID: "u-1", TotalAmount: 150, Currency: "ETB",
+		Items: []OrderItem{{
+			ProductID: "p-1", SellerID: "s-1", ProductName: "Lamp", UnitPrice: 50, Quantity: 2,
+			Category: "home", TaxRate: 0.15, TaxAmount: 11.74,
+		}},
+		Subtotal: 100, DiscountAmount: 10,
+		Discounts: []Discount{{PromotionID: "promo-1", Code: "SPRING10", Description: "10% off", Amount: 10}},
+		TaxAmount: 11.74, PricesIncludeTax: true,
+		TaxBreakdown:   []TaxBreakdown{{Rate: 0.15, TaxableAmount: 78.26, TaxAmount: 11.74}},
+		ShippingAmount: 60,
+	},
+	OrderAmended{
+		OrderID: fixtureOrderID, UserID: "u-1", AmendmentID: "am-1",
+		PreviousAmount: 150, TotalAmount: 110, Currency: "ETB",
+		Items: []OrderItem{{
+			ProductID: "p-1", SellerID: "s-1", ProductName: "Lamp", UnitPrice: 50, Quantity: 1,
+			Category: "home", TaxRate: 0.15, TaxAmount: 6.52,
+		}},
+		Subtotal: 50, DiscountAmount: 5, TaxAmount: 6.52, PricesIncludeTax: true, ShippingAmount: 65,
+	},
+	OrderPaid{OrderID: fixtureOrderID, Amount: 100, Currency: "ETB"},
+	PaymentSucceeded{PaymentID: "pay-1", OrderID: fixtureOrderID, Amount: 100},
+	OrderDelivered{OrderID: fixtureOrderID, Status: "Delivered"},
+	PaymentRefunded{
+		RefundID: "ref-1", OrderID: fixtureOrderID, PaymentID: "pay-1", Amount: 50,
+		Items: []RefundedItem{{ProductID: "p-1", SellerID: "s-1", Amount: 50}},
+	},
+	ReturnApproved{
+		ReturnID: "ret-1", OrderID: fixtureOrderID,
+		Items: []ReturnedItem{{ProductID: "p-1", SellerID: "s-1", Quantity: 1}},
+		PickupAddress: PickupAddress{
+			FullName: "Abebe Kebede", Phone: "+251911234567", Country: "ET", Region: "Addis Ababa",
+			City: "Addis Ababa", Street: "Bole Road", AddressLine2: "Apt 4", PostalCode: "1000",
+		},
+	},
+	ReturnPickedUp{ReturnID: "ret-1", OrderID: fixtureOrderID},
+	RefundRequested{
+		RefundID: "ret-1", OrderID: fixtureOrderID, Amount: 50, Currency: "ETB", Reason: "damaged",
+		Items: []RefundedItem{{ProductID: "p-1", SellerID: "s-1", Amount: 50}},
+	},
+	OrderStatusChanged{
+		OrderID: fixtureOrderID, UserID: "u-1", Status: "PAID", Sequence: 42,
+		ChangedAt: time.Date(2026, 1, 15, 10, 30, 4, 0, time.UTC),
+	},
+	UserDeleted{UserID: "u-1"},
+}
+
+// legacyTypes were published as bare payloads before the envelope existed.
+var legacyTypes = map[string]bool{
+	TypeOrderCreated:     true,
+	TypeOrderPaid:        true,
+	TypePaymentSucceeded: true,
+	TypeOrderDelivered:   true,
+}
+
+// jsonSchema is the subset of JSON Schema used by the contracts.
+type jsonSchema struct {
+	Type       string                 `json:"type"`
+	Required   []string               `json:"required"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Items      *jsonSchema            `json:"items"`
+}
+
+func loadSchema(t *testing.T, eventType string, version int) *jsonSchema {
+	t.Helper()
+	b, err := Schema(eventType, version)
+	require.NoError(t, err)
+	var s jsonSchema
+	require.NoError(t, json.Unmarshal(b, &s))
+	return &s
+}
+
+func validate(s *jsonSchema, v any, path string) []string {
+	var problems []string
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return []string{path + ": expected object"}
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: required", path, name))
+			}
+		}
+		for name, prop := range s.Properties {
+			if val, ok := obj[name]; ok {
+				problems = append(problems, validate(prop, val, path+"."+name)...)
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return []string{path + ": expected array"}
+		}
+		for i, item := range arr {
+			problems = append(problems, validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			problems = append(problems, path+": expected string")
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			problems = append(problems, path+": expected number")
+		}
+	case "integer":
+		if f, ok := v.(float64); !ok || f != float64(int64(f)) {
+			problems = append(problems, path+": expected integer")
+		}
+	}
+	return problems
+}
+
+func toJSONValue(t *testing.T, v any) any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var out any
+	require.NoError(t, json.Unmarshal(b, &out))
+	return out
+}
+
+// TestSchemas_CurrentVersionHasSchema makes sure every contract version that
+// can be produced is documented.
+func TestSchemas_CurrentVersionHasSchema(t *testing.T) {
+	for _, p := range samples {
+		_, err := Schema(p.EventType(), p.EventVersion())
+		assert.NoError(t, err, "missing schemas/%s.v%d.json", p.EventType(), p.EventVersion())
+	}
+}
+
+// TestSchemas_BackwardCompatible checks that what we produce today still
+// satisfies every schema version ever published for the type, so consumers
+// built against older versions keep working.
+func TestSchemas_BackwardCompatible(t *testing.T) {
+	versions := SchemaVersions()
+	for _, p := range samples {
+		value := toJSONValue(t, p)
+		for _, v := range versions[p.EventType()] {
+			s := loadSchema(t, p.EventType(), v)
+			assert.Empty(t, validate(s, value, "data"), "%s no longer satisfies schema v%d", p.EventType(), v)
+		}
+	}
+}
+
+// TestSchemas_RequiredFieldsAreNeverDropped checks consecutive schema
+// versions against the compatibility rules in the package documentation.
+func TestSchemas_RequiredFieldsAreNeverDropped(t *testing.T) {
+	for eventType, versions := range SchemaVersions() {
+		for i := 1; i < len(versions); i++ {
+			prev := loadSchema(t, eventType, versions[i-1])
+			next := loadSchema(t, eventType, versions[i])
+			for _, name := range prev.Required {
+				assert.Contains(t, next.Required, name, "%s v%d dropped required field %s", eventType, versions[i], name)
+				if p, n := prev.Properties[name], next.Properties[name]; p != nil && n != nil {
+					assert.Equal(t, p.Type, n.Type, "%s v%d retyped field %s", eventType, versions[i], name)
+				}
+			}
+		}
+	}
+}
+
+// TestFixtures_EveryPublishedVersionDecodes replays recorded messages of
+// every version, including legacy bare payloads, through the current
+// contracts.
+func TestFixtures_EveryPublishedVersionDecodes(t *testing.T) {
+	for _, p := range samples {
+		for v := LegacyVersion; v <= p.EventVersion(); v++ {
+			if v == LegacyVersion && !legacyTypes[p.EventType()] {
+				continue
+			}
+			name := fmt.Sprintf("testdata/%s.v%d.json", p.EventType(), v)
+			t.Run(name, func(t *testing.T) {
+				body, err := os.ReadFile(name)
+				require.NoError(t, err)
+
+				dst := newPayload(p)
+				env, err := Decode(body, dst)
+				require.NoError(t, err)
+				assert.Equal(t, v, env.Version)
+				assert.False(t, env.OccurredAt.IsZero())
+
+				s := loadSchema(t, p.EventType(), p.EventVersion())
+				assert.Empty(t, validate(s, toJSONValue(t, dst), "data"))
+			})
+		}
+	}
+}
+
+func TestFixtures_EnvelopeSchema(t *testing.T) {
+	s := loadSchema(t, "envelope", 1)
+	for _, p := range samples {
+		body, err := os.ReadFile(fmt.Sprintf("testdata/%s.v%d.json", p.EventType(), p.EventVersion()))
+		require.NoError(t, err)
+		var value any
+		require.NoError(t, json.Unmarshal(body, &value))
+		assert.Empty(t, validate(s, value, "envelope"))
+	}
+}
+
+func newPayload(p Payload) Payload {
+	switch p.(type) {
+	case OrderCreated:
+		return &OrderCreated{}
+	case OrderAmended:
+		return &OrderAmended{}
+	case OrderPaid:
+		return &OrderPaid{}
+	case PaymentSucceeded:
+		return &PaymentSucceeded{}
+	case OrderDelivered:
+		return &OrderDelivered{}
+	case PaymentRefunded:
+		return &PaymentRefunded{}
+	case ReturnApproved:
+		return &ReturnApproved{}
+	case ReturnPickedUp:
+		return &ReturnPickedUp{}
+	case RefundRequested:
+		return &RefundRequested{}
+	case OrderStatusChanged:
+		return &OrderStatusChanged{}
+	case UserDeleted:
+		return &UserDeleted{}
+	}
+	panic(fmt.Sprintf("no payload for %T", p))
+}
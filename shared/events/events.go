@@ -0,0 +1,135 @@
+// Package events defines the versioned event contracts exchanged over the
+// order_events exchange.
+//
+// Every event travels in an Envelope carrying its identity and metadata, with
+// the type-specific payload in Data. The envelope type doubles as the AMQP
+// routing key. Payloads are documented by JSON Schemas in schemas/, one file
+// per type and version.
+//
+// Compatibility rules: a new version may add fields but must not remove or
+// retype fields required by earlier versions; a breaking change needs a new
+// event type. Under that rule consumers decode any version into their
+// payload struct: older versions leave the newer fields zero, newer versions
+// carry fields the consumer ignores. Legacy messages published before the
+// envelope existed decode as version 0.
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LegacyVersion is reported for bare JSON payloads published before the
+// envelope was introduced.
+const LegacyVersion = 0
+
+// Envelope wraps every event payload.
+type Envelope struct {
+	EventID       string          `json:"event_id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Producer      string          `json:"producer"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Payload is implemented by every event contract.
+type Payload interface {
+	EventType() string
+	EventVersion() int
+}
+
+// Option customizes an envelope built by New.
+type Option func(*Envelope)
+
+// WithCorrelationID ties the event to the business flow it belongs to,
+// usually the order ID or the correlation ID of the event being handled.
+func WithCorrelationID(id string) Option {
+	return func(e *Envelope) { e.CorrelationID = id }
+}
+
+// WithOccurredAt overrides the event time, which defaults to now.
+func WithOccurredAt(t time.Time) Option {
+	return func(e *Envelope) { e.OccurredAt = t }
+}
+
+// New wraps p in an envelope produced by producer.
+func New(producer string, p Payload, opts ...Option) (Envelope, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("events: encoding %s: %w", p.EventType(), err)
+	}
+	env := Envelope{
+		EventID:    uuid.NewString(),
+		Type:       p.EventType(),
+		Version:    p.EventVersion(),
+		OccurredAt: time.Now().UTC(),
+		Producer:   producer,
+		Data:       data,
+	}
+	for _, opt := range opts {
+		opt(&env)
+	}
+	return env, nil
+}
+
+// ErrTypeMismatch is returned when a message holds a different event type
+// than the payload it is decoded into.
+var ErrTypeMismatch = errors.New("events: event type mismatch")
+
+// Decode parses body, either an envelope or a legacy bare payload, into dst
+// and returns the envelope. Legacy payloads get a synthesized envelope with
+// Version 0 and OccurredAt taken from their "timestamp" field.
+func Decode(body []byte, dst Payload) (Envelope, error) {
+	env, err := parseEnvelope(body)
+	if err != nil {
+		return Envelope{}, err
+	}
+	if env.Version == LegacyVersion {
+		env.Type = dst.EventType()
+	}
+	return env, env.DecodeData(dst)
+}
+
+// DecodeData decodes the envelope's payload into dst after checking the
+// event type.
+func (e Envelope) DecodeData(dst Payload) error {
+	if e.Type != dst.EventType() {
+		return fmt.Errorf("%w: got %q, want %q", ErrTypeMismatch, e.Type, dst.EventType())
+	}
+	if err := json.Unmarshal(e.Data, dst); err != nil {
+		return fmt.Errorf("events: decoding %s v%d: %w", e.Type, e.Version, err)
+	}
+	return nil
+}
+
+func parseEnvelope(body []byte) (Envelope, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return Envelope{}, fmt.Errorf("events: malformed message: %w", err)
+	}
+	_, hasID := probe["event_id"]
+	_, hasData := probe["data"]
+	if hasID && hasData {
+		var env Envelope
+		if err := json.Unmarshal(body, &env); err != nil {
+			return Envelope{}, fmt.Errorf("events: malformed envelope: %w", err)
+		}
+		if env.Type == "" || env.Version < 1 {
+			return Envelope{}, errors.New("events: envelope without type or version")
+		}
+		return env, nil
+	}
+
+	env := Envelope{Version: LegacyVersion, Data: bytes.Clone(body)}
+	if ts, ok := probe["timestamp"]; ok {
+		_ = json.Unmarshal(ts, &env.OccurredAt)
+	}
+	return env, nil
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureOrderID = "6f1c2a8e-4a51-4a8e-9f0e-3c0d5f0b7a11"
+
+func TestNewAndDecode_RoundTrip(t *testing.T) {
+	payload := PaymentSucceeded{PaymentID: "p-1", OrderID: "o-1", Amount: 42.5}
+	at := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	env, err := New("payment-service", payload, WithCorrelationID("o-1"), WithOccurredAt(at))
+	require.NoError(t, err)
+	assert.NotEmpty(t, env.EventID)
+	assert.Equal(t, TypePaymentSucceeded, env.Type)
+	assert.Equal(t, 1, env.Version)
+
+	body, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	var got PaymentSucceeded
+	decoded, err := Decode(body, &got)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+	assert.Equal(t, env.EventID, decoded.EventID)
+	assert.Equal(t, "o-1", decoded.CorrelationID)
+	assert.Equal(t, "payment-service", decoded.Producer)
+	assert.True(t, at.Equal(decoded.OccurredAt))
+}
+
+func TestDecode_LegacyPayload(t *testing.T) {
+	body, err := os.ReadFile("testdata/order.created.v0.json")
+	require.NoError(t, err)
+
+	var got OrderCreated
+	env, err := Decode(body, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, LegacyVersion, env.Version)
+	assert.Equal(t, TypeOrderCreated, env.Type)
+	assert.Equal(t, time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC), env.OccurredAt)
+	assert.Equal(t, fixtureOrderID, got.OrderID)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440002", got.Items[0].SellerID)
+	assert.Equal(t, 1, got.Items[0].Quantity)
+}
+
+func TestDecode_TypeMismatch(t *testing.T) {
+	body, err := os.ReadFile("testdata/order.paid.v1.json")
+	require.NoError(t, err)
+
+	var got PaymentSucceeded
+	_, err = Decode(body, &got)
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+}
+
+func TestDecode_NewerVersionIsTolerated(t *testing.T) {
+	body := []byte(`{"event_id":"e-1","type":"order.delivered","version":3,"occurred_at":"2026-01-15T10:30:10Z","producer":"delivery-service",
+		"data":{"order_id":"o-1","status":"Delivered","courier":"bike"}}`)
+
+	var got OrderDelivered
+	env, err := Decode(body, &got)
+	require.NoError(t, err)
+	assert.Equal(t, 3, env.Version)
+	assert.Equal(t, OrderDelivered{OrderID: "o-1", Status: "Delivered"}, got)
+}
+
+func TestDecode_Malformed(t *testing.T) {
+	var got OrderPaid
+	_, err := Decode([]byte(`not json`), &got)
+	assert.Error(t, err)
+
+	_, err = Decode([]byte(`{"event_id":"e-1","data":{}}`), &got)
+	assert.Error(t, err)
+}
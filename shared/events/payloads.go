@@ -0,0 +1,225 @@
+package events
+
+import "time"
+
+// Event types. They are also the routing keys on the order_events exchange.
+const (
+	TypeOrderCreated     = "order.created"
+	TypeOrderPaid        = "order.paid"
+	TypePaymentSucceeded = "payment.succeeded"
+	TypeOrderDelivered   = "order.delivered"
+	TypePaymentRefunded  = "payment.refunded"
+	TypeReturnApproved   = "return.approved"
+	TypeReturnPickedUp   = "return.picked_up"
+	TypeRefundRequested  = "refund.requested"
+	TypeOrderAmended     = "order.amended"
+	TypeUserDeleted      = "user.deleted"
+
+	TypeOrderStatusChanged = "order.status_changed"
+)
+
+// Exchange is the topic exchange all order lifecycle events are published to.
+const Exchange = "order_events"
+
+// OrderCreated is published by the order service when an order is placed.
+//
+// Version 2 adds the subtotal and the discounts of the order; TotalAmount is
+// what is charged, the subtotal less the discounts. Version 3 adds the tax of
+// the order and its lines; unless PricesIncludeTax, TotalAmount includes the
+// tax on top. Version 4 adds the shipping fee, included in TotalAmount.
+type OrderCreated struct {
+	OrderID          string         `json:"order_id"`
+	UserID           string         `json:"user_id"`
+	TotalAmount      float64        `json:"total_amount"`
+	Currency         string         `json:"currency"`
+	Items            []OrderItem    `json:"items"`
+	Subtotal         float64        `json:"subtotal,omitempty"`
+	DiscountAmount   float64        `json:"discount_amount,omitempty"`
+	Discounts        []Discount     `json:"discounts,omitempty"`
+	TaxAmount        float64        `json:"tax_amount,omitempty"`
+	PricesIncludeTax bool           `json:"prices_include_tax,omitempty"`
+	TaxBreakdown     []TaxBreakdown `json:"tax_breakdown,omitempty"`
+	ShippingAmount   float64        `json:"shipping_amount,omitempty"`
+}
+
+func (OrderCreated) EventType() string { return TypeOrderCreated }
+func (OrderCreated) EventVersion() int { return 4 }
+
+// OrderItem is one line of an order as carried in events.
+type OrderItem struct {
+	ProductID   string  `json:"product_id"`
+	SellerID    string  `json:"seller_id"`
+	ProductName string  `json:"product_name"`
+	UnitPrice   float64 `json:"unit_price"`
+	Quantity    int     `json:"quantity"`
+	Category    string  `json:"category,omitempty"`
+	TaxRate     float64 `json:"tax_rate,omitempty"`
+	TaxAmount   float64 `json:"tax_amount,omitempty"`
+}
+
+// TaxBreakdown sums the lines of an order taxed at one rate.
+type TaxBreakdown struct {
+	Rate          float64 `json:"rate"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+// Discount is a promotion applied to an order.
+type Discount struct {
+	PromotionID string  `json:"promotion_id"`
+	Code        string  `json:"code,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Amount      float64 `json:"amount"`
+}
+
+// OrderAmended is published by the order service when an unpaid order's
+// lines or addresses change. It carries the order as priced after the
+// change; the payment service authorizes TotalAmount instead of
+// PreviousAmount. Items lists every remaining line.
+type OrderAmended struct {
+	OrderID          string      `json:"order_id"`
+	UserID           string      `json:"user_id"`
+	AmendmentID      string      `json:"amendment_id"`
+	PreviousAmount   float64     `json:"previous_amount"`
+	TotalAmount      float64     `json:"total_amount"`
+	Currency         string      `json:"currency"`
+	Items            []OrderItem `json:"items"`
+	Subtotal         float64     `json:"subtotal"`
+	DiscountAmount   float64     `json:"discount_amount,omitempty"`
+	TaxAmount        float64     `json:"tax_amount,omitempty"`
+	PricesIncludeTax bool        `json:"prices_include_tax,omitempty"`
+	ShippingAmount   float64     `json:"shipping_amount,omitempty"`
+}
+
+func (OrderAmended) EventType() string { return TypeOrderAmended }
+func (OrderAmended) EventVersion() int { return 1 }
+
+// OrderPaid is published by the order service once payment is confirmed.
+type OrderPaid struct {
+	OrderID  string  `json:"order_id"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+func (OrderPaid) EventType() string { return TypeOrderPaid }
+func (OrderPaid) EventVersion() int { return 1 }
+
+// PaymentSucceeded is published by the payment service after charging.
+type PaymentSucceeded struct {
+	PaymentID string  `json:"payment_id"`
+	OrderID   string  `json:"order_id"`
+	Amount    float64 `json:"amount"`
+}
+
+func (PaymentSucceeded) EventType() string { return TypePaymentSucceeded }
+func (PaymentSucceeded) EventVersion() int { return 1 }
+
+// PaymentRefunded is published when money is returned to the customer of an
+// order. Items allocates the amount to the refunded lines; a refund without
+// items returns the whole payment.
+type PaymentRefunded struct {
+	RefundID  string         `json:"refund_id"`
+	OrderID   string         `json:"order_id"`
+	PaymentID string         `json:"payment_id,omitempty"`
+	Amount    float64        `json:"amount"`
+	Items     []RefundedItem `json:"items,omitempty"`
+}
+
+func (PaymentRefunded) EventType() string { return TypePaymentRefunded }
+func (PaymentRefunded) EventVersion() int { return 1 }
+
+// RefundedItem is the part of a refund returned for one order line.
+type RefundedItem struct {
+	ProductID string  `json:"product_id"`
+	SellerID  string  `json:"seller_id"`
+	Amount    float64 `json:"amount"`
+}
+
+// ReturnApproved is published by the order service when staff approve a
+// return. The delivery service schedules the pickup of the items.
+type ReturnApproved struct {
+	ReturnID      string         `json:"return_id"`
+	OrderID       string         `json:"order_id"`
+	Items         []ReturnedItem `json:"items"`
+	PickupAddress PickupAddress  `json:"pickup_address"`
+}
+
+func (ReturnApproved) EventType() string { return TypeReturnApproved }
+func (ReturnApproved) EventVersion() int { return 1 }
+
+// ReturnedItem is a quantity of one order line being returned.
+type ReturnedItem struct {
+	ProductID string `json:"product_id"`
+	SellerID  string `json:"seller_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+// PickupAddress is where returned items are collected.
+type PickupAddress struct {
+	FullName     string `json:"full_name"`
+	Phone        string `json:"phone"`
+	Country      string `json:"country"`
+	Region       string `json:"region,omitempty"`
+	City         string `json:"city"`
+	Street       string `json:"street"`
+	AddressLine2 string `json:"address_line2,omitempty"`
+	PostalCode   string `json:"postal_code,omitempty"`
+}
+
+// ReturnPickedUp is published by the delivery service once the items of a
+// return are collected.
+type ReturnPickedUp struct {
+	ReturnID string `json:"return_id"`
+	OrderID  string `json:"order_id"`
+}
+
+func (ReturnPickedUp) EventType() string { return TypeReturnPickedUp }
+func (ReturnPickedUp) EventVersion() int { return 1 }
+
+// RefundRequested is published by the order service to have the payment
+// service refund part of an order. The payment service answers with a
+// PaymentRefunded carrying the same RefundID.
+type RefundRequested struct {
+	RefundID string         `json:"refund_id"`
+	OrderID  string         `json:"order_id"`
+	Amount   float64        `json:"amount"`
+	Currency string         `json:"currency,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+	Items    []RefundedItem `json:"items"`
+}
+
+func (RefundRequested) EventType() string { return TypeRefundRequested }
+func (RefundRequested) EventVersion() int { return 1 }
+
+// OrderDelivered is published by the delivery service.
+type OrderDelivered struct {
+	OrderID string `json:"order_id"`
+	Status  string `json:"status"`
+}
+
+func (OrderDelivered) EventType() string { return TypeOrderDelivered }
+func (OrderDelivered) EventVersion() int { return 1 }
+
+// OrderStatusChanged is published by the order service for every recorded
+// status transition. Replicas consume it to push changes to watchers.
+// Sequence orders the transitions of all orders and lets watchers resume.
+type OrderStatusChanged struct {
+	OrderID   string    `json:"order_id"`
+	UserID    string    `json:"user_id"`
+	Status    string    `json:"status"`
+	Sequence  int64     `json:"sequence"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func (OrderStatusChanged) EventType() string { return TypeOrderStatusChanged }
+func (OrderStatusChanged) EventVersion() int { return 1 }
+
+// UserDeleted is to be published by the user service when an account is
+// deleted; it does not publish it yet. Services keeping personal data of the
+// user erase it.
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+}
+
+func (UserDeleted) EventType() string { return TypeUserDeleted }
+func (UserDeleted) EventVersion() int { return 1 }
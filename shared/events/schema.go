@@ -0,0 +1,44 @@
+package events
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+var schemaName = regexp.MustCompile(`^(.+)\.v(\d+)\.json$`)
+
+// Schema returns the JSON Schema for an event type and version. The envelope
+// schema is available under the type "envelope".
+func Schema(eventType string, version int) ([]byte, error) {
+	b, err := schemaFS.ReadFile(fmt.Sprintf("schemas/%s.v%d.json", eventType, version))
+	if err != nil {
+		return nil, fmt.Errorf("events: no schema for %s v%d", eventType, version)
+	}
+	return b, nil
+}
+
+// SchemaVersions lists the published schema versions of each event type in
+// ascending order.
+func SchemaVersions() map[string][]int {
+	out := map[string][]int{}
+	entries, _ := fs.ReadDir(schemaFS, "schemas")
+	for _, e := range entries {
+		m := schemaName.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		v, _ := strconv.Atoi(m[2])
+		out[m[1]] = append(out[m[1]], v)
+	}
+	for _, versions := range out {
+		sort.Ints(versions)
+	}
+	return out
+}
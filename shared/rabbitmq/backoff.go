@@ -0,0 +1,31 @@
+package rabbitmq
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes exponential reconnection delays.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Next doubles d, capped at Max.
+func (b Backoff) Next(d time.Duration) time.Duration {
+	d *= 2
+	if d > b.Max || d <= 0 {
+		return b.Max
+	}
+	return d
+}
+
+// Jitter returns a random delay in [d/2, d) so that many replicas losing the
+// broker at once do not reconnect in lockstep.
+func (b Backoff) Jitter(d time.Duration) time.Duration {
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half)
+}
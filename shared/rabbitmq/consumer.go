@@ -0,0 +1,300 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Handler processes one delivery. Returning nil acknowledges the message;
+// returning an error retries it, unless the error is wrapped with Permanent,
+// in which case the message is not retried.
+type Handler func(ctx context.Context, d amqp.Delivery) error
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying, e.g. a malformed message.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// RetryCountHeader counts the times a message was retried.
+const RetryCountHeader = "x-retry-count"
+
+// routingKeyHeader keeps the routing key of a message Consume requeued
+// through the default exchange, which routes by queue name.
+const routingKeyHeader = "x-routing-key"
+
+// Retry sets how a consumer retries deliveries failing with a transient
+// error: Max times, Delay apart. Messages that still fail, or fail with a
+// permanent error, are moved to the dead-letter queue "<queue>.dead".
+//
+// A failed message is republished to the retry queue "<queue>.retry" with
+// Delay as its expiration, so Delay can change between deployments without
+// redeclaring the queue. Once expired it returns to the queue through the
+// "<queue>.requeue" exchange, keeping its routing key. The retry count
+// travels in the RetryCountHeader header.
+type Retry struct {
+	Max   int
+	Delay time.Duration
+}
+
+type consumer struct {
+	queue    string
+	prefetch int
+	retry    *Retry
+	handler  Handler
+	// publish republishes a failed delivery and waits for the broker to
+	// confirm it; set per channel, on a copy of the consumer, by start.
+	publish func(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error
+}
+
+// consumption is a consumer running on its own channel, with notifications
+// of the channel closing and of the broker cancelling the consumer.
+type consumption struct {
+	ch       *amqp.Channel
+	closed   chan *amqp.Error
+	canceled chan string
+}
+
+// Consume registers handler for queue. The queue itself must be declared by
+// a Topology. Consumers are (re)started on every connection, each on its own
+// channel with manual acknowledgements. A delivery that fails is requeued
+// once, counted in the RetryCountHeader header, and dropped when it fails
+// again; see ConsumeWithRetry.
+func (m *Manager) Consume(queue string, prefetch int, handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consumers = append(m.consumers, &consumer{queue: queue, prefetch: prefetch, handler: handler})
+}
+
+// ConsumeWithRetry is Consume with failed deliveries retried and then
+// dead-lettered according to retry. It declares the retry and dead-letter
+// queues of queue; register it after the topology declaring queue.
+func (m *Manager) ConsumeWithRetry(queue string, prefetch int, retry Retry, handler Handler) {
+	m.DeclareTopology(func(ch *amqp.Channel) error {
+		return declareRetry(ch, queue)
+	})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consumers = append(m.consumers, &consumer{queue: queue, prefetch: prefetch, retry: &retry, handler: handler})
+}
+
+// declareRetry declares the fanout exchanges and queues a failed message of
+// queue passes through: "<queue>.retry", "<queue>.requeue" back to queue, and
+// "<queue>.dead". The retry queue has no TTL of its own: messages carry their
+// expiration.
+func declareRetry(ch *amqp.Channel, queue string) error {
+	retry, requeue, dead := queue+".retry", queue+".requeue", queue+".dead"
+	for _, name := range []string{retry, requeue, dead} {
+		if err := ch.ExchangeDeclare(name, "fanout", true, false, false, false, nil); err != nil {
+			return err
+		}
+	}
+	_, err := ch.QueueDeclare(retry, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange": requeue,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := ch.QueueDeclare(dead, true, false, false, false, nil); err != nil {
+		return err
+	}
+	for q, exchange := range map[string]string{retry: retry, queue: requeue, dead: dead} {
+		if err := ch.QueueBind(q, "", exchange, false, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start consumes on a new channel of conn. The notifications are registered
+// before consuming, so a channel closed or cancelled right away is reported.
+func (c *consumer) start(ctx context.Context, conn *amqp.Connection) (*consumption, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	run := &consumption{
+		ch:       ch,
+		closed:   ch.NotifyClose(make(chan *amqp.Error, 1)),
+		canceled: ch.NotifyCancel(make(chan string, 1)),
+	}
+	if c.prefetch > 0 {
+		if err := ch.Qos(c.prefetch, 0, false); err != nil {
+			ch.Close()
+			return nil, err
+		}
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, err
+	}
+	onChannel := *c
+	onChannel.publish = func(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+		confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, msg)
+		if err != nil {
+			return err
+		}
+		acked, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !acked {
+			return ErrNacked
+		}
+		return nil
+	}
+	msgs, err := ch.Consume(
+		c.queue, // queue
+		"",      // consumer
+		false,   // auto-ack
+		false,   // exclusive
+		false,   // no-local
+		false,   // no-wait
+		nil,     // args
+	)
+	if err != nil {
+		ch.Close()
+		return nil, err
+	}
+
+	go func() {
+		// The deliveries channel is closed when the channel or connection
+		// drops; the manager starts a new loop once it restores the consumer.
+		for d := range msgs {
+			onChannel.handle(ctx, d)
+		}
+	}()
+	return run, nil
+}
+
+func (c *consumer) handle(ctx context.Context, d amqp.Delivery) {
+	if key, ok := d.Headers[routingKeyHeader].(string); ok {
+		d.RoutingKey = key
+	}
+	err := c.handler(ctx, d)
+	switch {
+	case err == nil:
+		if ackErr := d.Ack(false); ackErr != nil {
+			log.Printf("Failed to ack %s message: %v", d.RoutingKey, ackErr)
+		}
+	case c.retry != nil:
+		c.retryOrDeadLetter(ctx, d, err)
+	case IsPermanent(err):
+		log.Printf("Dropping %s message: %v", d.RoutingKey, err)
+		_ = d.Reject(false)
+	default:
+		c.requeueOrDrop(ctx, d, err)
+	}
+}
+
+// requeueOrDrop republishes a delivery that failed for the first time to the
+// queue, through the default exchange, and acknowledges it; a delivery that
+// failed before is dropped. Attempts are counted in the RetryCountHeader
+// header rather than taken from Redelivered, which the broker also sets on
+// deliveries that were in flight when a connection or channel closed. If
+// republishing fails, the delivery is requeued as is.
+func (c *consumer) requeueOrDrop(ctx context.Context, d amqp.Delivery, err error) {
+	if retryCount(d) > 0 {
+		log.Printf("Dropping %s message after it failed again: %v", d.RoutingKey, err)
+		_ = d.Reject(false)
+		return
+	}
+	log.Printf("Failed to handle %s message, requeueing: %v", d.RoutingKey, err)
+	msg := republished(d, 1)
+	msg.Headers[routingKeyHeader] = d.RoutingKey
+	if pubErr := c.publish(ctx, "", c.queue, msg); pubErr != nil {
+		log.Printf("Failed to requeue %s message: %v", d.RoutingKey, pubErr)
+		_ = d.Nack(false, true)
+		return
+	}
+	if ackErr := d.Ack(false); ackErr != nil {
+		log.Printf("Failed to ack %s message: %v", d.RoutingKey, ackErr)
+	}
+}
+
+// retryOrDeadLetter republishes a failed delivery to the retry queue, or to
+// the dead-letter queue once it is permanent or out of retries, and then
+// acknowledges it. If republishing fails, it is requeued.
+func (c *consumer) retryOrDeadLetter(ctx context.Context, d amqp.Delivery, err error) {
+	retries := retryCount(d)
+	exchange := c.queue + ".retry"
+	switch {
+	case IsPermanent(err):
+		log.Printf("Dead-lettering %s message: %v", d.RoutingKey, err)
+		exchange = c.queue + ".dead"
+	case retries >= c.retry.Max:
+		log.Printf("Dead-lettering %s message after %d retries: %v", d.RoutingKey, retries, err)
+		exchange = c.queue + ".dead"
+	default:
+		log.Printf("Failed to handle %s message, retrying in %s (retry %d of %d): %v", d.RoutingKey, c.retry.Delay, retries+1, c.retry.Max, err)
+		retries++
+	}
+	msg := republished(d, retries)
+	if exchange == c.queue+".retry" {
+		msg.Expiration = strconv.FormatInt(c.retry.Delay.Milliseconds(), 10)
+	}
+	if pubErr := c.publish(ctx, exchange, d.RoutingKey, msg); pubErr != nil {
+		log.Printf("Failed to move %s message to %s, requeueing: %v", d.RoutingKey, exchange, pubErr)
+		_ = d.Nack(false, true)
+		return
+	}
+	if ackErr := d.Ack(false); ackErr != nil {
+		log.Printf("Failed to ack %s message: %v", d.RoutingKey, ackErr)
+	}
+}
+
+// retryCount returns the RetryCountHeader of d, 0 when absent.
+func retryCount(d amqp.Delivery) int {
+	switch n := d.Headers[RetryCountHeader].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+// republished copies d, with its headers and properties, for publishing
+// again with the given retry count. The user ID is left out, as the broker
+// checks it against the connection's user.
+func republished(d amqp.Delivery, retries int) amqp.Publishing {
+	headers := make(amqp.Table, len(d.Headers)+1)
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[RetryCountHeader] = int32(retries)
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     d.ContentType,
+		ContentEncoding: d.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		Priority:        d.Priority,
+		CorrelationId:   d.CorrelationId,
+		ReplyTo:         d.ReplyTo,
+		MessageId:       d.MessageId,
+		Timestamp:       d.Timestamp,
+		Type:            d.Type,
+		AppId:           d.AppId,
+		Body:            d.Body,
+	}
+}
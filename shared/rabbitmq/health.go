@@ -0,0 +1,20 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HealthHandler reports the connection state as JSON, answering 503 while
+// the broker is unreachable so orchestrators can take the replica out of
+// rotation.
+func HealthHandler(m *Manager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		state := m.State()
+		w.Header().Set("Content-Type", "application/json")
+		if state != StateConnected {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"rabbitmq": state.String()})
+	})
+}
@@ -0,0 +1,378 @@
+// Package rabbitmq provides a shared AMQP connection manager that survives
+// broker restarts. It reconnects with exponential backoff and jitter,
+// re-declares the registered topology and restores registered consumers
+// every time a connection is established, and restores a single consumer
+// whose channel the broker closes.
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// State describes the connection as seen by health checks.
+type State int32
+
+const (
+	StateConnecting State = iota
+	StateConnected
+	StateClosed
+)
+
+func (s State) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("State(%d)", int32(s))
+}
+
+// ErrClosed is returned by Channel once the manager has been closed.
+var ErrClosed = errors.New("rabbitmq: connection manager closed")
+
+// Topology declares exchanges, queues and bindings on a fresh channel. It is
+// run after every (re)connection, so it must be idempotent.
+type Topology func(ch *amqp.Channel) error
+
+// Manager owns a single AMQP connection and a publishing channel.
+type Manager struct {
+	cfg     config.RabbitMQ
+	backoff Backoff
+
+	mu         sync.Mutex
+	conn       *amqp.Connection
+	channel    *amqp.Channel
+	state      State
+	ready      chan struct{} // closed while connected
+	topologies []Topology
+	consumers  []*consumer
+	listeners  []func(State)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewManager creates a manager for the configured broker. Nothing is dialed
+// until Start is called.
+func NewManager(cfg config.RabbitMQ) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		cfg:     cfg,
+		backoff: Backoff{Initial: cfg.InitialBackoff, Max: cfg.MaxBackoff},
+		state:   StateConnecting,
+		ready:   make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+}
+
+// DeclareTopology registers t to run on every connection. Topologies added
+// after Start are applied from the next reconnection on, so register them
+// before starting.
+func (m *Manager) DeclareTopology(t Topology) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.topologies = append(m.topologies, t)
+}
+
+// OnStateChange registers fn to be called with the current state and on
+// every change. fn runs with the manager locked and must not call back into it.
+func (m *Manager) OnStateChange(fn func(State)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, fn)
+	fn(m.state)
+}
+
+// Start connects to the broker, giving up after cfg.ConnectRetries failed
+// attempts, and then keeps the connection alive in the background until
+// Close is called.
+func (m *Manager) Start() error {
+	var err error
+	delay := m.backoff.Initial
+	for attempt := 1; attempt <= m.cfg.ConnectRetries; attempt++ {
+		if err = m.connect(); err == nil {
+			go m.watch()
+			return nil
+		}
+		if attempt == m.cfg.ConnectRetries {
+			break
+		}
+		wait := m.backoff.Jitter(delay)
+		log.Printf("Failed to connect to RabbitMQ (attempt %d/%d): %v. Retrying in %s...", attempt, m.cfg.ConnectRetries, err, wait.Round(time.Millisecond))
+		select {
+		case <-time.After(wait):
+		case <-m.ctx.Done():
+			return ErrClosed
+		}
+		delay = m.backoff.Next(delay)
+	}
+	return fmt.Errorf("rabbitmq: could not connect after %d attempts: %w", m.cfg.ConnectRetries, err)
+}
+
+// State returns the current connection state.
+func (m *Manager) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Healthy reports whether the broker connection is currently established.
+func (m *Manager) Healthy() bool {
+	return m.State() == StateConnected
+}
+
+// Channel returns the shared publishing channel, waiting for a connection if
+// the manager is reconnecting.
+func (m *Manager) Channel(ctx context.Context) (*amqp.Channel, error) {
+	for {
+		m.mu.Lock()
+		state, ch, ready := m.state, m.channel, m.ready
+		m.mu.Unlock()
+
+		switch state {
+		case StateConnected:
+			return ch, nil
+		case StateClosed:
+			return nil, ErrClosed
+		}
+		select {
+		case <-ready:
+		case <-m.ctx.Done():
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, fmt.Errorf("rabbitmq: waiting for connection: %w", ctx.Err())
+		}
+	}
+}
+
+// openChannel opens a new channel on the current connection, waiting for a
+// connection if the manager is reconnecting.
+func (m *Manager) openChannel(ctx context.Context) (*amqp.Channel, error) {
+	if _, err := m.Channel(ctx); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	conn := m.conn
+	m.mu.Unlock()
+	return conn.Channel()
+}
+
+// Close stops reconnecting and closes the connection. Running consumers
+// finish their current delivery and stop.
+func (m *Manager) Close() error {
+	m.cancel()
+	m.mu.Lock()
+	conn := m.conn
+	started := conn != nil
+	m.setState(StateClosed)
+	m.mu.Unlock()
+
+	if conn != nil {
+		_ = conn.Close()
+	}
+	if started {
+		<-m.done
+	}
+	return nil
+}
+
+// connect dials the broker, declares the topology on the publishing channel
+// and restores consumers. On failure everything opened so far is closed.
+func (m *Manager) connect() error {
+	conn, err := amqp.Dial(m.cfg.URL)
+	if err != nil {
+		return err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	m.mu.Lock()
+	topologies := append([]Topology(nil), m.topologies...)
+	consumers := append([]*consumer(nil), m.consumers...)
+	m.mu.Unlock()
+
+	for _, declare := range topologies {
+		if err := declare(ch); err != nil {
+			conn.Close()
+			return fmt.Errorf("declaring topology: %w", err)
+		}
+	}
+	runs := make([]*consumption, len(consumers))
+	for i, c := range consumers {
+		run, err := c.start(m.ctx, conn)
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("starting consumer on %s: %w", c.queue, err)
+		}
+		runs[i] = run
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == StateClosed {
+		conn.Close()
+		return ErrClosed
+	}
+	m.conn, m.channel = conn, ch
+	m.setState(StateConnected)
+	for i, c := range consumers {
+		go m.supervise(c, conn, runs[i])
+	}
+	return nil
+}
+
+// supervise restores c while conn stays up when the broker closes its
+// channel, e.g. after a channel error, or cancels it, e.g. after its queue
+// was deleted: it re-declares the topology and starts c on a new channel,
+// with backoff. A lost connection is left to watch, which restores every
+// consumer.
+func (m *Manager) supervise(c *consumer, conn *amqp.Connection, run *consumption) {
+	for {
+		var reason any
+		select {
+		case <-m.ctx.Done():
+			return
+		case err, ok := <-run.closed:
+			if !ok {
+				return // closed by the client
+			}
+			reason = err
+		case tag, ok := <-run.canceled:
+			if !ok {
+				run.canceled = nil // the channel closed; wait for its reason
+				continue
+			}
+			reason = fmt.Sprintf("consumer %q cancelled by the broker", tag)
+			_ = run.ch.Close()
+		}
+		// The connection is marked closed before its channels are.
+		if conn.IsClosed() || m.ctx.Err() != nil {
+			return
+		}
+		log.Printf("RabbitMQ consumer on %s stopped: %v. Restoring...", c.queue, reason)
+
+		delay := m.backoff.Initial
+		for attempt := 1; ; attempt++ {
+			next, err := m.restore(c, conn)
+			if err == nil {
+				log.Printf("Restored consumer on %s after %d attempt(s)", c.queue, attempt)
+				run = next
+				break
+			}
+			if conn.IsClosed() {
+				return
+			}
+			log.Printf("Failed to restore consumer on %s (attempt %d): %v", c.queue, attempt, err)
+			select {
+			case <-time.After(m.backoff.Jitter(delay)):
+			case <-m.ctx.Done():
+				return
+			}
+			delay = m.backoff.Next(delay)
+		}
+	}
+}
+
+// restore re-declares the topology on a channel of its own, as what stopped
+// c may have been a missing queue, and starts c again on conn.
+func (m *Manager) restore(c *consumer, conn *amqp.Connection) (*consumption, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	topologies := append([]Topology(nil), m.topologies...)
+	m.mu.Unlock()
+	for _, declare := range topologies {
+		if err := declare(ch); err != nil {
+			_ = ch.Close()
+			return nil, fmt.Errorf("declaring topology: %w", err)
+		}
+	}
+	_ = ch.Close()
+	return c.start(m.ctx, conn)
+}
+
+// watch waits for the connection or publishing channel to close and
+// reconnects with exponential backoff until the manager is closed.
+func (m *Manager) watch() {
+	defer close(m.done)
+	for {
+		m.mu.Lock()
+		conn, ch := m.conn, m.channel
+		m.mu.Unlock()
+
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+		var reason *amqp.Error
+		select {
+		case <-m.ctx.Done():
+			return
+		case reason = <-connClosed:
+		case reason = <-chClosed:
+		}
+		if m.ctx.Err() != nil {
+			return
+		}
+		log.Printf("RabbitMQ connection lost: %v. Reconnecting...", reason)
+		_ = conn.Close()
+
+		m.mu.Lock()
+		m.setState(StateConnecting)
+		m.mu.Unlock()
+
+		delay := m.backoff.Initial
+		for attempt := 1; ; attempt++ {
+			wait := m.backoff.Jitter(delay)
+			select {
+			case <-time.After(wait):
+			case <-m.ctx.Done():
+				return
+			}
+			err := m.connect()
+			if err == nil {
+				log.Printf("Reconnected to RabbitMQ after %d attempt(s)", attempt)
+				break
+			}
+			if errors.Is(err, ErrClosed) {
+				return
+			}
+			log.Printf("Failed to reconnect to RabbitMQ (attempt %d): %v", attempt, err)
+			delay = m.backoff.Next(delay)
+		}
+	}
+}
+
+// setState must be called with m.mu held.
+func (m *Manager) setState(s State) {
+	if m.state == s {
+		return
+	}
+	if s == StateConnected {
+		close(m.ready)
+	} else if m.state == StateConnected {
+		m.ready = make(chan struct{})
+	}
+	m.state = s
+	for _, fn := range m.listeners {
+		fn(s)
+	}
+}
@@ -0,0 +1,168 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Asfm445/Distributed_EcommerceProject/shared/events"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// ErrUnroutable means the broker returned a mandatory message because no
+	// queue is bound for its routing key.
+	ErrUnroutable = errors.New("rabbitmq: message unroutable")
+	// ErrNacked means the broker refused responsibility for the message.
+	ErrNacked = errors.New("rabbitmq: message nacked by broker")
+	// ErrConfirmTimeout means no confirmation arrived in time. The message
+	// may or may not have been stored; callers should retry idempotently.
+	ErrConfirmTimeout = errors.New("rabbitmq: timed out waiting for publisher confirm")
+)
+
+// Publisher publishes mandatory messages on a dedicated channel in confirm
+// mode and waits for the broker's verdict, so that a nil error means the
+// message was routed to at least one queue and accepted.
+//
+// Publishes are serialized on the channel. Combined with the broker sending
+// basic.return before basic.ack, this lets Publish attribute a return to the
+// message it is waiting for.
+type Publisher struct {
+	conn    *Manager
+	timeout time.Duration
+	format  events.Format
+
+	mu      sync.Mutex
+	channel *amqp.Channel
+	returns chan amqp.Return
+}
+
+// NewPublisher creates a publisher that waits up to confirmTimeout for each
+// confirmation and lays out events in the given format.
+func NewPublisher(conn *Manager, confirmTimeout time.Duration, format events.Format) *Publisher {
+	return &Publisher{conn: conn, timeout: confirmTimeout, format: format}
+}
+
+// Publish sends msg with mandatory routing and blocks until it is confirmed.
+// It returns ErrUnroutable, ErrNacked or ErrConfirmTimeout (wrapped) when
+// the broker did not take the message.
+func (p *Publisher) Publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ch, err := p.ensureChannel(ctx)
+	if err != nil {
+		return err
+	}
+	if msg.MessageId == "" {
+		msg.MessageId = uuid.NewString()
+	}
+	p.drainReturns()
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx,
+		exchange,   // exchange
+		routingKey, // routing key
+		true,       // mandatory
+		false,      // immediate
+		msg)
+	if err != nil {
+		return fmt.Errorf("rabbitmq: publishing %s: %w", routingKey, err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	acked, err := confirm.WaitContext(waitCtx)
+	if err != nil {
+		return fmt.Errorf("%w: %s (%v)", ErrConfirmTimeout, routingKey, err)
+	}
+	if !acked {
+		return fmt.Errorf("%w: %s", ErrNacked, routingKey)
+	}
+
+	// A return for this message, if any, was delivered before the ack.
+	for {
+		select {
+		case r := <-p.returns:
+			if r.MessageId == msg.MessageId {
+				return fmt.Errorf("%w: exchange %q, routing key %q: %s", ErrUnroutable, exchange, routingKey, r.ReplyText)
+			}
+			continue
+		default:
+		}
+		return nil
+	}
+}
+
+// ensureChannel opens a confirm-mode channel on the current connection,
+// replacing one that was closed by a reconnection or a channel error.
+func (p *Publisher) ensureChannel(ctx context.Context) (*amqp.Channel, error) {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return p.channel, nil
+	}
+	ch, err := p.conn.openChannel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("rabbitmq: enabling confirm mode: %w", err)
+	}
+	p.returns = ch.NotifyReturn(make(chan amqp.Return, 16))
+	p.channel = ch
+	return ch, nil
+}
+
+// drainReturns discards returns left over from publishes that timed out.
+func (p *Publisher) drainReturns() {
+	for {
+		select {
+		case <-p.returns:
+		default:
+			return
+		}
+	}
+}
+
+// Close closes the publisher's channel.
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.channel != nil {
+		p.channel.Close()
+		p.channel = nil
+	}
+}
+
+// PublishEvent publishes env as a persistent message routed by its type,
+// encoded in the publisher's format, copying the envelope identity into the
+// AMQP properties.
+func (p *Publisher) PublishEvent(ctx context.Context, env events.Envelope) error {
+	msg, err := events.Encode(env, p.format)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, events.Exchange, env.Type, amqp.Publishing{
+		ContentType:   msg.ContentType,
+		Headers:       amqp.Table(msg.Headers),
+		DeliveryMode:  amqp.Persistent,
+		MessageId:     env.EventID,
+		CorrelationId: env.CorrelationID,
+		Type:          env.Type,
+		AppId:         env.Producer,
+		Timestamp:     env.OccurredAt,
+		Body:          msg.Body,
+	})
+}
+
+// DecodeEvent decodes a delivery in any format accepted by
+// events.DecodeMessage into dst.
+func DecodeEvent(d amqp.Delivery, dst events.Payload) (events.Envelope, error) {
+	return events.DecodeMessage(events.Message{
+		ContentType: d.ContentType,
+		Headers:     d.Headers,
+		Body:        d.Body,
+	}, dst)
+}